@@ -8,13 +8,14 @@ import (
 )
 
 type AddToyInput struct {
-	CategoryID  string            `json:"categoryId"`
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Price       float64           `json:"price"`
-	Quantity    int               `json:"quantity"`
-	Tags        []string          `json:"tags,omitempty"`
-	Attachments []*graphql.Upload `json:"attachments,omitempty"`
+	CategoryID          string            `json:"categoryId"`
+	Name                string            `json:"name"`
+	Description         string            `json:"description"`
+	Price               float64           `json:"price"`
+	Quantity            int               `json:"quantity"`
+	Tags                []string          `json:"tags,omitempty"`
+	Attachments         []*graphql.Upload `json:"attachments,omitempty"`
+	AttachmentUploadIds []string          `json:"attachmentUploadIds,omitempty"`
 }
 
 type ChangePasswordInput struct {
@@ -23,13 +24,14 @@ type ChangePasswordInput struct {
 }
 
 type CreateTicketInput struct {
-	CategoryID  string            `json:"categoryId"`
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Price       *float64          `json:"price,omitempty"`
-	Quantity    int               `json:"quantity"`
-	Tags        []string          `json:"tags,omitempty"`
-	Attachments []*graphql.Upload `json:"attachments,omitempty"`
+	CategoryID          string            `json:"categoryId"`
+	Name                string            `json:"name"`
+	Description         string            `json:"description"`
+	Price               *float64          `json:"price,omitempty"`
+	Quantity            int               `json:"quantity"`
+	Tags                []string          `json:"tags,omitempty"`
+	Attachments         []*graphql.Upload `json:"attachments,omitempty"`
+	AttachmentUploadIds []string          `json:"attachmentUploadIds,omitempty"`
 }
 
 type DeleteRespondInput struct {
@@ -153,10 +155,11 @@ type UpdateToyInput struct {
 }
 
 type UpdateUserProfileInput struct {
-	DisplayName *string         `json:"displayName,omitempty"`
-	Phone       *string         `json:"phone,omitempty"`
-	Telegram    *string         `json:"telegram,omitempty"`
-	Avatar      *graphql.Upload `json:"avatar,omitempty"`
+	DisplayName    *string         `json:"displayName,omitempty"`
+	Phone          *string         `json:"phone,omitempty"`
+	Telegram       *string         `json:"telegram,omitempty"`
+	Avatar         *graphql.Upload `json:"avatar,omitempty"`
+	AvatarUploadID *string         `json:"avatarUploadId,omitempty"`
 }
 
 type UserTicketsInput struct {
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	graphqlcontroller "github.com/DKhorkov/hmtm-bff/internal/controllers/graphql"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+	"github.com/DKhorkov/hmtm-bff/internal/jobs"
+	"github.com/DKhorkov/hmtm-bff/internal/repositories"
+	"github.com/DKhorkov/hmtm-bff/internal/services"
+	"github.com/DKhorkov/hmtm-bff/internal/usecases"
+)
+
+// newApplication wires repositories, services, use cases and GraphQL controller on top of downstreams.
+// Returned background jobs include jobs of downstreams.
+func newApplication(
+	settings config.Config,
+	downstreamServices *downstreams,
+	logger logging.Logger,
+	traceProvider tracing.Provider,
+	registerer prometheus.Registerer,
+) (*graphqlcontroller.Controller, []interfaces.Job, error) {
+	redisClient := downstreamServices.redisClient
+	stateRedisClient := downstreamServices.stateRedisClient
+
+	ssoRepository := repositories.NewSsoRepository(downstreamServices.ssoClient)
+	ssoService := services.NewSsoService(ssoRepository, logger)
+
+	toysRepository := repositories.NewToysRepository(downstreamServices.toysClient)
+	toysService := services.NewToysService(toysRepository, logger)
+
+	storageUsageRepository := repositories.NewRedisStorageUsageRepository(stateRedisClient)
+	fileStorageService := services.NewFileStorageService(
+		downstreamServices.fileStorage,
+		storageUsageRepository,
+		logger,
+	)
+
+	ticketsRepository := repositories.NewTicketsRepository(downstreamServices.ticketsClient)
+	ticketsService := services.NewTicketsService(ticketsRepository, logger)
+
+	notificationsRepository := repositories.NewNotificationsRepository(downstreamServices.notificationsClient)
+	notificationsService := services.NewNotificationsService(notificationsRepository, logger)
+
+	compensationsRepository := repositories.NewRedisCompensationsRepository(stateRedisClient)
+	compensationsService := services.NewCompensationsService(
+		compensationsRepository,
+		fileStorageService,
+		logger,
+	)
+
+	uploadsRepository := repositories.NewRedisUploadsRepository(stateRedisClient, settings.Uploads.TTL)
+	uploadsService := services.NewUploadsService(uploadsRepository, logger)
+
+	cacheTagsRepository := repositories.NewRedisCacheTagsRepository(redisClient)
+	locksRepository := repositories.NewRedisLocksRepository(redisClient)
+	localCacheRepository := repositories.NewLRUCacheRepository(settings.Cache.Local.Size, settings.Cache.Local.TTL)
+
+	useCases, err := usecases.NewCacheDecorator(
+		usecases.New(
+			ssoService,
+			toysService,
+			fileStorageService,
+			ticketsService,
+			notificationsService,
+			compensationsService,
+			uploadsService,
+			settings.Validation,
+			logger,
+			traceProvider,
+		),
+		downstreamServices.cacheProvider,
+		localCacheRepository,
+		cacheTagsRepository,
+		locksRepository,
+		settings.Cache,
+		logger,
+		registerer,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backgroundJobs := downstreamServices.jobs
+
+	readinessChecks := map[string]interfaces.ReadinessCheck{
+		"cache": useCases,
+	}
+
+	if settings.Cache.Warmup.Enabled {
+		var cacheWarmer *jobs.CacheWarmer
+
+		cacheWarmer, err = jobs.NewCacheWarmer(
+			useCases,
+			settings.Cache.Warmup,
+			logger,
+			registerer,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backgroundJobs = append(backgroundJobs, cacheWarmer)
+		readinessChecks["cache_warmup"] = cacheWarmer
+	}
+
+	controller, err := graphqlcontroller.New(
+		settings.HTTP,
+		settings.CORS,
+		settings.Cookies,
+		settings.Uploads,
+		useCases,
+		logger,
+		traceProvider,
+		settings.Tracing,
+		readinessChecks,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if settings.Cache.Local.Enabled {
+		backgroundJobs = append(
+			backgroundJobs,
+			jobs.NewCacheInvalidationsListener(cacheTagsRepository, localCacheRepository),
+		)
+	}
+
+	if settings.FilesGC.Enabled {
+		var filesGarbageCollector *jobs.FilesGarbageCollector
+
+		filesGarbageCollector, err = jobs.NewFilesGarbageCollector(
+			fileStorageService,
+			ssoService,
+			toysService,
+			ticketsService,
+			locksRepository,
+			settings.FilesGC,
+			logger,
+			registerer,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backgroundJobs = append(backgroundJobs, filesGarbageCollector)
+	}
+
+	if settings.Compensations.RetryEnabled {
+		var compensationsRetrier *jobs.CompensationsRetrier
+
+		compensationsRetrier, err = jobs.NewCompensationsRetrier(
+			compensationsService,
+			fileStorageService,
+			ssoService,
+			toysService,
+			ticketsService,
+			settings.Compensations,
+			logger,
+			registerer,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backgroundJobs = append(backgroundJobs, compensationsRetrier)
+	}
+
+	if settings.Migrations.PrivateTicketAttachments {
+		backgroundJobs = append(
+			backgroundJobs,
+			jobs.NewTicketAttachmentsMigration(fileStorageService, ticketsService, settings.Migrations, logger),
+		)
+	}
+
+	return controller, backgroundJobs, nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/DKhorkov/libs/cache"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/tracing"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/DKhorkov/hmtm-bff/internal/clients/interceptors"
+	notificationsgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/notifications/grpc"
+	ssogrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/sso/grpc"
+	ticketsgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/tickets/grpc"
+	toysgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/toys/grpc"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/fakes"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+	"github.com/DKhorkov/hmtm-bff/internal/repositories"
+)
+
+const (
+	// grpcMode uses real downstream services, Redis and S3:
+	grpcMode = "grpc"
+
+	// fakeMode uses in-memory downstream services with seeded data, embedded Redis and local disk
+	// instead of S3, so that BFF could be run without any infrastructure:
+	fakeMode = "fake"
+)
+
+// downstreams are dependencies of BFF, which are replaced in fake mode.
+type downstreams struct {
+	ssoClient           interfaces.SsoClient
+	toysClient          interfaces.ToysClient
+	ticketsClient       interfaces.TicketsClient
+	notificationsClient interfaces.NotificationsClient
+	fileStorage         interfaces.FileStorageRepository
+	redisClient         *redis.Client // Redis of cache, its tags and locks
+	stateRedisClient    *redis.Client // Persisted Redis of storage usage, compensations and uploads
+	cacheProvider       cache.Provider
+	jobs                []interfaces.Job
+	closers             []func() error
+}
+
+// Close releases resources of downstreams. Errors are only logged, because it is called on shutdown.
+func (d *downstreams) Close(logger logging.Logger) {
+	for _, closer := range d.closers {
+		if err := closer(); err != nil {
+			logging.LogError(logger, "Error closing downstream", err)
+		}
+	}
+}
+
+func newDownstreams(
+	mode string,
+	settings config.Config,
+	logger logging.Logger,
+	traceProvider tracing.Provider,
+) (*downstreams, error) {
+	switch mode {
+	case grpcMode:
+		return newGRPCDownstreams(settings, logger, traceProvider)
+	case fakeMode:
+		return newFakeDownstreams(settings, logger)
+	default:
+		return nil, fmt.Errorf("unknown server mode %q", mode)
+	}
+}
+
+func newGRPCDownstreams(
+	settings config.Config,
+	logger logging.Logger,
+	traceProvider tracing.Provider,
+) (*downstreams, error) {
+	clientsMetrics, err := interceptors.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, err
+	}
+
+	ssoClient, err := ssogrpcclient.New(
+		settings.Clients.SSO,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.SSO,
+		clientsMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	toysClient, err := toysgrpcclient.New(
+		settings.Clients.Toys,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Toys,
+		clientsMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketsClient, err := ticketsgrpcclient.New(
+		settings.Clients.Tickets,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Tickets,
+		clientsMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationsClient, err := notificationsgrpcclient.New(
+		settings.Clients.Notifications,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Notifications,
+		clientsMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fileStorageRepository, err := repositories.NewS3FileStorageRepository(settings.S3, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheProvider, err := cache.New(
+		cache.WithHost(settings.Cache.Host),
+		cache.WithPort(settings.Cache.Port),
+		cache.WithPassword(settings.Cache.Password),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	redisClient := redis.NewClient(
+		&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", settings.Cache.Host, settings.Cache.Port),
+			Password: settings.Cache.Password,
+		},
+	)
+
+	// State is lost, if cache Redis is flushed, so it could be kept in separate persisted Redis:
+	stateRedisClient := redis.NewClient(
+		&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", settings.State.Host, settings.State.Port),
+			Password: settings.State.Password,
+			DB:       settings.State.DB,
+		},
+	)
+
+	return &downstreams{
+		ssoClient:           ssoClient,
+		toysClient:          toysClient,
+		ticketsClient:       ticketsClient,
+		notificationsClient: notificationsClient,
+		fileStorage:         fileStorageRepository,
+		redisClient:         redisClient,
+		stateRedisClient:    stateRedisClient,
+		cacheProvider:       cacheProvider,
+		closers:             []func() error{redisClient.Close, stateRedisClient.Close},
+	}, nil
+}
+
+func newFakeDownstreams(settings config.Config, logger logging.Logger) (*downstreams, error) {
+	fileStorageRepository, err := repositories.NewLocalFileStorageRepository(
+		settings.Fake.FilesDir,
+		fakes.FilesURL(settings.Fake),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache, locks, uploads, compensations and cache tags rely on Redis commands and scripts, so embedded
+	// Redis is used for them:
+	embeddedRedis, err := miniredis.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheProvider, err := newEmbeddedCacheProvider(embeddedRedis)
+	if err != nil {
+		embeddedRedis.Close()
+
+		return nil, err
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: embeddedRedis.Addr()})
+	store := fakes.NewStore()
+
+	return &downstreams{
+		ssoClient:           fakes.NewSsoClient(store),
+		toysClient:          fakes.NewToysClient(store),
+		ticketsClient:       fakes.NewTicketsClient(store),
+		notificationsClient: fakes.NewNotificationsClient(store),
+		fileStorage:         fileStorageRepository,
+		redisClient:         redisClient,
+		stateRedisClient:    redisClient,
+		cacheProvider:       cacheProvider,
+		jobs:                []interfaces.Job{fakes.NewFilesServer(settings.Fake, logger)},
+		closers: []func() error{
+			redisClient.Close,
+			func() error {
+				embeddedRedis.Close()
+
+				return nil
+			},
+		},
+	}, nil
+}
+
+// newEmbeddedCacheProvider connects cache to embedded Redis, so that cached values and their tags are stored
+// together and invalidation of tags removes values.
+func newEmbeddedCacheProvider(embeddedRedis *miniredis.Miniredis) (cache.Provider, error) {
+	port, err := strconv.Atoi(embeddedRedis.Port())
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.New(
+		cache.WithHost(embeddedRedis.Host()),
+		cache.WithPort(port),
+	)
+}
@@ -0,0 +1,354 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/tracing"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/DKhorkov/hmtm-bff/internal/clients/interceptors"
+	notificationsgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/notifications/grpc"
+	ssogrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/sso/grpc"
+	ticketsgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/tickets/grpc"
+	toysgrpcclient "github.com/DKhorkov/hmtm-bff/internal/clients/toys/grpc"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/fakes"
+	"github.com/DKhorkov/hmtm-bff/internal/repositories"
+)
+
+const (
+	bufconnSize = 1024 * 1024
+
+	// Target is not resolved, because connections are created by bufconn dialer:
+	bufconnTarget = "passthrough:///bufconn"
+
+	testBucket = "hmtm-bff-test"
+	testRegion = "eu-central-1"
+)
+
+// harness runs BFF with real wiring of cmd/server against hermetic downstreams: in-process gRPC servers
+// of SSO, Toys, Tickets and Notifications over bufconn, embedded Redis and in-memory S3. Requests are sent
+// to GraphQL controller via HTTP by client, which keeps cookies between requests.
+type harness struct {
+	store  *fakes.Store
+	s3     *fakes.S3Client
+	server *httptest.Server
+	client *http.Client
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+// uploadFile is file of multipart GraphQL request, which is mapped to variable by path.
+type uploadFile struct {
+	path    string // For example "variables.input.attachments.0"
+	name    string
+	content []byte
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	traceProvider := noopTraceProvider{}
+	registry := prometheus.NewRegistry()
+	settings := harnessSettings()
+	store := fakes.NewStore()
+
+	clientsMetrics, err := interceptors.NewMetrics(registry)
+	require.NoError(t, err)
+
+	ssoListener := startGRPCServer(t, func(server *grpc.Server) {
+		fakes.RegisterSsoServer(server, fakes.NewSsoClient(store))
+	})
+
+	ssoClient, err := ssogrpcclient.New(
+		settings.Clients.SSO,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.SSO,
+		clientsMetrics,
+		bufconnDialer(ssoListener),
+	)
+	require.NoError(t, err)
+
+	toysListener := startGRPCServer(t, func(server *grpc.Server) {
+		fakes.RegisterToysServer(server, fakes.NewToysClient(store))
+	})
+
+	toysClient, err := toysgrpcclient.New(
+		settings.Clients.Toys,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Toys,
+		clientsMetrics,
+		bufconnDialer(toysListener),
+	)
+	require.NoError(t, err)
+
+	ticketsListener := startGRPCServer(t, func(server *grpc.Server) {
+		fakes.RegisterTicketsServer(server, fakes.NewTicketsClient(store))
+	})
+
+	ticketsClient, err := ticketsgrpcclient.New(
+		settings.Clients.Tickets,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Tickets,
+		clientsMetrics,
+		bufconnDialer(ticketsListener),
+	)
+	require.NoError(t, err)
+
+	notificationsListener := startGRPCServer(t, func(server *grpc.Server) {
+		fakes.RegisterNotificationsServer(server, fakes.NewNotificationsClient(store))
+	})
+
+	notificationsClient, err := notificationsgrpcclient.New(
+		settings.Clients.Notifications,
+		logger,
+		traceProvider,
+		settings.Tracing.Spans.Clients.Notifications,
+		clientsMetrics,
+		bufconnDialer(notificationsListener),
+	)
+	require.NoError(t, err)
+
+	s3Client := fakes.NewS3Client()
+	embeddedRedis := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: embeddedRedis.Addr()})
+
+	cacheProvider, err := newEmbeddedCacheProvider(embeddedRedis)
+	require.NoError(t, err)
+
+	downstreamServices := &downstreams{
+		ssoClient:           ssoClient,
+		toysClient:          toysClient,
+		ticketsClient:       ticketsClient,
+		notificationsClient: notificationsClient,
+		fileStorage: repositories.NewS3FileStorageRepositoryFromClients(
+			s3Client,
+			s3Client,
+			settings.S3,
+			logger,
+		),
+		redisClient:      redisClient,
+		stateRedisClient: redisClient,
+		cacheProvider:    cacheProvider,
+		closers:          []func() error{redisClient.Close},
+	}
+
+	t.Cleanup(func() { downstreamServices.Close(logger) })
+
+	controller, _, err := newApplication(settings, downstreamServices, logger, traceProvider, registry)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(controller.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	return &harness{
+		store:  store,
+		s3:     s3Client,
+		server: server,
+		client: &http.Client{Jar: jar, Timeout: 5 * time.Second},
+	}
+}
+
+// harnessSettings returns default settings with downstream clients, which connect via bufconn without TLS.
+// Background jobs are not started by harness, so features, which rely on them, are disabled.
+func harnessSettings() config.Config {
+	settings := config.New()
+
+	for _, clientConfig := range []*config.ClientConfig{
+		&settings.Clients.SSO,
+		&settings.Clients.Toys,
+		&settings.Clients.Tickets,
+		&settings.Clients.Notifications,
+	} {
+		clientConfig.TLS = config.TLSConfig{Insecure: true}
+		clientConfig.Balancing.Target = bufconnTarget
+		clientConfig.Balancing.HealthCheck = false
+	}
+
+	settings.S3.Bucket = testBucket
+	settings.S3.Region = testRegion
+	settings.S3.Timeout = time.Second
+	settings.Cache.Local.Enabled = false
+	settings.Cache.Warmup.Enabled = false
+	settings.FilesGC.Enabled = false
+	settings.Compensations.RetryEnabled = false
+
+	return settings
+}
+
+func startGRPCServer(t *testing.T, register func(server *grpc.Server)) *bufconn.Listener {
+	t.Helper()
+
+	listener := bufconn.Listen(bufconnSize)
+	server := grpc.NewServer()
+	register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener
+}
+
+func bufconnDialer(listener *bufconn.Listener) grpc.DialOption {
+	return grpc.WithContextDialer(
+		func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		},
+	)
+}
+
+// query sends GraphQL request as JSON.
+func (h *harness) query(t *testing.T, query string, variables map[string]any) graphqlResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	return h.send(t, "application/json", bytes.NewReader(body))
+}
+
+// upload sends GraphQL request with files according to multipart request specification:
+// https://github.com/jaydenseric/graphql-multipart-request-spec
+func (h *harness) upload(
+	t *testing.T,
+	query string,
+	variables map[string]any,
+	files ...uploadFile,
+) graphqlResponse {
+	t.Helper()
+
+	operations, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	filesMap := make(map[string][]string, len(files))
+	for i, file := range files {
+		filesMap[partName(i)] = []string{file.path}
+	}
+
+	encodedMap, err := json.Marshal(filesMap)
+	require.NoError(t, err)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("operations", string(operations)))
+	require.NoError(t, writer.WriteField("map", string(encodedMap)))
+
+	for i, file := range files {
+		part, err := writer.CreateFormFile(partName(i), file.name)
+		require.NoError(t, err)
+
+		_, err = part.Write(file.content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return h.send(t, writer.FormDataContentType(), body)
+}
+
+func (h *harness) send(t *testing.T, contentType string, body io.Reader) graphqlResponse {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, h.server.URL+"/query", body)
+	require.NoError(t, err)
+
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := h.client.Do(request)
+	require.NoError(t, err)
+
+	defer response.Body.Close()
+
+	var out graphqlResponse
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&out))
+
+	return out
+}
+
+// login logs in seeded user, so that following requests are authenticated by cookies.
+func (h *harness) login(t *testing.T, email string) {
+	t.Helper()
+
+	response := h.query(
+		t,
+		`mutation($input: LoginUserInput!) { loginUser(input: $input) }`,
+		map[string]any{"input": map[string]any{"email": email, "password": "password"}},
+	)
+	require.Empty(t, response.Errors)
+}
+
+// cookie returns cookie, which is stored by client for BFF.
+func (h *harness) cookie(t *testing.T, name string) *http.Cookie {
+	t.Helper()
+
+	serverURL, err := url.Parse(h.server.URL)
+	require.NoError(t, err)
+
+	for _, cookie := range h.client.Jar.Cookies(serverURL) {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+
+	return nil
+}
+
+func partName(i int) string {
+	return "file" + strconv.Itoa(i)
+}
+
+// noopTraceProvider creates non-recording spans, because traces are not exported in tests.
+type noopTraceProvider struct{}
+
+var _ tracing.Provider = noopTraceProvider{}
+
+func (noopTraceProvider) Span(
+	ctx context.Context,
+	_ string,
+	_ ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	return ctx, trace.SpanFromContext(ctx)
+}
+
+func (noopTraceProvider) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,310 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/fakes"
+)
+
+func TestIntegration_Queries(t *testing.T) {
+	h := newHarness(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		variables     map[string]any
+		expected      string
+		errorExpected bool
+	}{
+		{
+			name:     "category",
+			query:    `{ category(id: "2") { id name } }`,
+			expected: `{"category":{"id":2,"name":"Wooden toys"}}`,
+		},
+		{
+			name:      "toys counter with filters",
+			query:     `query($filters: ToysFilters) { toysCounter(filters: $filters) }`,
+			variables: map[string]any{"filters": map[string]any{"search": "wooden"}},
+			expected:  `{"toysCounter":2}`,
+		},
+		{
+			name:      "user by email with nested fields from several services",
+			query:     `query($email: String!) { userByEmail(email: $email) { email } }`,
+			variables: map[string]any{"email": "olga@example.com"},
+			expected:  `{"userByEmail":{"email":"olga@example.com"}}`,
+		},
+		{
+			name:          "not found",
+			query:         `{ toy(id: "1000") { id } }`,
+			expected:      `{"toy":null}`,
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := h.query(t, tc.query, tc.variables)
+			require.JSONEq(t, tc.expected, string(response.Data))
+
+			if tc.errorExpected {
+				require.NotEmpty(t, response.Errors)
+			} else {
+				require.Empty(t, response.Errors)
+			}
+		})
+	}
+}
+
+func TestIntegration_CookiesFlow(t *testing.T) {
+	h := newHarness(t)
+	meQuery := `{ me { email } }`
+
+	response := h.query(t, meQuery, nil)
+	require.NotEmpty(t, response.Errors)
+
+	h.login(t, "anna@example.com")
+	require.NotNil(t, h.cookie(t, "accessToken"))
+	require.NotNil(t, h.cookie(t, "refreshToken"))
+
+	response = h.query(t, meQuery, nil)
+	require.Empty(t, response.Errors)
+	require.JSONEq(t, `{"me":{"email":"anna@example.com"}}`, string(response.Data))
+
+	response = h.query(t, `mutation { refreshTokens }`, nil)
+	require.Empty(t, response.Errors)
+	require.NotNil(t, h.cookie(t, "accessToken"))
+
+	response = h.query(t, `mutation { logoutUser }`, nil)
+	require.Empty(t, response.Errors)
+	require.Nil(t, h.cookie(t, "accessToken"))
+	require.Nil(t, h.cookie(t, "refreshToken"))
+
+	response = h.query(t, meQuery, nil)
+	require.NotEmpty(t, response.Errors)
+}
+
+func TestIntegration_RegisterUser(t *testing.T) {
+	h := newHarness(t)
+	email := "ivan@example.com"
+
+	response := h.query(
+		t,
+		`mutation($input: RegisterUserInput!) { registerUser(input: $input) }`,
+		map[string]any{
+			"input": map[string]any{"displayName": "Ivan", "email": email, "password": "password"},
+		},
+	)
+	require.Empty(t, response.Errors)
+
+	var registered struct {
+		RegisterUser json.Number `json:"registerUser"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &registered))
+
+	userID, err := registered.RegisterUser.Int64()
+	require.NoError(t, err)
+
+	// Token is received from email, which was sent by SSO service:
+	emails, err := fakes.NewNotificationsClient(h.store).GetUserEmailCommunications(
+		context.Background(),
+		&notifications.GetUserEmailCommunicationsIn{UserID: uint64(userID)},
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, emails.GetEmails())
+
+	content := strings.Fields(emails.GetEmails()[0].GetContent())
+	response = h.query(
+		t,
+		`mutation($input: VerifyUserEmailInput!) { verifyUserEmail(input: $input) }`,
+		map[string]any{"input": map[string]any{"verifyEmailToken": content[len(content)-1]}},
+	)
+	require.Empty(t, response.Errors)
+
+	h.login(t, email)
+
+	response = h.query(t, `{ me { displayName emailConfirmed } myEmailCommunicationsCounter }`, nil)
+	require.Empty(t, response.Errors)
+	require.JSONEq(
+		t,
+		`{"me":{"displayName":"Ivan","emailConfirmed":true},"myEmailCommunicationsCounter":1}`,
+		string(response.Data),
+	)
+}
+
+func TestIntegration_AddToyWithAttachment(t *testing.T) {
+	h := newHarness(t)
+	attachment := []byte("\x89PNG\r\n\x1a\nimage")
+
+	h.login(t, "anna@example.com")
+
+	response := h.upload(
+		t,
+		`mutation($input: AddToyInput!) { addToy(input: $input) }`,
+		map[string]any{
+			"input": map[string]any{
+				"categoryId":  "1",
+				"name":        "Knitted fox",
+				"description": "Small fox",
+				"price":       1500,
+				"quantity":    2,
+				"tags":        []string{"handmade", "fox"},
+				"attachments": []any{nil},
+			},
+		},
+		uploadFile{path: "variables.input.attachments.0", name: "fox.png", content: attachment},
+	)
+	require.Empty(t, response.Errors)
+
+	var added struct {
+		AddToy json.Number `json:"addToy"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &added))
+
+	response = h.query(
+		t,
+		`query($id: ID!) {
+			toy(id: $id) { name master { user { email } } tags { name } attachments { link } }
+			me { storageUsage { usedBytes } }
+		}`,
+		map[string]any{"id": added.AddToy},
+	)
+	require.Empty(t, response.Errors)
+
+	var out struct {
+		Toy struct {
+			Name   string `json:"name"`
+			Master struct {
+				User struct {
+					Email string `json:"email"`
+				} `json:"user"`
+			} `json:"master"`
+			Tags []struct {
+				Name string `json:"name"`
+			} `json:"tags"`
+			Attachments []struct {
+				Link string `json:"link"`
+			} `json:"attachments"`
+		} `json:"toy"`
+		Me struct {
+			StorageUsage struct {
+				UsedBytes int `json:"usedBytes"`
+			} `json:"storageUsage"`
+		} `json:"me"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &out))
+
+	require.Equal(t, "Knitted fox", out.Toy.Name)
+	require.Equal(t, "anna@example.com", out.Toy.Master.User.Email)
+	require.Len(t, out.Toy.Tags, 2)
+	require.Len(t, out.Toy.Attachments, 1)
+	require.Equal(t, len(attachment), out.Me.StorageUsage.UsedBytes)
+
+	// Attachment is uploaded to S3 as public file:
+	objects, err := h.s3.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{})
+	require.NoError(t, err)
+	require.Len(t, objects.Contents, 1)
+
+	key := *objects.Contents[0].Key
+	require.Equal(t, "https://"+testBucket+".s3."+testRegion+".amazonaws.com/"+key, out.Toy.Attachments[0].Link)
+
+	content, acl, ok := h.s3.Object(key)
+	require.True(t, ok)
+	require.Equal(t, attachment, content)
+	require.Equal(t, types.ObjectCannedACL(harnessSettings().S3.ACL), acl)
+}
+
+func TestIntegration_RespondToTicket(t *testing.T) {
+	h := newHarness(t)
+	respondMutation := `mutation($input: RespondToTicketInput!) { respondToTicket(input: $input) }`
+
+	response := h.query(t, `{ tickets { id name } }`, nil)
+	require.Empty(t, response.Errors)
+
+	var out struct {
+		Tickets []struct {
+			ID   json.Number `json:"id"`
+			Name string      `json:"name"`
+		} `json:"tickets"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &out))
+
+	var ticketID json.Number
+
+	for _, ticket := range out.Tickets {
+		if ticket.Name == "Plush dinosaur" {
+			ticketID = ticket.ID
+		}
+	}
+
+	require.NotEmpty(t, ticketID)
+
+	respondInput := map[string]any{"input": map[string]any{"ticketId": ticketID, "price": 2500}}
+
+	// Only masters could respond to tickets:
+	h.login(t, "olga@example.com")
+	response = h.query(t, respondMutation, respondInput)
+	require.NotEmpty(t, response.Errors)
+
+	h.login(t, "anna@example.com")
+	response = h.query(t, respondMutation, respondInput)
+	require.Empty(t, response.Errors)
+
+	response = h.query(t, respondMutation, respondInput)
+	require.NotEmpty(t, response.Errors)
+
+	// Responds are available to owner of ticket:
+	h.login(t, "dmitry@example.com")
+
+	response = h.query(
+		t,
+		`query($id: ID!) { ticketResponds(ticketId: $id) { price master { user { email } } } }`,
+		map[string]any{"id": ticketID},
+	)
+	require.Empty(t, response.Errors)
+	require.JSONEq(
+		t,
+		`{"ticketResponds":[{"price":2500,"master":{"user":{"email":"anna@example.com"}}}]}`,
+		string(response.Data),
+	)
+}
+
+func TestIntegration_AddToyInvalidatesCache(t *testing.T) {
+	h := newHarness(t)
+	toysQuery := `{ toys { name } }`
+
+	h.login(t, "anna@example.com")
+
+	// List of Toys is cached by first query:
+	response := h.query(t, toysQuery, nil)
+	require.Empty(t, response.Errors)
+	require.NotContains(t, string(response.Data), "Knitted fox")
+
+	response = h.query(
+		t,
+		`mutation($input: AddToyInput!) { addToy(input: $input) }`,
+		map[string]any{
+			"input": map[string]any{
+				"categoryId":  "1",
+				"name":        "Knitted fox",
+				"description": "Small fox",
+				"price":       1500,
+				"quantity":    2,
+			},
+		},
+	)
+	require.Empty(t, response.Errors)
+
+	response = h.query(t, toysQuery, nil)
+	require.Empty(t, response.Errors)
+	require.Contains(t, string(response.Data), "Knitted fox")
+}
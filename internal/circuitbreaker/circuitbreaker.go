@@ -0,0 +1,155 @@
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops calls to dependency after configured number of consecutive failures. After open
+// timeout limited number of probe calls is allowed. Circuit is closed after successful probe and opened
+// again after failed one.
+type CircuitBreaker struct {
+	config        config.CircuitBreakerConfig
+	onStateChange func(from, to State)
+	mu            sync.Mutex
+	state         State
+	failures      int
+	probes        int
+	openedAt      time.Time
+}
+
+// New creates CircuitBreaker in closed state. onStateChange is called under lock, so it should not
+// call CircuitBreaker. Circuit breaker is disabled, if failure threshold is not positive.
+func New(config config.CircuitBreakerConfig, onStateChange func(from, to State)) *CircuitBreaker {
+	return &CircuitBreaker{
+		config:        config,
+		onStateChange: onStateChange,
+	}
+}
+
+// Allow reports, whether dependency could be called. Each allowed call must be followed by Success,
+// Failure or Cancel call.
+func (cb *CircuitBreaker) Allow() bool {
+	if cb.config.FailureThreshold <= 0 {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == StateOpen && time.Since(cb.openedAt) >= cb.config.OpenTimeout {
+		cb.setState(StateHalfOpen)
+	}
+
+	switch cb.state {
+	case StateClosed:
+		return true
+	case StateHalfOpen:
+		if cb.probes >= max(cb.config.HalfOpenProbes, 1) {
+			return false
+		}
+
+		cb.probes++
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (cb *CircuitBreaker) Success() {
+	if cb.config.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	if cb.state == StateHalfOpen {
+		cb.setState(StateClosed)
+	}
+}
+
+func (cb *CircuitBreaker) Failure() {
+	if cb.config.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+
+	switch {
+	case cb.state == StateHalfOpen:
+		cb.setState(StateOpen)
+	case cb.state == StateClosed && cb.failures >= cb.config.FailureThreshold:
+		cb.setState(StateOpen)
+	}
+}
+
+// Cancel reports, that allowed call finished without telling anything about health of dependency, for
+// example because caller gave up. Failures are kept and probe is released in half-open state.
+func (cb *CircuitBreaker) Cancel() {
+	if cb.config.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == StateHalfOpen && cb.probes > 0 {
+		cb.probes--
+	}
+}
+
+// State returns current state. Open circuit is reported as open even after open timeout, until
+// probe call is allowed.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+func (cb *CircuitBreaker) setState(state State) {
+	from := cb.state
+	cb.state = state
+	cb.probes = 0
+
+	if state == StateOpen {
+		cb.openedAt = time.Now()
+	}
+
+	if state == StateClosed {
+		cb.failures = 0
+	}
+
+	if cb.onStateChange != nil {
+		cb.onStateChange(from, state)
+	}
+}
@@ -0,0 +1,110 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var transitions []string
+
+	cb := New(
+		config.CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      time.Millisecond * 50,
+			HalfOpenProbes:   1,
+		},
+		func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	)
+
+	require.Equal(t, StateClosed, cb.State())
+
+	// Success resets consecutive failures:
+	require.True(t, cb.Allow())
+	cb.Failure()
+	require.True(t, cb.Allow())
+	cb.Success()
+	require.True(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateClosed, cb.State())
+
+	require.True(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+	require.False(t, cb.Allow())
+
+	// Failed probe opens circuit again:
+	time.Sleep(time.Millisecond * 60)
+	require.True(t, cb.Allow())
+	require.Equal(t, StateHalfOpen, cb.State())
+	require.False(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+	require.False(t, cb.Allow())
+
+	// Successful probe closes circuit:
+	time.Sleep(time.Millisecond * 60)
+	require.True(t, cb.Allow())
+	cb.Success()
+	require.Equal(t, StateClosed, cb.State())
+	require.True(t, cb.Allow())
+
+	require.Equal(
+		t,
+		[]string{
+			"closed->open",
+			"open->half-open",
+			"half-open->open",
+			"open->half-open",
+			"half-open->closed",
+		},
+		transitions,
+	)
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	cb := New(config.CircuitBreakerConfig{}, nil)
+
+	for range 10 {
+		require.True(t, cb.Allow())
+		cb.Failure()
+	}
+
+	require.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreaker_Cancel(t *testing.T) {
+	cb := New(
+		config.CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      time.Millisecond * 50,
+			HalfOpenProbes:   1,
+		},
+		nil,
+	)
+
+	// Cancelled call does not reset consecutive failures:
+	require.True(t, cb.Allow())
+	cb.Failure()
+	require.True(t, cb.Allow())
+	cb.Cancel()
+	require.True(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+
+	// Cancelled probe is released, so that circuit is probed again:
+	time.Sleep(time.Millisecond * 60)
+	require.True(t, cb.Allow())
+	require.False(t, cb.Allow())
+	cb.Cancel()
+	require.Equal(t, StateHalfOpen, cb.State())
+	require.True(t, cb.Allow())
+	cb.Success()
+	require.Equal(t, StateClosed, cb.State())
+}
@@ -0,0 +1,111 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+// UnaryClientDeadlineInterceptor bounds call by timeout of called method. Deadline of parent context,
+// which is remaining budget of operation, is kept, if it is earlier, and is propagated to downstream
+// service by gRPC. Call fails fast, if remaining budget is less than configured minimum, so that nested
+// use cases do not call services, which could not respond in time. Exceeded deadlines are recorded in
+// metrics and in span of call with their cause. Parent context is kept in context of call, so that
+// interceptors, which are chained after, could tell exceeded budget from exceeded timeout of method.
+func UnaryClientDeadlineInterceptor(
+	upstream string,
+	timeoutsConfig config.TimeoutsConfig,
+	metrics *Metrics,
+) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		name := path.Base(method)
+
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < timeoutsConfig.MinRemaining {
+				observeDeadlineExceeded(ctx, metrics, upstream, name, deadlineCauseBudgetExhausted)
+
+				return status.Errorf(
+					codes.DeadlineExceeded,
+					"remaining budget %s is not enough to call %s",
+					remaining,
+					method,
+				)
+			}
+		}
+
+		timeout := timeoutsConfig.Default
+		if methodTimeout, ok := timeoutsConfig.Methods[name]; ok {
+			timeout = methodTimeout
+		}
+
+		callCtx := context.WithValue(ctx, operationContextKey{}, ctx)
+		if timeout > 0 {
+			var cancel context.CancelFunc
+
+			callCtx, cancel = context.WithTimeout(callCtx, timeout)
+			defer cancel()
+		}
+
+		err := invoker(callCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.DeadlineExceeded && !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			observeDeadlineExceeded(ctx, metrics, upstream, name, deadlineCauseOperationBudget)
+		case callCtx.Err() != nil:
+			observeDeadlineExceeded(ctx, metrics, upstream, name, deadlineCauseMethodTimeout)
+		default:
+			observeDeadlineExceeded(ctx, metrics, upstream, name, deadlineCauseUpstream)
+		}
+
+		return err
+	}
+}
+
+type operationContextKey struct{}
+
+// isOperationBudgetExceeded reports, whether call failed, because deadline of operation budget, and not
+// timeout of method, was exceeded. Context of call is budget itself, if it was not bounded by
+// UnaryClientDeadlineInterceptor.
+func isOperationBudgetExceeded(ctx context.Context, err error) bool {
+	if status.Code(err) != codes.DeadlineExceeded && !errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	operationCtx, ok := ctx.Value(operationContextKey{}).(context.Context)
+	if !ok {
+		operationCtx = ctx
+	}
+
+	return operationCtx.Err() != nil
+}
+
+func observeDeadlineExceeded(ctx context.Context, metrics *Metrics, upstream, method, cause string) {
+	metrics.deadlinesExceeded.WithLabelValues(upstream, method, cause).Inc()
+	trace.SpanFromContext(ctx).AddEvent(
+		"deadline exceeded",
+		trace.WithAttributes(
+			attribute.String("upstream", upstream),
+			attribute.String("method", method),
+			attribute.String("cause", cause),
+		),
+	)
+}
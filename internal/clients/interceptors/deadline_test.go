@@ -0,0 +1,147 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+func TestUnaryClientDeadlineInterceptor(t *testing.T) {
+	timeoutsConfig := config.TimeoutsConfig{
+		Default:      time.Second,
+		Methods:      map[string]time.Duration{"GetToys": time.Millisecond * 20},
+		MinRemaining: time.Millisecond * 10,
+	}
+
+	// waitForDeadline imitates downstream service, which responds only after deadline of call:
+	waitForDeadline := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		<-ctx.Done()
+
+		return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		budget        time.Duration
+		invoker       grpc.UnaryInvoker
+		expectedCode  codes.Code
+		expectedCause string
+		invoked       bool
+	}{
+		{
+			name:   "success within method timeout",
+			method: "/toys.ToysService/GetToy",
+			budget: time.Second * 2,
+			invoker: func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(time.Second), deadline, time.Millisecond*50)
+
+				return nil
+			},
+			expectedCode: codes.OK,
+			invoked:      true,
+		},
+		{
+			name:          "budget is exhausted before call",
+			method:        "/toys.ToysService/GetToy",
+			budget:        time.Millisecond * 5,
+			invoker:       waitForDeadline,
+			expectedCode:  codes.DeadlineExceeded,
+			expectedCause: deadlineCauseBudgetExhausted,
+		},
+		{
+			name:          "method timeout is exceeded",
+			method:        "/toys.ToysService/GetToys",
+			budget:        time.Second,
+			invoker:       waitForDeadline,
+			expectedCode:  codes.DeadlineExceeded,
+			expectedCause: deadlineCauseMethodTimeout,
+			invoked:       true,
+		},
+		{
+			name:          "operation budget is exceeded",
+			method:        "/toys.ToysService/GetToy",
+			budget:        time.Millisecond * 30,
+			invoker:       waitForDeadline,
+			expectedCode:  codes.DeadlineExceeded,
+			expectedCause: deadlineCauseOperationBudget,
+			invoked:       true,
+		},
+		{
+			name:   "deadline is exceeded by upstream",
+			method: "/toys.ToysService/GetToy",
+			budget: time.Second,
+			invoker: func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+			},
+			expectedCode:  codes.DeadlineExceeded,
+			expectedCause: deadlineCauseUpstream,
+			invoked:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics, err := NewMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			interceptor := UnaryClientDeadlineInterceptor(testUpstream, timeoutsConfig, metrics)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.budget)
+			defer cancel()
+
+			var invoked bool
+			err = interceptor(
+				ctx,
+				tc.method,
+				nil,
+				nil,
+				nil,
+				func(
+					ctx context.Context,
+					method string,
+					req, reply any,
+					cc *grpc.ClientConn,
+					opts ...grpc.CallOption,
+				) error {
+					invoked = true
+
+					return tc.invoker(ctx, method, req, reply, cc, opts...)
+				},
+			)
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Equal(t, tc.invoked, invoked)
+
+			if tc.expectedCause != "" {
+				assert.Equal(t, 1, testutil.CollectAndCount(metrics.deadlinesExceeded))
+				assert.Equal(
+					t,
+					float64(1),
+					testutil.ToFloat64(
+						metrics.deadlinesExceeded.WithLabelValues(
+							testUpstream,
+							path.Base(tc.method),
+							tc.expectedCause,
+						),
+					),
+				)
+			} else {
+				assert.Equal(t, 0, testutil.CollectAndCount(metrics.deadlinesExceeded))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+)
+
+// Metadata keys, which are sent to downstream services with each call of request. Keys are part of
+// contract with downstream services, so they should be changed only together with them. Key is omitted,
+// if value is unknown, e.g. user ID of unauthenticated request:
+const (
+	RequestIDMetadataKey     = "x-request-id"     // ID of request, which is returned to client in X-Request-ID
+	UserIDMetadataKey        = "x-user-id"        // ID of user, authenticated by access token of request
+	ClientIPMetadataKey      = "x-client-ip"      // IP address of client of BFF
+	OperationNameMetadataKey = "x-operation-name" // Name of GraphQL operation
+)
+
+// UnaryClientMetadataInterceptor forwards metadata of incoming request to downstream service, so that
+// logs of all services could be correlated by request ID.
+func UnaryClientMetadataInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if requestMetadata := requestmeta.FromContext(ctx); requestMetadata != nil {
+			ctx = metadata.AppendToOutgoingContext(ctx, metadataPairs(requestMetadata)...)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func metadataPairs(requestMetadata *requestmeta.Metadata) []string {
+	var pairs []string
+
+	if requestID := requestMetadata.RequestID(); requestID != "" {
+		pairs = append(pairs, RequestIDMetadataKey, requestID)
+	}
+
+	if userID := requestMetadata.UserID(); userID != 0 {
+		pairs = append(pairs, UserIDMetadataKey, strconv.FormatUint(userID, 10))
+	}
+
+	if clientIP := requestMetadata.ClientIP(); clientIP != "" {
+		pairs = append(pairs, ClientIPMetadataKey, clientIP)
+	}
+
+	if operationName := requestMetadata.OperationName(); operationName != "" {
+		pairs = append(pairs, OperationNameMetadataKey, operationName)
+	}
+
+	return pairs
+}
@@ -0,0 +1,75 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+)
+
+func TestUnaryClientMetadataInterceptor(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setupCtx   func() context.Context
+		expectedMD metadata.MD
+	}{
+		{
+			name: "authenticated request",
+			setupCtx: func() context.Context {
+				ctx := requestmeta.WithMetadata(context.Background(), requestmeta.New("request-id", "10.0.0.1"))
+				requestmeta.SetOperationName(ctx, "myToys")
+				requestmeta.SetUserID(ctx, 1)
+
+				return ctx
+			},
+			expectedMD: metadata.Pairs(
+				RequestIDMetadataKey, "request-id",
+				UserIDMetadataKey, "1",
+				ClientIPMetadataKey, "10.0.0.1",
+				OperationNameMetadataKey, "myToys",
+			),
+		},
+		{
+			name: "unauthenticated request",
+			setupCtx: func() context.Context {
+				return requestmeta.WithMetadata(context.Background(), requestmeta.New("request-id", "10.0.0.1"))
+			},
+			expectedMD: metadata.Pairs(
+				RequestIDMetadataKey, "request-id",
+				ClientIPMetadataKey, "10.0.0.1",
+			),
+		},
+		{
+			name: "call outside of request",
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+		},
+	}
+
+	interceptor := UnaryClientMetadataInterceptor()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := interceptor(
+				tc.setupCtx(),
+				"/toys.ToysService/GetToys",
+				nil,
+				nil,
+				nil,
+				func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					assert.Equal(t, tc.expectedMD, md)
+
+					return nil
+				},
+			)
+			require.NoError(t, err)
+		})
+	}
+}
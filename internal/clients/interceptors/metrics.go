@@ -0,0 +1,84 @@
+package interceptors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace         = "hmtm_bff"
+	upstreamMetricsSubsystem = "upstream"
+
+	// Reasons of calls, rejected without calling downstream service:
+	rejectReasonCircuitOpen  = "circuit_open"
+	rejectReasonBulkheadFull = "bulkhead_full"
+
+	// Causes of exceeded deadlines of calls:
+	deadlineCauseBudgetExhausted = "budget_exhausted" // Call was not started, because budget was almost spent
+	deadlineCauseOperationBudget = "operation_budget" // Budget of operation was spent during call
+	deadlineCauseMethodTimeout   = "method_timeout"   // Timeout of method was exceeded
+	deadlineCauseUpstream        = "upstream"         // Downstream service returned DeadlineExceeded by itself
+)
+
+// Metrics are shared by interceptors of all downstream services, which are distinguished by upstream label.
+type Metrics struct {
+	circuitBreakerState *prometheus.GaugeVec
+	rejectedCalls       *prometheus.CounterVec
+	inFlightCalls       *prometheus.GaugeVec
+	deadlinesExceeded   *prometheus.CounterVec
+}
+
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	metrics := &Metrics{
+		circuitBreakerState: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: upstreamMetricsSubsystem,
+				Name:      "circuit_breaker_state",
+				Help:      "State of downstream service circuit breaker: 0 - closed, 1 - half-open, 2 - open.",
+			},
+			[]string{"upstream"},
+		),
+		rejectedCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: upstreamMetricsSubsystem,
+				Name:      "rejected_calls_total",
+				Help:      "Number of calls, rejected without calling downstream service, by reason.",
+			},
+			[]string{"upstream", "reason"},
+		),
+		inFlightCalls: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: upstreamMetricsSubsystem,
+				Name:      "in_flight_calls",
+				Help:      "Number of concurrent calls to downstream service.",
+			},
+			[]string{"upstream"},
+		),
+		deadlinesExceeded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: upstreamMetricsSubsystem,
+				Name:      "deadlines_exceeded_total",
+				Help:      "Number of calls to downstream service, which exceeded deadline, by method and cause.",
+			},
+			[]string{"upstream", "method", "cause"},
+		),
+	}
+
+	collectors := []prometheus.Collector{
+		metrics.circuitBreakerState,
+		metrics.rejectedCalls,
+		metrics.inFlightCalls,
+		metrics.deadlinesExceeded,
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,126 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DKhorkov/hmtm-bff/internal/circuitbreaker"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+)
+
+var (
+	ErrCircuitOpen  = errors.New("circuit breaker is open")
+	ErrBulkheadFull = errors.New("max concurrent calls reached")
+)
+
+// UnaryClientCircuitBreakerInterceptor fails fast with UpstreamUnavailableError, while downstream service
+// is considered unavailable. Interceptor should be chained before retry interceptor, so that retried call
+// is counted as single failure and calls are not retried, when circuit is open. Calls, which exceeded
+// budget of operation, are not counted, because their deadline was set by caller, not by method timeout.
+func UnaryClientCircuitBreakerInterceptor(
+	upstream string,
+	circuitBreakerConfig config.CircuitBreakerConfig,
+	logger logging.Logger,
+	metrics *Metrics,
+) grpc.UnaryClientInterceptor {
+	stateGauge := metrics.circuitBreakerState.WithLabelValues(upstream)
+	stateGauge.Set(float64(circuitbreaker.StateClosed))
+
+	circuitBreaker := circuitbreaker.New(
+		circuitBreakerConfig,
+		func(from, to circuitbreaker.State) {
+			stateGauge.Set(float64(to))
+			logging.LogInfo(
+				logger,
+				fmt.Sprintf("%s circuit breaker changed state from %s to %s", upstream, from, to),
+			)
+		},
+	)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !circuitBreaker.Allow() {
+			metrics.rejectedCalls.WithLabelValues(upstream, rejectReasonCircuitOpen).Inc()
+
+			return &customerrors.UpstreamUnavailableError{Message: upstream, BaseErr: ErrCircuitOpen}
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		switch {
+		case isOperationBudgetExceeded(ctx, err):
+			circuitBreaker.Cancel()
+		case isUpstreamFailure(err):
+			circuitBreaker.Failure()
+		default:
+			circuitBreaker.Success()
+		}
+
+		return err
+	}
+}
+
+// UnaryClientBulkheadInterceptor limits number of concurrent calls to downstream service, so that
+// goroutines do not pile up, while it responds slowly. Calls over limit fail fast with
+// UpstreamUnavailableError. Limit is disabled, if maxConcurrentCalls is not positive.
+func UnaryClientBulkheadInterceptor(
+	upstream string,
+	maxConcurrentCalls int,
+	metrics *Metrics,
+) grpc.UnaryClientInterceptor {
+	inFlightGauge := metrics.inFlightCalls.WithLabelValues(upstream)
+
+	var slots chan struct{}
+	if maxConcurrentCalls > 0 {
+		slots = make(chan struct{}, maxConcurrentCalls)
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			default:
+				metrics.rejectedCalls.WithLabelValues(upstream, rejectReasonBulkheadFull).Inc()
+
+				return &customerrors.UpstreamUnavailableError{Message: upstream, BaseErr: ErrBulkheadFull}
+			}
+		}
+
+		inFlightGauge.Inc()
+		defer inFlightGauge.Dec()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// isUpstreamFailure reports, whether error means, that downstream service is unhealthy. Errors of request
+// itself, such as NotFound or InvalidArgument, do not open circuit.
+func isUpstreamFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,287 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+)
+
+const testUpstream = "tickets"
+
+func TestNewMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	metrics, err := NewMetrics(registry)
+	require.NoError(t, err)
+	require.NotNil(t, metrics)
+
+	// Metrics could not be registered twice:
+	metrics, err = NewMetrics(registry)
+	require.Error(t, err)
+	require.Nil(t, metrics)
+}
+
+func TestUnaryClientCircuitBreakerInterceptor(t *testing.T) {
+	testCases := []struct {
+		name             string
+		invokerErrors    []error
+		expectedInvoked  int
+		expectedErrors   []error
+		expectedRejected float64
+		expectedState    float64
+		setupMocks       func(logger *mocklogging.MockLogger)
+	}{
+		{
+			name: "upstream failures open circuit",
+			invokerErrors: []error{
+				status.Error(codes.Unavailable, "unavailable"),
+				status.Error(codes.DeadlineExceeded, "deadline"),
+			},
+			expectedInvoked: 2,
+			expectedErrors: []error{
+				status.Error(codes.Unavailable, "unavailable"),
+				status.Error(codes.DeadlineExceeded, "deadline"),
+				&customerrors.UpstreamUnavailableError{Message: testUpstream, BaseErr: ErrCircuitOpen},
+			},
+			expectedRejected: 1,
+			expectedState:    2,
+			setupMocks: func(logger *mocklogging.MockLogger) {
+				logger.
+					EXPECT().
+					Info(gomock.Any()).
+					Times(1)
+			},
+		},
+		{
+			name: "request errors do not open circuit",
+			invokerErrors: []error{
+				status.Error(codes.NotFound, "not found"),
+				status.Error(codes.InvalidArgument, "invalid"),
+			},
+			expectedInvoked: 3,
+			expectedErrors: []error{
+				status.Error(codes.NotFound, "not found"),
+				status.Error(codes.InvalidArgument, "invalid"),
+				nil,
+			},
+		},
+		{
+			name:            "success resets failures",
+			invokerErrors:   []error{status.Error(codes.Unavailable, "unavailable"), nil},
+			expectedInvoked: 3,
+			expectedErrors: []error{
+				status.Error(codes.Unavailable, "unavailable"),
+				nil,
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(logger)
+			}
+
+			metrics, err := NewMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			interceptor := UnaryClientCircuitBreakerInterceptor(
+				testUpstream,
+				config.CircuitBreakerConfig{
+					FailureThreshold: 2,
+					OpenTimeout:      time.Hour,
+					HalfOpenProbes:   1,
+				},
+				logger,
+				metrics,
+			)
+
+			var invoked int
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				defer func() { invoked++ }()
+
+				if invoked < len(tc.invokerErrors) {
+					return tc.invokerErrors[invoked]
+				}
+
+				return nil
+			}
+
+			for _, expectedError := range tc.expectedErrors {
+				err = interceptor(context.Background(), "/method", nil, nil, nil, invoker)
+				assert.Equal(t, expectedError, err)
+			}
+
+			assert.Equal(t, tc.expectedInvoked, invoked)
+			assert.Equal(
+				t,
+				tc.expectedRejected,
+				testutil.ToFloat64(metrics.rejectedCalls.WithLabelValues(testUpstream, rejectReasonCircuitOpen)),
+			)
+			assert.Equal(
+				t,
+				tc.expectedState,
+				testutil.ToFloat64(metrics.circuitBreakerState.WithLabelValues(testUpstream)),
+			)
+		})
+	}
+}
+
+func TestUnaryClientCircuitBreakerInterceptor_OperationBudget(t *testing.T) {
+	// waitForDeadline imitates downstream service, which responds only after deadline of call:
+	waitForDeadline := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		<-ctx.Done()
+
+		return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+
+	testCases := []struct {
+		name          string
+		budget        time.Duration
+		methodTimeout time.Duration
+		expectedState float64
+	}{
+		{
+			name:          "exceeded budget of operation does not open circuit",
+			budget:        time.Millisecond * 20,
+			methodTimeout: time.Second,
+			expectedState: 0,
+		},
+		{
+			name:          "exceeded timeout of method opens circuit",
+			budget:        time.Second,
+			methodTimeout: time.Millisecond * 20,
+			expectedState: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := mocklogging.NewMockLogger(ctrl)
+			logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			metrics, err := NewMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			// Deadline interceptor is chained before circuit breaker, as in clients:
+			deadlineInterceptor := UnaryClientDeadlineInterceptor(
+				testUpstream,
+				config.TimeoutsConfig{Default: tc.methodTimeout},
+				metrics,
+			)
+			circuitBreakerInterceptor := UnaryClientCircuitBreakerInterceptor(
+				testUpstream,
+				config.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour},
+				logger,
+				metrics,
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.budget)
+			defer cancel()
+
+			err = deadlineInterceptor(
+				ctx,
+				"/method",
+				nil,
+				nil,
+				nil,
+				func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+					return circuitBreakerInterceptor(ctx, method, req, reply, cc, waitForDeadline, opts...)
+				},
+			)
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+			assert.Equal(
+				t,
+				tc.expectedState,
+				testutil.ToFloat64(metrics.circuitBreakerState.WithLabelValues(testUpstream)),
+			)
+		})
+	}
+}
+
+func TestUnaryClientBulkheadInterceptor(t *testing.T) {
+	testCases := []struct {
+		name               string
+		maxConcurrentCalls int
+		expectedError      error
+		expectedRejected   float64
+	}{
+		{
+			name:               "calls over limit are rejected",
+			maxConcurrentCalls: 1,
+			expectedError:      &customerrors.UpstreamUnavailableError{Message: testUpstream, BaseErr: ErrBulkheadFull},
+			expectedRejected:   1,
+		},
+		{
+			name:               "limit is disabled",
+			maxConcurrentCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics, err := NewMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			interceptor := UnaryClientBulkheadInterceptor(testUpstream, tc.maxConcurrentCalls, metrics)
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			blockingInvoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				close(started)
+				<-release
+
+				return nil
+			}
+
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return nil
+			}
+
+			blockingCallErr := make(chan error)
+			go func() {
+				blockingCallErr <- interceptor(context.Background(), "/method", nil, nil, nil, blockingInvoker)
+			}()
+
+			<-started
+			assert.Equal(t, float64(1), testutil.ToFloat64(metrics.inFlightCalls.WithLabelValues(testUpstream)))
+
+			err = interceptor(context.Background(), "/method", nil, nil, nil, invoker)
+			assert.Equal(t, tc.expectedError, err)
+
+			close(release)
+			require.NoError(t, <-blockingCallErr)
+
+			// Slot is released after call is finished:
+			require.NoError(t, interceptor(context.Background(), "/method", nil, nil, nil, invoker))
+
+			assert.Equal(
+				t,
+				tc.expectedRejected,
+				testutil.ToFloat64(metrics.rejectedCalls.WithLabelValues(testUpstream, rejectReasonBulkheadFull)),
+			)
+			assert.Equal(t, float64(0), testutil.ToFloat64(metrics.inFlightCalls.WithLabelValues(testUpstream)))
+			assert.True(t, errors.Is(err, ErrBulkheadFull) == (tc.expectedError != nil))
+		})
+	}
+}
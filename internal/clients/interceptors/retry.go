@@ -0,0 +1,91 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+// IdempotencyKeyMetadataKey is metadata key of idempotency key, which allows downstream service to
+// deduplicate retried write calls.
+const IdempotencyKeyMetadataKey = "x-idempotency-key"
+
+// WithIdempotencyKey attaches idempotency key to outgoing calls, so that write calls could be retried.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, IdempotencyKeyMetadataKey, key)
+}
+
+// UnaryClientRetryPolicyInterceptor selects retry policy of called method. Interceptor should be chained
+// right before retry interceptor, which retries calls according to provided call options.
+func UnaryClientRetryPolicyInterceptor(retryConfig config.RetryConfig) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(ctx, method, req, reply, cc, append(opts, retryOptions(ctx, method, retryConfig)...)...)
+	}
+}
+
+// retryOptions returns retry options of called method. Reads are retried only on codes, which mean, that
+// request was not processed. Writes are never retried without idempotency key, because even failed call
+// could have created entity.
+func retryOptions(ctx context.Context, method string, retryConfig config.RetryConfig) []grpc.CallOption {
+	name := path.Base(method)
+
+	policy := retryConfig.Reads
+	if !isReadMethod(name) {
+		if !hasIdempotencyKey(ctx) {
+			return []grpc.CallOption{grpcretry.Disable()}
+		}
+
+		policy = retryConfig.Writes
+	}
+
+	if override, ok := retryConfig.Methods[name]; ok {
+		policy.Disabled = override.Disabled
+		if override.MaxAttempts > 0 {
+			policy.MaxAttempts = override.MaxAttempts
+		}
+
+		if override.Timeout > 0 {
+			policy.Timeout = override.Timeout
+		}
+
+		if override.Backoff > 0 {
+			policy.Backoff = override.Backoff
+		}
+	}
+
+	if policy.Disabled || policy.MaxAttempts <= 1 {
+		return []grpc.CallOption{grpcretry.Disable()}
+	}
+
+	return []grpc.CallOption{
+		grpcretry.WithMax(uint(policy.MaxAttempts)),
+		grpcretry.WithCodes(codes.Unavailable, codes.ResourceExhausted),
+		grpcretry.WithPerRetryTimeout(policy.Timeout),
+		grpcretry.WithBackoff(grpcretry.BackoffExponentialWithJitter(policy.Backoff, retryConfig.Jitter)),
+	}
+}
+
+func isReadMethod(name string) bool {
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "Count")
+}
+
+func hasIdempotencyKey(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+
+	return ok && len(md.Get(IdempotencyKeyMetadataKey)) > 0
+}
@@ -0,0 +1,188 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+func TestUnaryClientRetryPolicyInterceptor(t *testing.T) {
+	retryConfig := config.RetryConfig{
+		Reads:  config.RetryPolicyConfig{MaxAttempts: 3, Backoff: time.Millisecond},
+		Writes: config.RetryPolicyConfig{MaxAttempts: 2, Backoff: time.Millisecond},
+		Methods: map[string]config.RetryPolicyConfig{
+			"GetUsers":     {Disabled: true},
+			"GetToys":      {MaxAttempts: 5},
+			"CreateTicket": {MaxAttempts: 5},
+		},
+		Jitter: 0.1,
+	}
+
+	testCases := []struct {
+		name             string
+		method           string
+		idempotencyKey   string
+		invokerError     error
+		expectedAttempts int
+	}{
+		{
+			name:             "read is retried on Unavailable",
+			method:           "/toys.ToysService/GetToy",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 3,
+		},
+		{
+			name:             "read is retried on ResourceExhausted",
+			method:           "/toys.ToysService/CountToys",
+			invokerError:     status.Error(codes.ResourceExhausted, "exhausted"),
+			expectedAttempts: 3,
+		},
+		{
+			name:             "read is not retried on NotFound",
+			method:           "/toys.ToysService/GetToy",
+			invokerError:     status.Error(codes.NotFound, "not found"),
+			expectedAttempts: 1,
+		},
+		{
+			name:             "read is not retried on DeadlineExceeded",
+			method:           "/toys.ToysService/GetToy",
+			invokerError:     status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+			expectedAttempts: 1,
+		},
+		{
+			name:             "successful read is not retried",
+			method:           "/toys.ToysService/GetToy",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries of method are disabled",
+			method:           "/sso.UsersService/GetUsers",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 1,
+		},
+		{
+			name:             "attempts of method are overridden",
+			method:           "/toys.ToysService/GetToys",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 5,
+		},
+		{
+			name:             "write without idempotency key is not retried",
+			method:           "/toys.ToysService/AddToy",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 1,
+		},
+		{
+			name:             "write with idempotency key is retried",
+			method:           "/toys.ToysService/AddToy",
+			idempotencyKey:   "key",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 2,
+		},
+		{
+			name:             "overridden write without idempotency key is not retried",
+			method:           "/tickets.TicketsService/CreateTicket",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 1,
+		},
+		{
+			name:             "overridden write with idempotency key is retried",
+			method:           "/tickets.TicketsService/CreateTicket",
+			idempotencyKey:   "key",
+			invokerError:     status.Error(codes.Unavailable, "unavailable"),
+			expectedAttempts: 5,
+		},
+	}
+
+	policyInterceptor := UnaryClientRetryPolicyInterceptor(retryConfig)
+	retryInterceptor := grpcretry.UnaryClientInterceptor()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.idempotencyKey != "" {
+				ctx = WithIdempotencyKey(ctx, tc.idempotencyKey)
+			}
+
+			var attempts int
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				attempts++
+
+				return tc.invokerError
+			}
+
+			err := policyInterceptor(
+				ctx,
+				tc.method,
+				nil,
+				nil,
+				nil,
+				func(
+					ctx context.Context,
+					method string,
+					req, reply any,
+					cc *grpc.ClientConn,
+					opts ...grpc.CallOption,
+				) error {
+					return retryInterceptor(ctx, method, req, reply, cc, invoker, opts...)
+				},
+			)
+
+			assert.Equal(t, tc.invokerError, err)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestUnaryClientRetryPolicyInterceptor_MethodOverrides(t *testing.T) {
+	retryConfig := config.RetryConfig{
+		Reads: config.RetryPolicyConfig{MaxAttempts: 2, Backoff: time.Millisecond},
+		Methods: map[string]config.RetryPolicyConfig{
+			"GetToys": {Timeout: time.Minute, Backoff: 100 * time.Millisecond},
+		},
+	}
+
+	var (
+		attemptedAt []time.Time
+		deadlines   []bool
+	)
+
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		_, ok := ctx.Deadline()
+		deadlines = append(deadlines, ok)
+		attemptedAt = append(attemptedAt, time.Now())
+
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	retryInterceptor := grpcretry.UnaryClientInterceptor()
+	_ = UnaryClientRetryPolicyInterceptor(retryConfig)(
+		context.Background(),
+		"/toys.ToysService/GetToys",
+		nil,
+		nil,
+		nil,
+		func(
+			ctx context.Context,
+			method string,
+			req, reply any,
+			cc *grpc.ClientConn,
+			opts ...grpc.CallOption,
+		) error {
+			return retryInterceptor(ctx, method, req, reply, cc, invoker, opts...)
+		},
+	)
+
+	// Each attempt has overridden timeout and second one waits for overridden backoff:
+	assert.Equal(t, []bool{true, true}, deadlines)
+	assert.GreaterOrEqual(t, attemptedAt[1].Sub(attemptedAt[0]), 100*time.Millisecond)
+}
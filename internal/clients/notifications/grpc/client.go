@@ -1,39 +1,56 @@
 package notificationsgrpcclient
 
 import (
-	"fmt"
-	"time"
-
 	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/tracing"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 
 	customgrpc "github.com/DKhorkov/libs/grpc/interceptors"
 	grpclogging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+
+	"github.com/DKhorkov/hmtm-bff/internal/clients/interceptors"
+	"github.com/DKhorkov/hmtm-bff/internal/clients/transport"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
 )
 
+// upstream is name of downstream service in metrics and errors:
+const upstream = "notifications"
+
 type Client struct {
 	notifications.EmailsServiceClient
 }
 
 func New(
-	host string,
-	port int,
-	retriesCount int,
-	retriesTimeout time.Duration,
+	clientConfig config.ClientConfig,
 	logger logging.Logger,
 	traceProvider tracing.Provider,
 	spanConfig tracing.SpanConfig,
+	metrics *interceptors.Metrics,
+	dialOptions ...grpc.DialOption, // Additional options, for example dialer of in-process connections
 ) (*Client, error) {
-	// Options for interceptors (перехватчики / middlewares) for retries purposes:
-	retryOptions := []grpcretry.CallOption{
-		grpcretry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
-		grpcretry.WithMax(uint(retriesCount)),
-		grpcretry.WithPerRetryTimeout(retriesTimeout),
+	transportCredentials, err := transport.NewCredentials(clientConfig.TLS, logger)
+	if err != nil {
+		logging.LogError(
+			logger,
+			"Failed to create Notifications gRPC client credentials",
+			err,
+		)
+
+		return nil, err
+	}
+
+	// Calls are distributed between instances of downstream service:
+	target, balancingOptions, err := transport.NewBalancing(upstream, clientConfig)
+	if err != nil {
+		logging.LogError(
+			logger,
+			"Failed to configure Notifications gRPC client load balancing",
+			err,
+		)
+
+		return nil, err
 	}
 
 	// Options for interceptors for logging purposes:
@@ -46,18 +63,32 @@ func New(
 
 	// Create connection with Notifications gRPC-server for client:
 	clientConnection, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(
-			insecure.NewCredentials(),
-		),
-		grpc.WithChainUnaryInterceptor( // Middlewares. Using chain not to overwrite interceptors.
-			customgrpc.UnaryClientTracingInterceptor(traceProvider, spanConfig),
-			grpclogging.UnaryClientInterceptor(
-				customgrpc.UnaryClientLoggingInterceptor(logger),
-				logOptions...,
-			),
-			grpcretry.UnaryClientInterceptor(retryOptions...),
-		),
+		target,
+		append(
+			[]grpc.DialOption{
+				grpc.WithTransportCredentials(transportCredentials),
+				grpc.WithChainUnaryInterceptor( // Middlewares. Using chain not to overwrite interceptors.
+					customgrpc.UnaryClientTracingInterceptor(traceProvider, spanConfig),
+					grpclogging.UnaryClientInterceptor(
+						customgrpc.UnaryClientLoggingInterceptor(logger),
+						logOptions...,
+					),
+					interceptors.UnaryClientMetadataInterceptor(),
+					interceptors.UnaryClientDeadlineInterceptor(upstream, clientConfig.Timeouts, metrics),
+					interceptors.UnaryClientBulkheadInterceptor(upstream, clientConfig.MaxConcurrentCalls, metrics),
+					interceptors.UnaryClientCircuitBreakerInterceptor(
+						upstream,
+						clientConfig.CircuitBreaker,
+						logger,
+						metrics,
+					),
+					// Retry options are selected for each call by method:
+					interceptors.UnaryClientRetryPolicyInterceptor(clientConfig.Retry),
+					grpcretry.UnaryClientInterceptor(),
+				),
+			},
+			append(balancingOptions, dialOptions...)...,
+		)...,
 	)
 	if err != nil {
 		logging.LogError(
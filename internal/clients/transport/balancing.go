@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	_ "google.golang.org/grpc/health" // Registers client of gRPC health protocol for health checks of instances
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+// staticScheme is scheme of resolver, which returns static addresses of downstream service instances.
+const staticScheme = "static"
+
+// serviceConfig is gRPC service config: https://github.com/grpc/grpc/blob/master/doc/service_config.md
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]any   `json:"loadBalancingConfig"`
+	HealthCheckConfig   *healthCheckConfig `json:"healthCheckConfig,omitempty"`
+}
+
+type healthCheckConfig struct {
+	ServiceName string `json:"serviceName"`
+}
+
+// NewBalancing returns target of connection to downstream service and dial options, which configure
+// resolving of its instances, load balancing between them, health checks and keepalive of connections.
+func NewBalancing(upstream string, clientConfig config.ClientConfig) (string, []grpc.DialOption, error) {
+	balancingConfig := clientConfig.Balancing
+
+	encodedServiceConfig, err := newServiceConfig(balancingConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	options := []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(encodedServiceConfig),
+	}
+
+	if balancingConfig.Keepalive.Time > 0 {
+		options = append(
+			options,
+			grpc.WithKeepaliveParams(
+				keepalive.ClientParameters{
+					Time:                balancingConfig.Keepalive.Time,
+					Timeout:             balancingConfig.Keepalive.Timeout,
+					PermitWithoutStream: balancingConfig.Keepalive.PermitWithoutStream,
+				},
+			),
+		)
+	}
+
+	switch {
+	case balancingConfig.Target != "":
+		return balancingConfig.Target, options, nil
+	case len(balancingConfig.Addresses) > 0:
+		staticResolver, err := newStaticResolver(balancingConfig.Addresses)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("%s:///%s", staticScheme, upstream), append(options, grpc.WithResolvers(staticResolver)), nil
+	default:
+		return net.JoinHostPort(clientConfig.Host, strconv.Itoa(clientConfig.Port)), options, nil
+	}
+}
+
+// newServiceConfig returns encoded service config with load balancing policy and health checks of instances.
+func newServiceConfig(balancingConfig config.BalancingConfig) (string, error) {
+	var policy map[string]any
+
+	switch balancingConfig.Policy {
+	case config.RoundRobinPolicy:
+		policy = map[string]any{roundrobin.Name: struct{}{}}
+	case config.LeastRequestPolicy:
+		policy = map[string]any{leastrequest.Name: leastrequest.LBConfig{ChoiceCount: 2}}
+	default:
+		return "", fmt.Errorf("unknown load balancing policy=%s", balancingConfig.Policy)
+	}
+
+	sc := serviceConfig{
+		LoadBalancingConfig: []map[string]any{policy},
+	}
+
+	if balancingConfig.HealthCheck {
+		sc.HealthCheckConfig = &healthCheckConfig{ServiceName: balancingConfig.HealthCheckService}
+	}
+
+	encoded, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// newStaticResolver returns resolver of static addresses. Host of each address is used as server name
+// for TLS verification, because target of connection contains only name of downstream service.
+func newStaticResolver(addresses []string) (*manual.Resolver, error) {
+	endpoints := make([]resolver.Endpoint, len(addresses))
+	for i, address := range addresses {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address=%s of downstream service: %w", address, err)
+		}
+
+		endpoints[i] = resolver.Endpoint{
+			Addresses: []resolver.Address{{Addr: address, ServerName: host}},
+		}
+	}
+
+	staticResolver := manual.NewBuilderWithScheme(staticScheme)
+	staticResolver.InitialState(resolver.State{Endpoints: endpoints})
+
+	return staticResolver, nil
+}
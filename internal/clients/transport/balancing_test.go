@@ -0,0 +1,243 @@
+package transport
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+const testMethod = "/test.TestService/Call"
+
+// testInstance is instance of downstream service, which counts received calls.
+type testInstance struct {
+	address string
+	calls   atomic.Int32
+	health  *health.Server
+}
+
+func startTestInstance(t *testing.T) *testInstance {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	instance := &testInstance{
+		address: listener.Addr().String(),
+		health:  health.NewServer(),
+	}
+
+	server := grpc.NewServer(
+		grpc.UnknownServiceHandler(
+			func(_ any, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+					return err
+				}
+
+				instance.calls.Add(1)
+
+				return stream.SendMsg(&emptypb.Empty{})
+			},
+		),
+	)
+	grpc_health_v1.RegisterHealthServer(server, instance.health)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return instance
+}
+
+func TestNewBalancing(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clientConfig   config.ClientConfig
+		expectedTarget string
+		errorExpected  bool
+	}{
+		{
+			name: "host and port",
+			clientConfig: config.ClientConfig{
+				Host:      "toys",
+				Port:      8060,
+				Balancing: config.BalancingConfig{Policy: config.RoundRobinPolicy},
+			},
+			expectedTarget: "toys:8060",
+		},
+		{
+			name: "static addresses",
+			clientConfig: config.ClientConfig{
+				Host: "toys",
+				Port: 8060,
+				Balancing: config.BalancingConfig{
+					Addresses: []string{"toys-1:8060", "toys-2:8060"},
+					Policy:    config.LeastRequestPolicy,
+				},
+			},
+			expectedTarget: "static:///toys",
+		},
+		{
+			name: "target",
+			clientConfig: config.ClientConfig{
+				Host: "toys",
+				Port: 8060,
+				Balancing: config.BalancingConfig{
+					Target:    "dns:///toys:8060",
+					Addresses: []string{"toys-1:8060"},
+					Policy:    config.RoundRobinPolicy,
+				},
+			},
+			expectedTarget: "dns:///toys:8060",
+		},
+		{
+			name: "invalid address",
+			clientConfig: config.ClientConfig{
+				Balancing: config.BalancingConfig{
+					Addresses: []string{"toys-1"},
+					Policy:    config.RoundRobinPolicy,
+				},
+			},
+			errorExpected: true,
+		},
+		{
+			name: "unknown policy",
+			clientConfig: config.ClientConfig{
+				Host:      "toys",
+				Port:      8060,
+				Balancing: config.BalancingConfig{Policy: "random"},
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target, options, err := NewBalancing("toys", tc.clientConfig)
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTarget, target)
+
+			connection, err := grpc.NewClient(
+				target,
+				append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))...,
+			)
+			require.NoError(t, err)
+			require.NoError(t, connection.Close())
+		})
+	}
+}
+
+func TestNewBalancing_Distribution(t *testing.T) {
+	testCases := []struct {
+		name              string
+		policy            string
+		healthCheck       bool
+		secondServing     bool
+		expectSecondCalls bool
+	}{
+		{
+			name:              "round robin",
+			policy:            config.RoundRobinPolicy,
+			healthCheck:       true,
+			secondServing:     true,
+			expectSecondCalls: true,
+		},
+		{
+			name:              "least request",
+			policy:            config.LeastRequestPolicy,
+			healthCheck:       true,
+			secondServing:     true,
+			expectSecondCalls: true,
+		},
+		{
+			name:              "unhealthy instance is skipped",
+			policy:            config.RoundRobinPolicy,
+			healthCheck:       true,
+			secondServing:     false,
+			expectSecondCalls: false,
+		},
+		{
+			name:              "health is not checked",
+			policy:            config.RoundRobinPolicy,
+			healthCheck:       false,
+			secondServing:     false,
+			expectSecondCalls: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first := startTestInstance(t)
+			second := startTestInstance(t)
+
+			if !tc.secondServing {
+				second.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+			}
+
+			target, options, err := NewBalancing(
+				"toys",
+				config.ClientConfig{
+					Balancing: config.BalancingConfig{
+						Addresses:   []string{first.address, second.address},
+						Policy:      tc.policy,
+						HealthCheck: tc.healthCheck,
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			connection, err := grpc.NewClient(
+				target,
+				append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))...,
+			)
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				_ = connection.Close()
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			call := func() {
+				require.NoError(t, connection.Invoke(ctx, testMethod, &emptypb.Empty{}, &emptypb.Empty{}))
+			}
+
+			if tc.expectSecondCalls {
+				// Calls are distributed between instances, when both of them are ready:
+				for first.calls.Load() == 0 || second.calls.Load() == 0 {
+					call()
+					require.NoError(t, ctx.Err())
+				}
+
+				return
+			}
+
+			for range 20 {
+				call()
+			}
+
+			assert.Equal(t, int32(20), first.calls.Load())
+			assert.Zero(t, second.calls.Load())
+		})
+	}
+}
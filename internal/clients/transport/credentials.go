@@ -0,0 +1,227 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+var (
+	errNoPeerCertificates = errors.New("server did not present certificate")
+	errUnknownServerName  = errors.New("server name to verify certificate is unknown")
+)
+
+// NewCredentials returns transport credentials of connection to downstream service. Plaintext credentials
+// are returned only, if insecure connection is explicitly enabled.
+func NewCredentials(tlsConfig config.TLSConfig, logger logging.Logger) (credentials.TransportCredentials, error) {
+	if tlsConfig.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+
+	reloader, err := newCertificatesReloader(tlsConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tlsCredentials{
+		TransportCredentials: credentials.NewTLS(reloader.tlsConfig(tlsConfig.ServerName)),
+		serverName:           tlsConfig.ServerName,
+		reloader:             reloader,
+	}, nil
+}
+
+// tlsCredentials verifies server certificate against configured server name or against host of dialed address.
+// Name of connection state could not be used for verification, because it is empty for IP addresses, which are
+// not sent in SNI.
+type tlsCredentials struct {
+	credentials.TransportCredentials
+	serverName string
+	reloader   *certificatesReloader
+}
+
+func (c *tlsCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	serverName := c.serverName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(authority)
+		if err != nil {
+			host = authority
+		}
+
+		serverName = host
+	}
+
+	return credentials.NewTLS(c.reloader.tlsConfig(serverName)).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *tlsCredentials) Clone() credentials.TransportCredentials {
+	return &tlsCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		serverName:           c.serverName,
+		reloader:             c.reloader,
+	}
+}
+
+// verifyServerCertificate verifies certificate chain of server and its name like tls package does.
+// System roots are used, if roots are nil. Certificate is rejected, if server name is empty.
+func verifyServerCertificate(state tls.ConnectionState, roots *x509.CertPool, serverName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return errNoPeerCertificates
+	}
+
+	if serverName == "" {
+		return errUnknownServerName
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(
+		x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			DNSName:       serverName,
+		},
+	)
+
+	return err
+}
+
+// certificatesReloader keeps CA bundle and client certificate, which are read from disk. Files are read
+// again on handshake, if reload interval has passed since previous read. Previous files are kept, if
+// new ones could not be read, so that certificate rotation does not break new connections.
+type certificatesReloader struct {
+	config   config.TLSConfig
+	logger   logging.Logger
+	mu       sync.Mutex
+	rootsCAs *x509.CertPool
+	cert     *tls.Certificate
+	loadedAt time.Time
+}
+
+func newCertificatesReloader(tlsConfig config.TLSConfig, logger logging.Logger) (*certificatesReloader, error) {
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return nil, errors.New("both client certificate and key files should be provided for mTLS")
+	}
+
+	reloader := &certificatesReloader{
+		config: tlsConfig,
+		logger: logger,
+	}
+
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// tlsConfig returns TLS config, which reads CA bundle and client certificate from reloader on each handshake.
+// Server certificate is verified manually, because roots of tls.Config could not be replaced.
+func (r *certificatesReloader) tlsConfig(serverName string) *tls.Config {
+	clientTLSConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         serverName,
+		InsecureSkipVerify: true, //nolint:gosec // Server certificate is verified by VerifyConnection
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return verifyServerCertificate(state, r.roots(), serverName)
+		},
+	}
+
+	if r.config.CertFile != "" {
+		clientTLSConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.certificate(), nil
+		}
+	}
+
+	return clientTLSConfig
+}
+
+func (r *certificatesReloader) roots() *x509.CertPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadIfExpired()
+
+	return r.rootsCAs
+}
+
+func (r *certificatesReloader) certificate() *tls.Certificate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadIfExpired()
+
+	return r.cert
+}
+
+// reloadIfExpired should be called under lock.
+func (r *certificatesReloader) reloadIfExpired() {
+	if r.config.ReloadInterval <= 0 || time.Since(r.loadedAt) < r.config.ReloadInterval {
+		return
+	}
+
+	if err := r.read(); err != nil {
+		// Files are not read again until next interval to avoid reading them on each handshake:
+		r.loadedAt = time.Now()
+		logging.LogError(r.logger, "Failed to reload TLS certificates, previous ones are used", err)
+	}
+}
+
+func (r *certificatesReloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read()
+}
+
+// read should be called under lock.
+func (r *certificatesReloader) read() error {
+	var roots *x509.CertPool
+
+	if r.config.CAFile != "" {
+		bundle, err := os.ReadFile(r.config.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("CA bundle %s does not contain certificates", r.config.CAFile)
+		}
+	}
+
+	var certificate *tls.Certificate
+
+	if r.config.CertFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client certificate: %w", err)
+		}
+
+		certificate = &keyPair
+	}
+
+	r.rootsCAs = roots
+	r.cert = certificate
+	r.loadedAt = time.Now()
+
+	return nil
+}
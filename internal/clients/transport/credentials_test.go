@@ -0,0 +1,349 @@
+package transport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+const (
+	testServerName = "toys.internal"
+	testAuthority  = testServerName + ":8060"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+	keyPEM      []byte
+}
+
+func (c testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	certificate, err := tls.X509KeyPair(c.pem, c.keyPEM)
+	require.NoError(t, err)
+
+	return certificate
+}
+
+// newTestCertificate creates certificate, signed by parent, or self-signed CA certificate, if parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return testCertificate{
+		certificate: certificate,
+		key:         key,
+		pem:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// handshake performs TLS handshake of client credentials, which dial authority, with server, which requires
+// client certificate, signed by CA.
+func handshake(
+	t *testing.T,
+	transportCredentials credentials.TransportCredentials,
+	authority string,
+	server testCertificate,
+	ca testCertificate,
+) error {
+	t.Helper()
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+
+	clientConnection, serverConnection := net.Pipe()
+	defer clientConnection.Close()
+	defer serverConnection.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		tlsServer := tls.Server(
+			serverConnection,
+			&tls.Config{
+				Certificates: []tls.Certificate{server.tlsCertificate(t)},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    clientCAs,
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"}, // Is required by gRPC clients
+			},
+		)
+
+		serverErr <- tlsServer.Handshake()
+		_ = serverConnection.Close()
+	}()
+
+	_, _, clientErr := transportCredentials.Clone().ClientHandshake(context.Background(), authority, clientConnection)
+	_ = clientConnection.Close()
+
+	if err := <-serverErr; clientErr == nil {
+		return err
+	}
+
+	return clientErr
+}
+
+func TestNewCredentials_TLS(t *testing.T) {
+	ca := newTestCertificate(t, "CA", nil)
+	otherCA := newTestCertificate(t, "Other CA", nil)
+	server := newTestCertificate(t, testServerName, &ca)
+	client := newTestCertificate(t, "hmtm-bff", &ca)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ca.pem"), ca.pem)
+	writeFile(t, filepath.Join(dir, "other-ca.pem"), otherCA.pem)
+	writeFile(t, filepath.Join(dir, "client.pem"), client.pem)
+	writeFile(t, filepath.Join(dir, "client-key.pem"), client.keyPEM)
+
+	testCases := []struct {
+		name                 string
+		tlsConfig            config.TLSConfig
+		configErrorExpected  bool
+		handshakeErrExpected bool
+	}{
+		{
+			name: "mTLS",
+			tlsConfig: config.TLSConfig{
+				CAFile:     filepath.Join(dir, "ca.pem"),
+				CertFile:   filepath.Join(dir, "client.pem"),
+				KeyFile:    filepath.Join(dir, "client-key.pem"),
+				ServerName: testServerName,
+			},
+		},
+		{
+			name: "untrusted server certificate",
+			tlsConfig: config.TLSConfig{
+				CAFile:     filepath.Join(dir, "other-ca.pem"),
+				CertFile:   filepath.Join(dir, "client.pem"),
+				KeyFile:    filepath.Join(dir, "client-key.pem"),
+				ServerName: testServerName,
+			},
+			handshakeErrExpected: true,
+		},
+		{
+			name: "server name mismatch",
+			tlsConfig: config.TLSConfig{
+				CAFile:     filepath.Join(dir, "ca.pem"),
+				CertFile:   filepath.Join(dir, "client.pem"),
+				KeyFile:    filepath.Join(dir, "client-key.pem"),
+				ServerName: "tickets.internal",
+			},
+			handshakeErrExpected: true,
+		},
+		{
+			name: "client certificate is required by server",
+			tlsConfig: config.TLSConfig{
+				CAFile:     filepath.Join(dir, "ca.pem"),
+				ServerName: testServerName,
+			},
+			handshakeErrExpected: true,
+		},
+		{
+			name: "client certificate without key",
+			tlsConfig: config.TLSConfig{
+				CAFile:   filepath.Join(dir, "ca.pem"),
+				CertFile: filepath.Join(dir, "client.pem"),
+			},
+			configErrorExpected: true,
+		},
+		{
+			name: "missing CA bundle",
+			tlsConfig: config.TLSConfig{
+				CAFile: filepath.Join(dir, "missing.pem"),
+			},
+			configErrorExpected: true,
+		},
+		{
+			name: "invalid CA bundle",
+			tlsConfig: config.TLSConfig{
+				CAFile: filepath.Join(dir, "client-key.pem"),
+			},
+			configErrorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transportCredentials, err := NewCredentials(tc.tlsConfig, nil)
+			if tc.configErrorExpected {
+				require.Error(t, err)
+				require.Nil(t, transportCredentials)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			err = handshake(t, transportCredentials, testAuthority, server, ca)
+			if tc.handshakeErrExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewCredentials_Reload(t *testing.T) {
+	ca := newTestCertificate(t, "CA", nil)
+	server := newTestCertificate(t, testServerName, &ca)
+	client := newTestCertificate(t, "hmtm-bff", &ca)
+
+	dir := t.TempDir()
+	tlsConfig := config.TLSConfig{
+		CAFile:         filepath.Join(dir, "ca.pem"),
+		CertFile:       filepath.Join(dir, "client.pem"),
+		KeyFile:        filepath.Join(dir, "client-key.pem"),
+		ServerName:     testServerName,
+		ReloadInterval: time.Millisecond * 200,
+	}
+
+	writeFile(t, tlsConfig.CAFile, ca.pem)
+	writeFile(t, tlsConfig.CertFile, client.pem)
+	writeFile(t, tlsConfig.KeyFile, client.keyPEM)
+
+	transportCredentials, err := NewCredentials(tlsConfig, nil)
+	require.NoError(t, err)
+	require.NoError(t, handshake(t, transportCredentials, testAuthority, server, ca))
+
+	// Certificates are rotated on disk:
+	rotatedCA := newTestCertificate(t, "Rotated CA", nil)
+	rotatedServer := newTestCertificate(t, testServerName, &rotatedCA)
+	rotatedClient := newTestCertificate(t, "hmtm-bff", &rotatedCA)
+
+	writeFile(t, tlsConfig.CAFile, rotatedCA.pem)
+	writeFile(t, tlsConfig.CertFile, rotatedClient.pem)
+	writeFile(t, tlsConfig.KeyFile, rotatedClient.keyPEM)
+
+	// Previous certificates are used until reload interval passes:
+	require.Error(t, handshake(t, transportCredentials, testAuthority, rotatedServer, rotatedCA))
+
+	time.Sleep(tlsConfig.ReloadInterval)
+	require.NoError(t, handshake(t, transportCredentials, testAuthority, rotatedServer, rotatedCA))
+}
+
+func TestNewCredentials(t *testing.T) {
+	transportCredentials, err := NewCredentials(config.TLSConfig{Insecure: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "insecure", transportCredentials.Info().SecurityProtocol)
+
+	// System roots are used without CA bundle:
+	transportCredentials, err = NewCredentials(config.TLSConfig{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "tls", transportCredentials.Info().SecurityProtocol)
+}
+
+func TestNewCredentials_ServerName(t *testing.T) {
+	ca := newTestCertificate(t, "CA", nil)
+	server := newTestCertificate(t, testServerName, &ca)
+	client := newTestCertificate(t, "hmtm-bff", &ca)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ca.pem"), ca.pem)
+	writeFile(t, filepath.Join(dir, "client.pem"), client.pem)
+	writeFile(t, filepath.Join(dir, "client-key.pem"), client.keyPEM)
+
+	testCases := []struct {
+		name                 string
+		serverName           string
+		authority            string
+		handshakeErrExpected bool
+	}{
+		{
+			name:      "host of dialed address",
+			authority: testServerName + ":8060",
+		},
+		{
+			name:                 "host of dialed address mismatch",
+			authority:            "tickets.internal:8060",
+			handshakeErrExpected: true,
+		},
+		{
+			// Name of connection state is empty for IP addresses, so that it could not be used for verification:
+			name:                 "IP address of dialed address",
+			authority:            "127.0.0.1:8060",
+			handshakeErrExpected: true,
+		},
+		{
+			name:       "configured server name with IP address of dialed address",
+			serverName: testServerName,
+			authority:  "127.0.0.1:8060",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transportCredentials, err := NewCredentials(
+				config.TLSConfig{
+					CAFile:     filepath.Join(dir, "ca.pem"),
+					CertFile:   filepath.Join(dir, "client.pem"),
+					KeyFile:    filepath.Join(dir, "client-key.pem"),
+					ServerName: tc.serverName,
+				},
+				nil,
+			)
+			require.NoError(t, err)
+
+			err = handshake(t, transportCredentials, tc.authority, server, ca)
+			if tc.handshakeErrExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
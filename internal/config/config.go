@@ -3,6 +3,11 @@ package config
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DKhorkov/libs/cookies"
@@ -13,9 +18,18 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// localEnvironment is environment of local docker compose stack:
+const localEnvironment = "local"
+
+// insecureEnvironments are environments, where plaintext connections to downstream services are allowed:
+var insecureEnvironments = []string{localEnvironment, "dev"}
+
 func New() Config {
+	environment := loadenv.GetEnv("ENVIRONMENT", localEnvironment)
+	insecureAllowed := insecureConnectionsAllowed()
+
 	return Config{
-		Environment: loadenv.GetEnv("ENVIRONMENT", "local"),
+		Environment: environment,
 		Version:     loadenv.GetEnv("VERSION", "latest"),
 		HTTP: HTTPConfig{
 			Host: loadenv.GetEnv("HOST", "0.0.0.0"),
@@ -29,39 +43,137 @@ func New() Config {
 			TimeoutHandlerTimeout: time.Second * time.Duration(
 				loadenv.GetEnvAsInt("HTTP_TIMEOUT_HANDLER_TIMEOUT", 2),
 			),
+			OperationBudget: time.Millisecond * time.Duration(
+				loadenv.GetEnvAsInt("HTTP_OPERATION_BUDGET", 1900),
+			),
+			OperationBudgets: durations(
+				"budget of operation",
+				loadenv.GetEnvAsSlice("HTTP_OPERATIONS_BUDGET", []string{}, ", "),
+			),
+			TrustForwardedFor: loadenv.GetEnvAsBool("HTTP_TRUST_FORWARDED_FOR", false),
+			DegradableFields: loadenv.GetEnvAsSlice(
+				"HTTP_DEGRADABLE_FIELDS",
+				[]string{
+					"Master.user",
+					"Ticket.user",
+					"Email.user",
+					"Toy.category",
+					"Ticket.category",
+					"Toy.tags",
+					"Ticket.tags",
+					"Query.myEmailCommunications",
+				},
+				", ",
+			),
 		},
 		Clients: ClientsConfig{
 			SSO: ClientConfig{
-				Host:         loadenv.GetEnv("SSO_CLIENT_HOST", "0.0.0.0"),
-				Port:         loadenv.GetEnvAsInt("SSO_CLIENT_PORT", 8070),
-				RetriesCount: loadenv.GetEnvAsInt("SSO_RETRIES_COUNT", 3),
-				RetryTimeout: time.Second * time.Duration(
-					loadenv.GetEnvAsInt("SSO_RETRIES_TIMEOUT", 1),
-				),
+				Host:  loadenv.GetEnv("SSO_CLIENT_HOST", "0.0.0.0"),
+				Port:  loadenv.GetEnvAsInt("SSO_CLIENT_PORT", 8070),
+				Retry: retryConfig("SSO"),
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: loadenv.GetEnvAsInt("SSO_CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+					OpenTimeout: time.Second * time.Duration(
+						loadenv.GetEnvAsInt("SSO_CIRCUIT_BREAKER_OPEN_TIMEOUT", 10),
+					),
+					HalfOpenProbes: loadenv.GetEnvAsInt("SSO_CIRCUIT_BREAKER_HALF_OPEN_PROBES", 1),
+				},
+				MaxConcurrentCalls: loadenv.GetEnvAsInt("SSO_MAX_CONCURRENT_CALLS", 100),
+				Timeouts: TimeoutsConfig{
+					Default: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("SSO_TIMEOUT", 1500),
+					),
+					Methods: durations(
+						"timeout of method",
+						loadenv.GetEnvAsSlice("SSO_METHODS_TIMEOUT", []string{}, ", "),
+					),
+					MinRemaining: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("SSO_MIN_REMAINING_BUDGET", 10),
+					),
+				},
+				TLS:       clientTLSConfig("SSO", insecureAllowed),
+				Balancing: balancingConfig("SSO"),
 			},
 			Toys: ClientConfig{
-				Host:         loadenv.GetEnv("TOYS_CLIENT_HOST", "0.0.0.0"),
-				Port:         loadenv.GetEnvAsInt("TOYS_CLIENT_PORT", 8060),
-				RetriesCount: loadenv.GetEnvAsInt("TOYS_RETRIES_COUNT", 3),
-				RetryTimeout: time.Second * time.Duration(
-					loadenv.GetEnvAsInt("TOYS_RETRIES_TIMEOUT", 1),
-				),
+				Host:  loadenv.GetEnv("TOYS_CLIENT_HOST", "0.0.0.0"),
+				Port:  loadenv.GetEnvAsInt("TOYS_CLIENT_PORT", 8060),
+				Retry: retryConfig("TOYS"),
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: loadenv.GetEnvAsInt("TOYS_CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+					OpenTimeout: time.Second * time.Duration(
+						loadenv.GetEnvAsInt("TOYS_CIRCUIT_BREAKER_OPEN_TIMEOUT", 10),
+					),
+					HalfOpenProbes: loadenv.GetEnvAsInt("TOYS_CIRCUIT_BREAKER_HALF_OPEN_PROBES", 1),
+				},
+				MaxConcurrentCalls: loadenv.GetEnvAsInt("TOYS_MAX_CONCURRENT_CALLS", 100),
+				Timeouts: TimeoutsConfig{
+					Default: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("TOYS_TIMEOUT", 1500),
+					),
+					Methods: durations(
+						"timeout of method",
+						loadenv.GetEnvAsSlice("TOYS_METHODS_TIMEOUT", []string{}, ", "),
+					),
+					MinRemaining: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("TOYS_MIN_REMAINING_BUDGET", 10),
+					),
+				},
+				TLS:       clientTLSConfig("TOYS", insecureAllowed),
+				Balancing: balancingConfig("TOYS"),
 			},
 			Tickets: ClientConfig{
-				Host:         loadenv.GetEnv("TICKETS_CLIENT_HOST", "0.0.0.0"),
-				Port:         loadenv.GetEnvAsInt("TICKETS_CLIENT_PORT", 8050),
-				RetriesCount: loadenv.GetEnvAsInt("TICKETS_RETRIES_COUNT", 3),
-				RetryTimeout: time.Second * time.Duration(
-					loadenv.GetEnvAsInt("TICKETS_RETRIES_TIMEOUT", 1),
-				),
+				Host:  loadenv.GetEnv("TICKETS_CLIENT_HOST", "0.0.0.0"),
+				Port:  loadenv.GetEnvAsInt("TICKETS_CLIENT_PORT", 8050),
+				Retry: retryConfig("TICKETS"),
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: loadenv.GetEnvAsInt("TICKETS_CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+					OpenTimeout: time.Second * time.Duration(
+						loadenv.GetEnvAsInt("TICKETS_CIRCUIT_BREAKER_OPEN_TIMEOUT", 10),
+					),
+					HalfOpenProbes: loadenv.GetEnvAsInt("TICKETS_CIRCUIT_BREAKER_HALF_OPEN_PROBES", 1),
+				},
+				MaxConcurrentCalls: loadenv.GetEnvAsInt("TICKETS_MAX_CONCURRENT_CALLS", 100),
+				Timeouts: TimeoutsConfig{
+					Default: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("TICKETS_TIMEOUT", 1500),
+					),
+					Methods: durations(
+						"timeout of method",
+						loadenv.GetEnvAsSlice("TICKETS_METHODS_TIMEOUT", []string{}, ", "),
+					),
+					MinRemaining: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("TICKETS_MIN_REMAINING_BUDGET", 10),
+					),
+				},
+				TLS:       clientTLSConfig("TICKETS", insecureAllowed),
+				Balancing: balancingConfig("TICKETS"),
 			},
 			Notifications: ClientConfig{
-				Host:         loadenv.GetEnv("NOTIFICATIONS_CLIENT_HOST", "0.0.0.0"),
-				Port:         loadenv.GetEnvAsInt("NOTIFICATIONS_CLIENT_HOST_CLIENT_PORT", 8040),
-				RetriesCount: loadenv.GetEnvAsInt("NOTIFICATIONS_CLIENT_HOST_RETRIES_COUNT", 3),
-				RetryTimeout: time.Second * time.Duration(
-					loadenv.GetEnvAsInt("NOTIFICATIONS_CLIENT_HOST_RETRIES_TIMEOUT", 1),
-				),
+				Host:  loadenv.GetEnv("NOTIFICATIONS_CLIENT_HOST", "0.0.0.0"),
+				Port:  loadenv.GetEnvAsInt("NOTIFICATIONS_CLIENT_HOST_CLIENT_PORT", 8040),
+				Retry: retryConfig("NOTIFICATIONS_CLIENT_HOST"),
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: loadenv.GetEnvAsInt("NOTIFICATIONS_CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+					OpenTimeout: time.Second * time.Duration(
+						loadenv.GetEnvAsInt("NOTIFICATIONS_CIRCUIT_BREAKER_OPEN_TIMEOUT", 10),
+					),
+					HalfOpenProbes: loadenv.GetEnvAsInt("NOTIFICATIONS_CIRCUIT_BREAKER_HALF_OPEN_PROBES", 1),
+				},
+				MaxConcurrentCalls: loadenv.GetEnvAsInt("NOTIFICATIONS_MAX_CONCURRENT_CALLS", 100),
+				Timeouts: TimeoutsConfig{
+					Default: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("NOTIFICATIONS_TIMEOUT", 1500),
+					),
+					Methods: durations(
+						"timeout of method",
+						loadenv.GetEnvAsSlice("NOTIFICATIONS_METHODS_TIMEOUT", []string{}, ", "),
+					),
+					MinRemaining: time.Millisecond * time.Duration(
+						loadenv.GetEnvAsInt("NOTIFICATIONS_MIN_REMAINING_BUDGET", 10),
+					),
+				},
+				TLS:       clientTLSConfig("NOTIFICATIONS", insecureAllowed),
+				Balancing: balancingConfig("NOTIFICATIONS"),
 			},
 		},
 		Logging: logging.Config{
@@ -74,6 +186,18 @@ func New() Config {
 			AllowedHeaders:   loadenv.GetEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"*"}, ", "),
 			AllowCredentials: loadenv.GetEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
 			MaxAge:           loadenv.GetEnvAsInt("CORS_MAX_AGE", 600),
+			ExposedHeaders: loadenv.GetEnvAsSlice(
+				"CORS_EXPOSED_HEADERS",
+				[]string{
+					"Location",
+					"Tus-Resumable",
+					"Tus-Version",
+					"Tus-Extension",
+					"Upload-Offset",
+					"Upload-Length",
+				},
+				", ",
+			),
 		},
 		Cookies: CookiesConfig{
 			AccessToken: cookies.Config{
@@ -109,12 +233,22 @@ func New() Config {
 			Region:          loadenv.GetEnv("S3_REGION", ""),
 			Bucket:          loadenv.GetEnv("S3_BUCKET", ""),
 			ACL:             loadenv.GetEnv("S3_ACL", "public-read"),
+			PrivateACL:      loadenv.GetEnv("S3_PRIVATE_ACL", "private"),
+			PresignExpiry: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("S3_PRESIGN_EXPIRY", 15),
+			),
 			Timeout: time.Second * time.Duration(
 				loadenv.GetEnvAsInt("S3_TIMEOUT", 60),
 			),
 		},
 		Validation: ValidationConfig{
 			FileMaxSize: int64(loadenv.GetEnvAsInt("FILE_MAX_SIZE", 5*1024*1024)), // 5 Mb
+			UserStorageLimit: int64(
+				loadenv.GetEnvAsInt("USER_STORAGE_LIMIT", 50*1024*1024), // 50 Mb
+			),
+			MasterStorageLimit: int64(
+				loadenv.GetEnvAsInt("MASTER_STORAGE_LIMIT", 500*1024*1024), // 500 Mb
+			),
 			FileAllowedExtensions: loadenv.GetEnvAsSlice(
 				"FILE_ALLOWED_EXTENSIONS",
 				[]string{
@@ -319,6 +453,126 @@ func New() Config {
 
 			// Use value from HMTM_BFF_CACHE_OUTER_PORT for local launch:
 			Port: loadenv.GetEnvAsInt("REDIS_PORT", 6379),
+			Stampede: CacheStampedeConfig{
+				LockEnabled: loadenv.GetEnvAsBool("CACHE_STAMPEDE_LOCK_ENABLED", false),
+				LockTTL: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_STAMPEDE_LOCK_TTL", 10),
+				),
+				LockWaitTimeout: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_STAMPEDE_LOCK_WAIT_TIMEOUT", 3000),
+				),
+				LockPollInterval: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_STAMPEDE_LOCK_POLL_INTERVAL", 50),
+				),
+				EarlyExpirationBeta: float64(
+					loadenv.GetEnvAsInt("CACHE_EARLY_EXPIRATION_BETA_PERCENT", 100),
+				) / 100,
+			},
+			CircuitBreaker: CircuitBreakerConfig{
+				FailureThreshold: loadenv.GetEnvAsInt("CACHE_CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+				OpenTimeout: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_CIRCUIT_BREAKER_OPEN_TIMEOUT", 10),
+				),
+				HalfOpenProbes: loadenv.GetEnvAsInt("CACHE_CIRCUIT_BREAKER_HALF_OPEN_PROBES", 1),
+			},
+			Policies: cachePolicies(
+				loadenv.GetEnvAsSlice("CACHE_DISABLED_POLICIES", []string{}, ", "),
+				loadenv.GetEnvAsSlice("CACHE_POLICIES_TTL", []string{}, ", "),
+			),
+			AccessTokens: AccessTokensCacheConfig{
+				Size: loadenv.GetEnvAsInt("CACHE_ACCESS_TOKENS_SIZE", 10000),
+				TTL: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_ACCESS_TOKENS_TTL", 10),
+				),
+			},
+			Local: LocalCacheConfig{
+				Enabled: loadenv.GetEnvAsBool("CACHE_LOCAL_ENABLED", true),
+				Size:    loadenv.GetEnvAsInt("CACHE_LOCAL_SIZE", 10000),
+				TTL: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_LOCAL_TTL", 30),
+				),
+				Prefixes: loadenv.GetEnvAsSlice(
+					"CACHE_LOCAL_PREFIXES",
+					[]string{
+						"get_categories",
+						"get_category_by_id",
+						"get_tags",
+						"get_tag_by_id",
+						"get_toy_by_id",
+					},
+					", ",
+				),
+			},
+			NotFoundTTL: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("CACHE_NOT_FOUND_TTL", 30),
+			),
+			Warmup: CacheWarmupConfig{
+				Enabled: loadenv.GetEnvAsBool("CACHE_WARMUP_ENABLED", true),
+				Interval: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_WARMUP_INTERVAL", 0),
+				),
+				Budget: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CACHE_WARMUP_BUDGET", 10),
+				),
+				Concurrency: loadenv.GetEnvAsInt("CACHE_WARMUP_CONCURRENCY", 4),
+				Pages:       uint64(loadenv.GetEnvAsInt("CACHE_WARMUP_PAGES", 3)),
+				PageSize:    uint64(loadenv.GetEnvAsInt("CACHE_WARMUP_PAGE_SIZE", 20)),
+			},
+			Encoding: CacheEncodingConfig{
+				Codec:                loadenv.GetEnv("CACHE_CODEC", "msgpack"),
+				Compression:          loadenv.GetEnv("CACHE_COMPRESSION", "zstd"),
+				CompressionThreshold: loadenv.GetEnvAsInt("CACHE_COMPRESSION_THRESHOLD", 1024),
+			},
+		},
+		FilesGC: FilesGCConfig{
+			Enabled: loadenv.GetEnvAsBool("FILES_GC_ENABLED", false),
+			DryRun:  loadenv.GetEnvAsBool("FILES_GC_DRY_RUN", true),
+			Interval: time.Hour * time.Duration(
+				loadenv.GetEnvAsInt("FILES_GC_INTERVAL", 6),
+			),
+			GracePeriod: time.Hour * time.Duration(
+				loadenv.GetEnvAsInt("FILES_GC_GRACE_PERIOD", 24),
+			),
+			LockTTL: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("FILES_GC_LOCK_TTL", 30),
+			),
+			PageSize: uint64(loadenv.GetEnvAsInt("FILES_GC_PAGE_SIZE", 100)),
+		},
+		Compensations: CompensationsConfig{
+			RetryEnabled: loadenv.GetEnvAsBool("COMPENSATIONS_RETRY_ENABLED", true),
+			RetryInterval: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("COMPENSATIONS_RETRY_INTERVAL", 5),
+			),
+			RetryBatchSize: loadenv.GetEnvAsInt("COMPENSATIONS_RETRY_BATCH_SIZE", 100),
+			MaxAttempts:    loadenv.GetEnvAsInt("COMPENSATIONS_MAX_ATTEMPTS", 10),
+			PageSize:       uint64(loadenv.GetEnvAsInt("COMPENSATIONS_PAGE_SIZE", 100)),
+		},
+		Migrations: MigrationsConfig{
+			PrivateTicketAttachments: loadenv.GetEnvAsBool("MIGRATIONS_PRIVATE_TICKET_ATTACHMENTS", false),
+			PageSize:                 uint64(loadenv.GetEnvAsInt("MIGRATIONS_PAGE_SIZE", 100)),
+		},
+		Uploads: UploadsConfig{
+			TTL: time.Hour * time.Duration(
+				loadenv.GetEnvAsInt("UPLOADS_TTL", 24),
+			),
+			MaxChunkSize: int64(
+				loadenv.GetEnvAsInt("UPLOADS_MAX_CHUNK_SIZE", 5*1024*1024), // 5 Mb
+			),
+			ChunkTimeout: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("UPLOADS_CHUNK_TIMEOUT", 60),
+			),
+		},
+		State: StateConfig{
+			// Cache Redis is used by default for backward compatibility:
+			Host:     loadenv.GetEnv("STATE_REDIS_HOST", loadenv.GetEnv("REDIS_HOST", "0.0.0.0")),
+			Port:     loadenv.GetEnvAsInt("STATE_REDIS_PORT", loadenv.GetEnvAsInt("REDIS_PORT", 6379)),
+			Password: loadenv.GetEnv("STATE_REDIS_PASSWORD", loadenv.GetEnv("REDIS_PASSWORD", "")),
+			DB:       loadenv.GetEnvAsInt("STATE_REDIS_DB", 0),
+		},
+		Fake: FakeConfig{
+			FilesDir:  loadenv.GetEnv("FAKE_FILES_DIR", filepath.Join(os.TempDir(), "hmtm-bff-files")),
+			FilesHost: loadenv.GetEnv("FAKE_FILES_HOST", "localhost"),
+			FilesPort: loadenv.GetEnvAsInt("FAKE_FILES_PORT", 8090),
 		},
 	}
 }
@@ -329,21 +583,91 @@ type HTTPConfig struct {
 	ReadHeaderTimeout     time.Duration
 	ReadTimeout           time.Duration
 	TimeoutHandlerTimeout time.Duration
+	OperationBudget       time.Duration            // Deadline of GraphQL operation, including all downstream calls
+	OperationBudgets      map[string]time.Duration // Overrides of budget by operation name
+	TrustForwardedFor     bool                     // Client IP is taken from X-Forwarded-For, set by proxy
+	DegradableFields      []string                 // "Type.field" fields, which are null, if downstream is unavailable
 }
 
 type CORSConfig struct {
 	AllowedOrigins   []string
 	AllowedMethods   []string
 	AllowedHeaders   []string
+	ExposedHeaders   []string // Headers, which are available for reading by browser scripts
 	MaxAge           int
 	AllowCredentials bool
 }
 
 type ClientConfig struct {
-	Host         string
-	Port         int
-	RetryTimeout time.Duration
-	RetriesCount int
+	Host               string
+	Port               int
+	Retry              RetryConfig
+	CircuitBreaker     CircuitBreakerConfig
+	MaxConcurrentCalls int // Max number of concurrent calls to downstream service. Zero disables limit
+	Timeouts           TimeoutsConfig
+	TLS                TLSConfig
+	Balancing          BalancingConfig
+}
+
+const (
+	RoundRobinPolicy   = "round_robin"
+	LeastRequestPolicy = "least_request"
+)
+
+// BalancingConfig configures distribution of calls between instances of downstream service. Target has
+// priority over Addresses, which have priority over Host and Port of client. Host is resolved via DNS,
+// so calls are distributed between all its addresses.
+type BalancingConfig struct {
+	Target             string   // gRPC target with resolver scheme, for example "dns:///toys:8060"
+	Addresses          []string // Static "host:port" addresses of instances
+	Policy             string   // RoundRobinPolicy or LeastRequestPolicy
+	HealthCheck        bool     // Instances are checked by standard gRPC health protocol
+	HealthCheckService string   // Service name in health checks. Empty name means whole server
+	Keepalive          KeepaliveConfig
+}
+
+// KeepaliveConfig configures pings of idle connections, so that broken connections are detected before calls.
+type KeepaliveConfig struct {
+	Time                time.Duration // Interval of pings. Zero disables pings
+	Timeout             time.Duration // Time to wait for ping acknowledgement before closing connection
+	PermitWithoutStream bool          // Pings are sent without active calls
+}
+
+// TLSConfig configures transport security of connection to downstream service. Server certificate is
+// verified by CA bundle or by system roots, if bundle is not provided. Client certificate is presented for
+// mTLS, if provided. Files are reloaded from disk, so that rotated certificates are used by new connections.
+type TLSConfig struct {
+	Insecure       bool   // Plaintext connection, which is allowed only in local environment
+	CAFile         string // PEM bundle of trusted CA certificates
+	CertFile       string // PEM client certificate for mTLS
+	KeyFile        string // PEM private key of client certificate
+	ServerName     string // Overrides server name, which is verified in server certificate
+	ReloadInterval time.Duration
+}
+
+// TimeoutsConfig limits duration of downstream gRPC calls. Call is bounded by both method timeout and
+// remaining budget of operation, which is propagated to downstream service as gRPC deadline.
+type TimeoutsConfig struct {
+	Default      time.Duration            // Timeout of method call, including retries. Zero disables timeout
+	Methods      map[string]time.Duration // Overrides of timeout by method name, e.g. GetToys
+	MinRemaining time.Duration            // Call fails fast, if less budget remains
+}
+
+// RetryConfig configures retries of downstream gRPC calls. Read methods (Get* and Count*) are retried on
+// Unavailable and ResourceExhausted codes. Write methods are retried only, if call has idempotency key,
+// and are not retried by default, because downstream services do not deduplicate calls by such key yet.
+type RetryConfig struct {
+	Reads   RetryPolicyConfig
+	Writes  RetryPolicyConfig            // Applied only to calls with idempotency key
+	Methods map[string]RetryPolicyConfig // Overrides of policies by method name, e.g. GetToys
+	Jitter  float64                      // Max fraction of backoff, which is randomly added or subtracted
+}
+
+type RetryPolicyConfig struct {
+	Disabled    bool
+	MaxAttempts int           // Number of attempts, including first one. Zero keeps default of method type
+	Timeout     time.Duration // Timeout of each attempt. Zero keeps default of method type
+	Backoff     time.Duration // Backoff before second attempt, which is doubled for each next one
 }
 
 type ClientsConfig struct {
@@ -363,13 +687,17 @@ type S3Config struct {
 	Bucket          string
 	AccessKeyID     string
 	SecretAccessKey string
-	ACL             string
+	ACL             string        // ACL for public files
+	PrivateACL      string        // ACL for private files, which are available only via presigned URLs
+	PresignExpiry   time.Duration // Lifetime of presigned URLs for private files
 	Timeout         time.Duration
 }
 
 type ValidationConfig struct {
 	FileMaxSize           int64
 	FileAllowedExtensions []string
+	UserStorageLimit      int64 // Total size of files in bytes, which could be uploaded by User
+	MasterStorageLimit    int64 // Total size of files in bytes, which could be uploaded by Master
 }
 
 type TracingConfig struct {
@@ -390,21 +718,338 @@ type SpanClients struct {
 }
 
 type CacheConfig struct {
+	Host           string
+	Port           int
+	Password       string
+	Stampede       CacheStampedeConfig
+	CircuitBreaker CircuitBreakerConfig
+	Policies       map[string]CachePolicyConfig // Overrides of cache policies by policy name
+	Local          LocalCacheConfig
+	Encoding       CacheEncodingConfig
+	NotFoundTTL    time.Duration // TTL of remembered NotFound results of lookups by ID. Zero disables them
+	Warmup         CacheWarmupConfig
+	AccessTokens   AccessTokensCacheConfig
+}
+
+// AccessTokensCacheConfig configures in-process cache of owners of access tokens, which are resolved by SSO
+// to read cached data of authorized users. Zero TTL disables cache, so that SSO is called for each read.
+type AccessTokensCacheConfig struct {
+	Size int           // Max number of tokens. Least recently used tokens are evicted first
+	TTL  time.Duration // Should be short, because token could expire or be revoked in SSO
+}
+
+// CacheWarmupConfig configures pre-population of frequently read values after startup and,
+// optionally, periodically.
+type CacheWarmupConfig struct {
+	Enabled     bool
+	Interval    time.Duration // Interval between periodic warmups. Zero disables them
+	Budget      time.Duration // Max duration of warmup. Readiness is not delayed longer
+	Concurrency int           // Max number of simultaneously warmed values
+	Pages       uint64        // Number of first pages of toys and tickets, which are warmed
+	PageSize    uint64        // Should match page size of frontend, because pagination is part of cache key
+}
+
+// CacheEncodingConfig configures encoding of values, which are stored in Redis.
+type CacheEncodingConfig struct {
+	Codec                string // json or msgpack. Empty value means json
+	Compression          string // zstd, snappy or none. Empty value means none
+	CompressionThreshold int    // Min size of encoded value in bytes, which is compressed
+}
+
+// CachePolicyConfig overrides defaults of cache policy, which describes caching of read use case.
+type CachePolicyConfig struct {
+	Disabled bool
+	TTL      time.Duration // Zero keeps default TTL of policy
+}
+
+// CacheStampedeConfig configures protection from simultaneous recomputation of missing cache entries.
+type CacheStampedeConfig struct {
+	LockEnabled         bool          // Coalesces recomputation across BFF instances by Redis lock
+	LockTTL             time.Duration // Lock expires, even if lock owner failed to release it
+	LockWaitTimeout     time.Duration // Value is recomputed locally, if lock owner did not cache it in time
+	LockPollInterval    time.Duration
+	EarlyExpirationBeta float64 // Values above 1 favor earlier recomputation. Zero disables early expiration
+}
+
+// CircuitBreakerConfig configures circuit breaker, which stops calls to failing dependency.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures, which open circuit. Zero disables circuit breaker
+	OpenTimeout      time.Duration // Time before probing dependency again
+	HalfOpenProbes   int           // Max number of probe calls in half-open state
+}
+
+// LocalCacheConfig configures in-process cache tier, which is checked before Redis.
+type LocalCacheConfig struct {
+	Enabled  bool
+	Size     int           // Max number of entries. Least recently used entries are evicted first
+	TTL      time.Duration // Should be short, because invalidation broadcast could be missed
+	Prefixes []string      // Names of cache policies, which values are cached locally
+}
+
+// FilesGCConfig configures garbage collector, which removes files from storage,
+// that are not referenced by any toy, ticket or user avatar.
+type FilesGCConfig struct {
+	Enabled     bool
+	DryRun      bool          // Only logs orphaned files without deleting them
+	Interval    time.Duration // Interval between collector runs
+	GracePeriod time.Duration // Files younger than grace period are never deleted
+	LockTTL     time.Duration // TTL of distributed lock, which prevents concurrent runs
+	PageSize    uint64        // Page size for fetching toys, tickets and users
+}
+
+// CompensationsConfig configures retry of Compensations, which failed during saga rollback.
+type CompensationsConfig struct {
+	RetryEnabled   bool
+	RetryInterval  time.Duration
+	RetryBatchSize int    // Max amount of Compensations, processed during single retry
+	MaxAttempts    int    // Compensation is dropped after reaching max attempts
+	PageSize       uint64 // Page size for fetching toys, tickets and users, which could use files to be deleted
+}
+
+// MigrationsConfig configures one-time migrations of stored data, which are run on startup.
+type MigrationsConfig struct {
+	PrivateTicketAttachments bool   // Makes attachments of Tickets, which were uploaded as public files, private
+	PageSize                 uint64 // Page size for fetching migrated entities
+}
+
+// UploadsConfig configures resumable uploads, which are received by chunks via tus protocol.
+type UploadsConfig struct {
+	TTL          time.Duration // Not completed or not used upload is removed after TTL
+	MaxChunkSize int64         // Max size of chunk in bytes, which could be received by single request
+	ChunkTimeout time.Duration // Max time of receiving and writing single chunk instead of HTTP timeouts
+}
+
+// StateConfig configures Redis, which keeps state of BFF: storage usage of users, pending compensations and
+// resumable uploads. Unlike cache Redis, it should persist data and should not be flushed.
+type StateConfig struct {
 	Host     string
 	Port     int
 	Password string
+	DB       int
+}
+
+// FakeConfig is used only by fake mode of server, which works without downstream services.
+type FakeConfig struct {
+	FilesDir  string // Directory, where uploaded files are stored instead of S3
+	FilesHost string
+	FilesPort int
 }
 
 type Config struct {
-	HTTP        HTTPConfig
-	CORS        CORSConfig
-	Clients     ClientsConfig
-	Logging     logging.Config
-	Cookies     CookiesConfig
-	S3          S3Config
-	Validation  ValidationConfig
-	Tracing     TracingConfig
-	Environment string
-	Version     string
-	Cache       CacheConfig
+	HTTP          HTTPConfig
+	CORS          CORSConfig
+	Clients       ClientsConfig
+	Logging       logging.Config
+	Cookies       CookiesConfig
+	S3            S3Config
+	Validation    ValidationConfig
+	Tracing       TracingConfig
+	Environment   string
+	Version       string
+	Cache         CacheConfig
+	FilesGC       FilesGCConfig
+	Compensations CompensationsConfig
+	Migrations    MigrationsConfig
+	Uploads       UploadsConfig
+	State         StateConfig
+	Fake          FakeConfig
+}
+
+// cachePolicies builds overrides of cache policies from names of disabled policies and
+// "name=duration" TTL pairs. Invalid TTL is a misconfiguration, so it stops the application.
+func cachePolicies(disabled, ttls []string) map[string]CachePolicyConfig {
+	policies := make(map[string]CachePolicyConfig)
+
+	for _, name := range disabled {
+		if name == "" {
+			continue
+		}
+
+		policy := policies[name]
+		policy.Disabled = true
+		policies[name] = policy
+	}
+
+	for _, pair := range ttls {
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			panic(fmt.Sprintf("invalid TTL of cache policy %s: %q", name, value))
+		}
+
+		policy := policies[name]
+		policy.TTL = ttl
+		policies[name] = policy
+	}
+
+	return policies
+}
+
+// retryConfig loads retry config of downstream service by prefix of its environment variables.
+func retryConfig(envPrefix string) RetryConfig {
+	timeout := time.Second * time.Duration(loadenv.GetEnvAsInt(envPrefix+"_RETRIES_TIMEOUT", 1))
+	backoff := time.Millisecond * time.Duration(loadenv.GetEnvAsInt(envPrefix+"_RETRIES_BACKOFF", 50))
+
+	return RetryConfig{
+		Reads: RetryPolicyConfig{
+			MaxAttempts: loadenv.GetEnvAsInt(envPrefix+"_RETRIES_COUNT", 3),
+			Timeout:     timeout,
+			Backoff:     backoff,
+		},
+		Writes: RetryPolicyConfig{
+			MaxAttempts: loadenv.GetEnvAsInt(envPrefix+"_IDEMPOTENT_WRITES_RETRIES_COUNT", 1),
+			Timeout:     timeout,
+			Backoff:     backoff,
+		},
+		Methods: retryPolicies(
+			loadenv.GetEnvAsSlice(envPrefix+"_RETRIES_DISABLED_METHODS", []string{}, ", "),
+			loadenv.GetEnvAsSlice(envPrefix+"_RETRIES_METHODS_COUNT", []string{}, ", "),
+			durations(
+				"retry timeout of method",
+				loadenv.GetEnvAsSlice(envPrefix+"_RETRIES_METHODS_TIMEOUT", []string{}, ", "),
+			),
+			durations(
+				"retry backoff of method",
+				loadenv.GetEnvAsSlice(envPrefix+"_RETRIES_METHODS_BACKOFF", []string{}, ", "),
+			),
+		),
+		Jitter: float64(loadenv.GetEnvAsInt(envPrefix+"_RETRIES_JITTER_PERCENT", 20)) / 100,
+	}
+}
+
+// retryPolicies parses overrides of retry policies by method name. Attempts are provided as pairs
+// of method name and number of attempts, e.g. GetToys=5. Timeouts and backoffs are already parsed.
+func retryPolicies(
+	disabled, attempts []string,
+	timeouts, backoffs map[string]time.Duration,
+) map[string]RetryPolicyConfig {
+	policies := make(map[string]RetryPolicyConfig)
+
+	for _, method := range disabled {
+		if method == "" {
+			continue
+		}
+
+		policy := policies[method]
+		policy.Disabled = true
+		policies[method] = policy
+	}
+
+	for _, pair := range attempts {
+		if pair == "" {
+			continue
+		}
+
+		method, value, _ := strings.Cut(pair, "=")
+
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil || maxAttempts <= 0 {
+			panic(fmt.Sprintf("invalid number of retry attempts of method %s: %q", method, value))
+		}
+
+		policy := policies[method]
+		policy.MaxAttempts = maxAttempts
+		policies[method] = policy
+	}
+
+	for method, timeout := range timeouts {
+		policy := policies[method]
+		policy.Timeout = timeout
+		policies[method] = policy
+	}
+
+	for method, backoff := range backoffs {
+		policy := policies[method]
+		policy.Backoff = backoff
+		policies[method] = policy
+	}
+
+	return policies
+}
+
+// durations parses pairs of name and duration, e.g. GetToys=300ms. Description is used in panic message.
+func durations(description string, pairs []string) map[string]time.Duration {
+	values := make(map[string]time.Duration)
+
+	for _, pair := range pairs {
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			panic(fmt.Sprintf("invalid %s %s: %q", description, name, value))
+		}
+
+		values[name] = duration
+	}
+
+	return values
+}
+
+// clientTLSConfig loads TLS config of downstream service by prefix of its environment variables. Insecure
+// connection should be explicitly enabled and is forbidden outside local environment.
+func clientTLSConfig(envPrefix string, insecureAllowed bool) TLSConfig {
+	tlsConfig := TLSConfig{
+		Insecure:   loadenv.GetEnvAsBool(envPrefix+"_TLS_INSECURE", false),
+		CAFile:     loadenv.GetEnv(envPrefix+"_TLS_CA_FILE", ""),
+		CertFile:   loadenv.GetEnv(envPrefix+"_TLS_CERT_FILE", ""),
+		KeyFile:    loadenv.GetEnv(envPrefix+"_TLS_KEY_FILE", ""),
+		ServerName: loadenv.GetEnv(envPrefix+"_TLS_SERVER_NAME", ""),
+		ReloadInterval: time.Second * time.Duration(
+			loadenv.GetEnvAsInt(envPrefix+"_TLS_RELOAD_INTERVAL", 60),
+		),
+	}
+
+	if tlsConfig.Insecure && !insecureAllowed {
+		panic(
+			fmt.Sprintf(
+				"insecure connection of %s client is allowed only with ENVIRONMENT set to one of %s",
+				envPrefix,
+				insecureEnvironments,
+			),
+		)
+	}
+
+	return tlsConfig
+}
+
+// insecureConnectionsAllowed reports, whether ENVIRONMENT is explicitly set to local or development one.
+// Default environment is not used, so that deployment without ENVIRONMENT does not allow plaintext connections.
+func insecureConnectionsAllowed() bool {
+	return slices.Contains(insecureEnvironments, os.Getenv("ENVIRONMENT"))
+}
+
+// balancingConfig reads load balancing settings of client. Unknown policy is a misconfiguration,
+// so it stops the application.
+func balancingConfig(envPrefix string) BalancingConfig {
+	balancingConfig := BalancingConfig{
+		Target:             loadenv.GetEnv(envPrefix+"_CLIENT_TARGET", ""),
+		Addresses:          loadenv.GetEnvAsSlice(envPrefix+"_CLIENT_ADDRESSES", []string{}, ", "),
+		Policy:             loadenv.GetEnv(envPrefix+"_LOAD_BALANCING_POLICY", RoundRobinPolicy),
+		HealthCheck:        loadenv.GetEnvAsBool(envPrefix+"_HEALTH_CHECK", true),
+		HealthCheckService: loadenv.GetEnv(envPrefix+"_HEALTH_CHECK_SERVICE", ""),
+		Keepalive: KeepaliveConfig{
+			Time: time.Second * time.Duration(
+				loadenv.GetEnvAsInt(envPrefix+"_KEEPALIVE_TIME", 30),
+			),
+			Timeout: time.Second * time.Duration(
+				loadenv.GetEnvAsInt(envPrefix+"_KEEPALIVE_TIMEOUT", 10),
+			),
+			PermitWithoutStream: loadenv.GetEnvAsBool(envPrefix+"_KEEPALIVE_PERMIT_WITHOUT_STREAM", false),
+		},
+	}
+
+	if balancingConfig.Policy != RoundRobinPolicy && balancingConfig.Policy != LeastRequestPolicy {
+		panic(fmt.Sprintf("unknown load balancing policy=%s of %s client", balancingConfig.Policy, envPrefix))
+	}
+
+	return balancingConfig
 }
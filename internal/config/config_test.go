@@ -0,0 +1,183 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePolicies(t *testing.T) {
+	testCases := []struct {
+		name          string
+		disabled      []string
+		ttls          []string
+		expected      map[string]CachePolicyConfig
+		panicExpected bool
+	}{
+		{
+			name:     "no overrides",
+			expected: map[string]CachePolicyConfig{},
+		},
+		{
+			name:     "overrides",
+			disabled: []string{"users", ""},
+			ttls:     []string{"users=1m", "get_tags=1h30m"},
+			expected: map[string]CachePolicyConfig{
+				"users":    {Disabled: true, TTL: time.Minute},
+				"get_tags": {TTL: time.Hour + time.Minute*30},
+			},
+		},
+		{
+			name:          "invalid TTL",
+			ttls:          []string{"users=1"},
+			panicExpected: true,
+		},
+		{
+			name:          "missing TTL",
+			ttls:          []string{"users"},
+			panicExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panicExpected {
+				require.Panics(t, func() { cachePolicies(tc.disabled, tc.ttls) })
+
+				return
+			}
+
+			require.Equal(t, tc.expected, cachePolicies(tc.disabled, tc.ttls))
+		})
+	}
+}
+
+func TestRetryPolicies(t *testing.T) {
+	testCases := []struct {
+		name          string
+		disabled      []string
+		attempts      []string
+		timeouts      map[string]time.Duration
+		backoffs      map[string]time.Duration
+		expected      map[string]RetryPolicyConfig
+		panicExpected bool
+	}{
+		{
+			name:     "no overrides",
+			expected: map[string]RetryPolicyConfig{},
+		},
+		{
+			name:     "overrides",
+			disabled: []string{"GetUsers", ""},
+			attempts: []string{"GetUsers=2", "GetToys=5"},
+			timeouts: map[string]time.Duration{"GetToys": time.Second},
+			backoffs: map[string]time.Duration{"GetToys": time.Millisecond * 100, "GetToy": time.Second},
+			expected: map[string]RetryPolicyConfig{
+				"GetUsers": {Disabled: true, MaxAttempts: 2},
+				"GetToys":  {MaxAttempts: 5, Timeout: time.Second, Backoff: time.Millisecond * 100},
+				"GetToy":   {Backoff: time.Second},
+			},
+		},
+		{
+			name:          "invalid attempts",
+			attempts:      []string{"GetToys=0"},
+			panicExpected: true,
+		},
+		{
+			name:          "missing attempts",
+			attempts:      []string{"GetToys"},
+			panicExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panicExpected {
+				require.Panics(t, func() { retryPolicies(tc.disabled, tc.attempts, tc.timeouts, tc.backoffs) })
+
+				return
+			}
+
+			require.Equal(t, tc.expected, retryPolicies(tc.disabled, tc.attempts, tc.timeouts, tc.backoffs))
+		})
+	}
+}
+
+func TestDurations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pairs         []string
+		expected      map[string]time.Duration
+		panicExpected bool
+	}{
+		{
+			name:     "no values",
+			expected: map[string]time.Duration{},
+		},
+		{
+			name:  "values",
+			pairs: []string{"GetToys=300ms", "", "GetUsers=1s"},
+			expected: map[string]time.Duration{
+				"GetToys":  time.Millisecond * 300,
+				"GetUsers": time.Second,
+			},
+		},
+		{
+			name:          "invalid duration",
+			pairs:         []string{"GetToys=300"},
+			panicExpected: true,
+		},
+		{
+			name:          "missing duration",
+			pairs:         []string{"GetToys"},
+			panicExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panicExpected {
+				require.Panics(t, func() { durations("timeout of method", tc.pairs) })
+
+				return
+			}
+
+			require.Equal(t, tc.expected, durations("timeout of method", tc.pairs))
+		})
+	}
+}
+
+func TestInsecureConnectionsAllowed(t *testing.T) {
+	testCases := []struct {
+		name        string
+		environment string
+		expected    bool
+	}{
+		{
+			name:        "local environment",
+			environment: "local",
+			expected:    true,
+		},
+		{
+			name:        "dev environment",
+			environment: "dev",
+			expected:    true,
+		},
+		{
+			name:        "production environment",
+			environment: "prod",
+		},
+		{
+			// Default environment does not allow plaintext connections:
+			name: "environment is not set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ENVIRONMENT", tc.environment)
+			require.Equal(t, tc.expected, insecureConnectionsAllowed())
+		})
+	}
+}
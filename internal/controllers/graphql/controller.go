@@ -17,7 +17,6 @@ import (
 
 	graphqlapi "github.com/DKhorkov/hmtm-bff/api/graphql"
 	"github.com/DKhorkov/hmtm-bff/internal/config"
-	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
 )
 
@@ -25,22 +24,47 @@ func New(
 	httpConfig config.HTTPConfig,
 	corsConfig config.CORSConfig,
 	cookiesConfig config.CookiesConfig,
+	uploadsConfig config.UploadsConfig,
 	useCases interfaces.UseCases,
 	logger logging.Logger,
 	traceProvider tracing.Provider,
 	tracingConfig config.TracingConfig,
-) *Controller {
-	graphqlServer := graphqlhandler.NewDefaultServer(
-		graphqlapi.NewExecutableSchema(
-			graphqlapi.Config{
-				Resolvers: NewResolver(
-					useCases,
-					logger,
-					cookiesConfig,
-				),
-			},
-		),
+	readinessChecks map[string]interfaces.ReadinessCheck,
+) (*Controller, error) {
+	executableSchema := graphqlapi.NewExecutableSchema(
+		graphqlapi.Config{
+			Resolvers: NewResolver(
+				useCases,
+				logger,
+				cookiesConfig,
+			),
+		},
+	)
+
+	graphqlServer := graphqlhandler.NewDefaultServer(executableSchema)
+
+	// Operation name is forwarded to downstream services:
+	graphqlServer.AroundOperations(operationNameMiddleware)
+
+	// Values, which are needed by resolvers of several fields, are resolved once per operation:
+	graphqlServer.AroundOperations(requestCacheMiddleware)
+
+	// Each operation has deadline budget, which is shared by all its downstream calls:
+	graphqlServer.AroundResponses(
+		newOperationBudgetMiddleware(httpConfig.OperationBudget, httpConfig.OperationBudgets),
+	)
+
+	// Non-critical fields are resolved to null with warning, when their downstream service is unavailable:
+	degradableFieldsMiddleware, err := newDegradableFieldsMiddleware(
+		executableSchema.Schema(),
+		httpConfig.DegradableFields,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	graphqlServer.AroundFields(degradableFieldsMiddleware)
+	graphqlServer.AroundResponses(newDegradationWarningsMiddleware())
 
 	mux := http.NewServeMux()
 	mux.Handle(
@@ -50,11 +74,18 @@ func New(
 	mux.Handle("/query", graphqlServer)        // for graphql queries
 	mux.Handle("/metrics", promhttp.Handler()) // for prometheus metrics
 
+	// For readiness probes with states of dependencies:
+	mux.Handle(readinessPath, newReadinessHandler(readinessChecks, logger))
+
+	// For resumable uploads via tus protocol:
+	mux.Handle(uploadsPath, newUploadsHandler(useCases, uploadsConfig, logger))
+
 	httpHandler := cors.New(
 		cors.Options{
 			AllowedOrigins:   corsConfig.AllowedOrigins,
 			AllowedMethods:   corsConfig.AllowedMethods,
 			AllowedHeaders:   corsConfig.AllowedHeaders,
+			ExposedHeaders:   corsConfig.ExposedHeaders,
 			MaxAge:           corsConfig.MaxAge,
 			AllowCredentials: corsConfig.AllowCredentials,
 		},
@@ -83,15 +114,14 @@ func New(
 	// Configuring logging:
 	httpHandler = middlewares.GraphQLLoggingMiddleware(httpHandler, logger)
 
+	// Metadata of request, which is forwarded to downstream services:
+	httpHandler = requestMetadataMiddleware(httpHandler, httpConfig.TrustForwardedFor)
+
 	// Create request ID for request for later logging. Should be used as latest middleware. Stack logics:
 	httpHandler = middlewares.RequestIDMiddleware(httpHandler)
 
-	// Protecting server from too long requests:
-	httpHandler = http.TimeoutHandler(
-		httpHandler,
-		httpConfig.TimeoutHandlerTimeout,
-		customerrors.HTTPHandlerTimeoutError{}.Error(),
-	)
+	// Protecting server from too long requests. Chunks of resumable uploads have own timeout:
+	httpHandler = newTimeoutHandler(httpHandler, httpConfig.TimeoutHandlerTimeout, uploadsConfig.ChunkTimeout)
 
 	httpServer := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", httpConfig.Host, httpConfig.Port),
@@ -105,7 +135,7 @@ func New(
 		host:       httpConfig.Host,
 		port:       httpConfig.Port,
 		logger:     logger,
-	}
+	}, nil
 }
 
 type Controller struct {
@@ -129,6 +159,12 @@ func (controller *Controller) Run() {
 	logging.LogInfo(controller.logger, "Stopped serving new connections.")
 }
 
+// Handler returns HTTP handler with all middlewares, so that controller could be served without listening
+// to configured address, for example by httptest server.
+func (controller *Controller) Handler() http.Handler {
+	return controller.httpServer.Handler
+}
+
 // Stop http server gracefully (graceful shutdown).
 func (controller *Controller) Stop() {
 	// Stops accepting new requests and processes already received requests:
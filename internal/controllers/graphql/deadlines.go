@@ -0,0 +1,64 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newOperationBudgetMiddleware sets deadline of GraphQL operation. Deadline is inherited by all downstream
+// calls of operation, so that they are cancelled, when budget is spent, and could fail fast, when
+// remaining budget is not enough. Budget should be less than timeout of HTTP handler, so that client gets
+// GraphQL errors instead of timeout of whole request.
+func newOperationBudgetMiddleware(
+	defaultBudget time.Duration,
+	budgets map[string]time.Duration,
+) graphql.ResponseMiddleware {
+	return func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		name := operationName(ctx)
+
+		budget := defaultBudget
+		if operationBudget, ok := budgets[name]; ok {
+			budget = operationBudget
+		}
+
+		if budget <= 0 {
+			return next(ctx)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, budget)
+		defer cancel()
+
+		response := next(ctx)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			trace.SpanFromContext(ctx).AddEvent(
+				"operation budget exceeded",
+				trace.WithAttributes(
+					attribute.String("operation", name),
+					attribute.String("budget", budget.String()),
+				),
+			)
+		}
+
+		return response
+	}
+}
+
+// operationName returns name of executed operation. Name from request is preferred, because document
+// could contain several operations.
+func operationName(ctx context.Context) string {
+	if !graphql.HasOperationContext(ctx) {
+		return ""
+	}
+
+	operationContext := graphql.GetOperationContext(ctx)
+	if operationContext.OperationName != "" || operationContext.Operation == nil {
+		return operationContext.OperationName
+	}
+
+	return operationContext.Operation.Name
+}
@@ -0,0 +1,73 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestOperationBudgetMiddleware(t *testing.T) {
+	middleware := newOperationBudgetMiddleware(
+		time.Second,
+		map[string]time.Duration{"login": time.Millisecond * 100},
+	)
+
+	testCases := []struct {
+		name             string
+		operationContext *graphql.OperationContext
+		expectedBudget   time.Duration
+	}{
+		{
+			name:             "default budget",
+			operationContext: &graphql.OperationContext{OperationName: "toys"},
+			expectedBudget:   time.Second,
+		},
+		{
+			name:             "budget of operation from request",
+			operationContext: &graphql.OperationContext{OperationName: "login"},
+			expectedBudget:   time.Millisecond * 100,
+		},
+		{
+			name: "budget of operation from document",
+			operationContext: &graphql.OperationContext{
+				Operation: &ast.OperationDefinition{Name: "login"},
+			},
+			expectedBudget: time.Millisecond * 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := graphql.WithOperationContext(context.Background(), tc.operationContext)
+			startedAt := time.Now()
+
+			response := middleware(ctx, func(ctx context.Context) *graphql.Response {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, startedAt.Add(tc.expectedBudget), deadline, time.Millisecond*50)
+
+				return &graphql.Response{}
+			})
+
+			require.NotNil(t, response)
+		})
+	}
+}
+
+func TestOperationBudgetMiddleware_Disabled(t *testing.T) {
+	middleware := newOperationBudgetMiddleware(0, nil)
+
+	response := middleware(context.Background(), func(ctx context.Context) *graphql.Response {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+
+		return &graphql.Response{}
+	})
+
+	require.NotNil(t, response)
+}
@@ -0,0 +1,164 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+)
+
+const (
+	degradationWarningsKey       = "degradationWarnings"
+	degradationWarningsExtension = "warnings"
+	upstreamUnavailableCode      = "UPSTREAM_UNAVAILABLE"
+)
+
+// degradationWarning describes field, which was resolved to null, because its downstream service is unavailable.
+type degradationWarning struct {
+	Code    string   `json:"code"`
+	Field   string   `json:"field"`
+	Path    ast.Path `json:"path"`
+	Message string   `json:"message"`
+}
+
+// degradationWarnings are collected concurrently by field resolvers of single response.
+type degradationWarnings struct {
+	mu       sync.Mutex
+	warnings []degradationWarning
+}
+
+func (w *degradationWarnings) add(warning degradationWarning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.warnings = append(w.warnings, warning)
+}
+
+func (w *degradationWarnings) list() []degradationWarning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.warnings
+}
+
+// newDegradationWarningsMiddleware returns warnings about degraded fields in "warnings" extension of response.
+func newDegradationWarningsMiddleware() graphql.ResponseMiddleware {
+	return func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		warnings := &degradationWarnings{}
+		ctx = contextlib.WithValue(ctx, degradationWarningsKey, warnings)
+
+		response := next(ctx)
+		if response == nil || len(warnings.list()) == 0 {
+			return response
+		}
+
+		if response.Extensions == nil {
+			response.Extensions = make(map[string]any)
+		}
+
+		response.Extensions[degradationWarningsExtension] = warnings.list()
+
+		return response
+	}
+}
+
+// newDegradableFieldsMiddleware resolves non-critical fields to null instead of failing operation, when
+// downstream service of field is unavailable or too slow. Fields are set in "Type.field" format and are
+// validated against schema, because null of non-nullable field would fail its parent instead of the field.
+func newDegradableFieldsMiddleware(schema *ast.Schema, fields []string) (graphql.FieldMiddleware, error) {
+	degradable := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if err := validateDegradableField(schema, field); err != nil {
+			return nil, err
+		}
+
+		degradable[field] = struct{}{}
+	}
+
+	return func(ctx context.Context, next graphql.Resolver) (any, error) {
+		result, err := next(ctx)
+		if err == nil || !isUpstreamUnavailable(err) {
+			return result, err
+		}
+
+		fieldContext := graphql.GetFieldContext(ctx)
+		if fieldContext == nil {
+			return result, err
+		}
+
+		field := fieldContext.Object + "." + fieldContext.Field.Name
+		if _, ok := degradable[field]; !ok {
+			return result, err
+		}
+
+		warnings, valueErr := contextlib.ValueFromContext[*degradationWarnings](ctx, degradationWarningsKey)
+		if valueErr != nil {
+			return result, err
+		}
+
+		path := fieldContext.Path()
+		warnings.add(
+			degradationWarning{
+				Code:    upstreamUnavailableCode,
+				Field:   field,
+				Path:    path,
+				Message: err.Error(),
+			},
+		)
+
+		trace.SpanFromContext(ctx).AddEvent(
+			"field degraded",
+			trace.WithAttributes(
+				attribute.String("field", field),
+				attribute.String("path", path.String()),
+			),
+		)
+
+		return nil, nil
+	}, nil
+}
+
+// validateDegradableField checks, that field in "Type.field" format exists in schema and is nullable.
+func validateDegradableField(schema *ast.Schema, field string) error {
+	typeName, fieldName, ok := strings.Cut(field, ".")
+	if !ok || typeName == "" || fieldName == "" {
+		return fmt.Errorf("degradable field %q should be set in Type.field format", field)
+	}
+
+	definition, ok := schema.Types[typeName]
+	if !ok {
+		return fmt.Errorf("type of degradable field %q is not found in schema", field)
+	}
+
+	fieldDefinition := definition.Fields.ForName(fieldName)
+	if fieldDefinition == nil {
+		return fmt.Errorf("degradable field %q is not found in schema", field)
+	}
+
+	if fieldDefinition.Type.NonNull {
+		return fmt.Errorf("degradable field %q should be nullable in schema", field)
+	}
+
+	return nil
+}
+
+// isUpstreamUnavailable checks, whether error is caused by unavailable downstream service or by its deadline.
+func isUpstreamUnavailable(err error) bool {
+	var unavailableErr *customerrors.UnavailableError
+	if errors.As(err, &unavailableErr) {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+}
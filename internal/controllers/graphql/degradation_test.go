@@ -0,0 +1,243 @@
+package graphqlcontroller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mocklogger "github.com/DKhorkov/libs/logging/mocks"
+
+	graphqlapi "github.com/DKhorkov/hmtm-bff/api/graphql"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
+)
+
+func TestDegradableFieldsMiddleware(t *testing.T) {
+	const query = `{"query": "{ toy(id: \"1\") { id master { id user { id } } category { id } } }"}`
+
+	toy := &entities.Toy{ID: toyID, MasterID: masterID, CategoryID: categoryID}
+	master := &entities.Master{ID: masterID, UserID: userID}
+
+	testCases := []struct {
+		name             string
+		degradableFields []string
+		setupMocks       func(useCases *mockusecases.MockUseCases)
+		expectedData     string
+		expectedErrors   []string
+		expectedWarnings []degradationWarning
+	}{
+		{
+			name:             "unavailable degradable field",
+			degradableFields: []string{"Master.user"},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserByID(gomock.Any(), userID).
+					Return(nil, &customerrors.UnavailableError{Message: "upstream sso is unavailable"}).
+					Times(1)
+			},
+			expectedData: `{"toy":{"id":1,"master":{"id":1,"user":null},"category":{"id":1}}}`,
+			expectedWarnings: []degradationWarning{
+				{
+					Code:    upstreamUnavailableCode,
+					Field:   "Master.user",
+					Message: "upstream sso is unavailable",
+				},
+			},
+		},
+		{
+			name:             "deadline of degradable field",
+			degradableFields: []string{"Master.user"},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserByID(gomock.Any(), userID).
+					Return(nil, status.Error(codes.DeadlineExceeded, "deadline exceeded")).
+					Times(1)
+			},
+			expectedData: `{"toy":{"id":1,"master":{"id":1,"user":null},"category":{"id":1}}}`,
+			expectedWarnings: []degradationWarning{
+				{
+					Code:    upstreamUnavailableCode,
+					Field:   "Master.user",
+					Message: "rpc error: code = DeadlineExceeded desc = deadline exceeded",
+				},
+			},
+		},
+		{
+			name:             "unavailable field is not degradable",
+			degradableFields: []string{"Toy.category"},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserByID(gomock.Any(), userID).
+					Return(nil, &customerrors.UnavailableError{Message: "upstream sso is unavailable"}).
+					Times(1)
+			},
+			expectedData:   `{"toy":{"id":1,"master":{"id":1,"user":null},"category":{"id":1}}}`,
+			expectedErrors: []string{"upstream sso is unavailable"},
+		},
+		{
+			name:             "degradable field is not found",
+			degradableFields: []string{"Master.user"},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserByID(gomock.Any(), userID).
+					Return(nil, &customerrors.NotFoundError{Message: "user not found"}).
+					Times(1)
+			},
+			expectedData:   `{"toy":{"id":1,"master":{"id":1,"user":null},"category":{"id":1}}}`,
+			expectedErrors: []string{"user not found"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			useCases := mockusecases.NewMockUseCases(ctrl)
+			logger := mocklogger.NewMockLogger(ctrl)
+
+			logger.
+				EXPECT().
+				ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				AnyTimes()
+
+			useCases.
+				EXPECT().
+				GetToyByID(gomock.Any(), toyID).
+				Return(toy, nil).
+				Times(1)
+
+			useCases.
+				EXPECT().
+				GetMasterByID(gomock.Any(), masterID).
+				Return(master, nil).
+				Times(1)
+
+			useCases.
+				EXPECT().
+				GetCategoryByID(gomock.Any(), categoryID).
+				Return(&entities.Category{ID: categoryID}, nil).
+				Times(1)
+
+			tc.setupMocks(useCases)
+
+			executableSchema := graphqlapi.NewExecutableSchema(
+				graphqlapi.Config{Resolvers: NewResolver(useCases, logger, config.CookiesConfig{})},
+			)
+
+			degradableFieldsMiddleware, err := newDegradableFieldsMiddleware(
+				executableSchema.Schema(),
+				tc.degradableFields,
+			)
+			require.NoError(t, err)
+
+			server := handler.New(executableSchema)
+			server.AddTransport(transport.POST{})
+			server.AroundFields(degradableFieldsMiddleware)
+			server.AroundResponses(newDegradationWarningsMiddleware())
+
+			request := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(query))
+			request.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			var response struct {
+				Data   json.RawMessage `json:"data"`
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+				Extensions struct {
+					Warnings []struct {
+						Code    string `json:"code"`
+						Field   string `json:"field"`
+						Path    []any  `json:"path"`
+						Message string `json:"message"`
+					} `json:"warnings"`
+				} `json:"extensions"`
+			}
+
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.JSONEq(t, tc.expectedData, string(response.Data))
+
+			actualErrors := make([]string, len(response.Errors))
+			for i, responseErr := range response.Errors {
+				actualErrors[i] = responseErr.Message
+			}
+
+			assert.ElementsMatch(t, tc.expectedErrors, actualErrors)
+			require.Len(t, response.Extensions.Warnings, len(tc.expectedWarnings))
+
+			for i, expected := range tc.expectedWarnings {
+				actual := response.Extensions.Warnings[i]
+				assert.Equal(t, expected.Code, actual.Code)
+				assert.Equal(t, expected.Field, actual.Field)
+				assert.Equal(t, expected.Message, actual.Message)
+				assert.Equal(t, []any{"toy", "master", "user"}, actual.Path)
+			}
+		})
+	}
+}
+
+func TestNewDegradableFieldsMiddleware(t *testing.T) {
+	schema := graphqlapi.NewExecutableSchema(graphqlapi.Config{}).Schema()
+
+	testCases := []struct {
+		name          string
+		fields        []string
+		errorExpected bool
+	}{
+		{
+			name:   "default fields",
+			fields: config.New().HTTP.DegradableFields,
+		},
+		{
+			name:          "unknown type",
+			fields:        []string{"Master.user", "Doll.master"},
+			errorExpected: true,
+		},
+		{
+			name:          "unknown field",
+			fields:        []string{"Toy.owner"},
+			errorExpected: true,
+		},
+		{
+			// Null of non-nullable field fails its parent instead of field:
+			name:          "non-nullable field",
+			fields:        []string{"Toy.master"},
+			errorExpected: true,
+		},
+		{
+			name:          "invalid format",
+			fields:        []string{"Toy"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			middleware, err := newDegradableFieldsMiddleware(schema, tc.fields)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Nil(t, middleware)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, middleware)
+			}
+		})
+	}
+}
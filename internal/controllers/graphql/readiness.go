@@ -0,0 +1,61 @@
+package graphqlcontroller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const readinessPath = "/ready"
+
+type readinessCheckResult struct {
+	State string `json:"state"`
+	Ready bool   `json:"ready"`
+}
+
+type readinessResult struct {
+	Ready  bool                            `json:"ready"`
+	Checks map[string]readinessCheckResult `json:"checks"`
+}
+
+// readinessHandler reports states of dependencies. Server is not ready, if any dependency is not ready.
+type readinessHandler struct {
+	checks map[string]interfaces.ReadinessCheck
+	logger logging.Logger
+}
+
+func newReadinessHandler(checks map[string]interfaces.ReadinessCheck, logger logging.Logger) *readinessHandler {
+	return &readinessHandler{
+		checks: checks,
+		logger: logger,
+	}
+}
+
+func (h *readinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result := readinessResult{
+		Ready:  true,
+		Checks: make(map[string]readinessCheckResult, len(h.checks)),
+	}
+
+	for name, check := range h.checks {
+		state, ready := check.Ready(r.Context())
+		result.Checks[name] = readinessCheckResult{State: state, Ready: ready}
+		result.Ready = result.Ready && ready
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if result.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logging.LogErrorContext(r.Context(), h.logger, "Failed to write readiness result", err)
+	}
+}
@@ -0,0 +1,64 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+type readinessCheckFunc func(ctx context.Context) (string, bool)
+
+func (f readinessCheckFunc) Ready(ctx context.Context) (string, bool) {
+	return f(ctx)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	testCases := []struct {
+		name               string
+		checks             map[string]interfaces.ReadinessCheck
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "no checks",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ready":true,"checks":{}}`,
+		},
+		{
+			name: "ready",
+			checks: map[string]interfaces.ReadinessCheck{
+				"cache": readinessCheckFunc(func(context.Context) (string, bool) { return "open", true }),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ready":true,"checks":{"cache":{"state":"open","ready":true}}}`,
+		},
+		{
+			name: "not ready",
+			checks: map[string]interfaces.ReadinessCheck{
+				"cache": readinessCheckFunc(func(context.Context) (string, bool) { return "closed", true }),
+				"sso":   readinessCheckFunc(func(context.Context) (string, bool) { return "unavailable", false }),
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody: `{"ready":false,"checks":{"cache":{"state":"closed","ready":true},` +
+				`"sso":{"state":"unavailable","ready":false}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			newReadinessHandler(tc.checks, nil).ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, readinessPath, nil),
+			)
+
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+			require.JSONEq(t, tc.expectedBody, recorder.Body.String())
+		})
+	}
+}
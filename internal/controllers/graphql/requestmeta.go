@@ -0,0 +1,92 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+
+	"github.com/DKhorkov/hmtm-bff/internal/requestcache"
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+)
+
+const (
+	requestIDHeader     = "X-Request-ID"
+	forwardedForHeader  = "X-Forwarded-For"
+	maxRequestIDLength  = 128
+	requestIDCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.:"
+)
+
+// requestMetadataMiddleware creates metadata of request, which is forwarded to downstream services.
+// Request ID is taken from request, if it is set by proxy, or otherwise the one, which was created by
+// RequestIDMiddleware for logging, is used. ID of proxy replaces created one in context, so that logs
+// and downstream services receive the same ID. It is returned to client, so that client could report it.
+func requestMetadataMiddleware(next http.Handler, trustForwardedFor bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		requestID := r.Header.Get(requestIDHeader)
+		if isValidRequestID(requestID) {
+			ctx = contextlib.WithValue(ctx, requestid.Key, requestID)
+		} else if requestID, _ = contextlib.ValueFromContext[string](ctx, requestid.Key); requestID == "" {
+			requestID = requestid.New()
+			ctx = contextlib.WithValue(ctx, requestid.Key, requestID)
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+
+		ctx = requestmeta.WithMetadata(ctx, requestmeta.New(requestID, clientIP(r, trustForwardedFor)))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// operationNameMiddleware remembers name of GraphQL operation in metadata of request.
+func operationNameMiddleware(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	requestmeta.SetOperationName(ctx, operationName(ctx))
+
+	return next(ctx)
+}
+
+// requestCacheMiddleware creates cache of values, which are resolved once per operation, for example
+// authenticated user, which is needed by resolvers of several fields.
+func requestCacheMiddleware(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(requestcache.WithCache(ctx, requestcache.New()))
+}
+
+// clientIP returns IP of client. First address of X-Forwarded-For is used only, if header is set by trusted
+// proxy, because otherwise it could be set by client to any value.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwardedFor := r.Header.Get(forwardedForHeader); forwardedFor != "" {
+			first, _, _ := strings.Cut(forwardedFor, ",")
+
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// isValidRequestID checks, that request ID from client could be safely written to logs and metadata.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, character := range requestID {
+		if !strings.ContainsRune(requestIDCharacters, character) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,160 @@
+package graphqlcontroller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/middlewares"
+	"github.com/DKhorkov/libs/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/DKhorkov/hmtm-bff/internal/clients/interceptors"
+	"github.com/DKhorkov/hmtm-bff/internal/requestcache"
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+)
+
+func TestRequestMetadataMiddleware(t *testing.T) {
+	testCases := []struct {
+		name              string
+		headers           map[string]string
+		remoteAddr        string
+		trustForwardedFor bool
+		expectedRequestID string
+		expectedClientIP  string
+	}{
+		{
+			name:             "request ID of logs",
+			remoteAddr:       "10.0.0.1:5000",
+			expectedClientIP: "10.0.0.1",
+		},
+		{
+			name:              "request ID from proxy",
+			headers:           map[string]string{requestIDHeader: "proxy-request-id"},
+			remoteAddr:        "10.0.0.1:5000",
+			expectedRequestID: "proxy-request-id",
+			expectedClientIP:  "10.0.0.1",
+		},
+		{
+			name:             "invalid request ID is replaced",
+			headers:          map[string]string{requestIDHeader: "id\nwith new line"},
+			remoteAddr:       "10.0.0.1:5000",
+			expectedClientIP: "10.0.0.1",
+		},
+		{
+			name:             "too long request ID is replaced",
+			headers:          map[string]string{requestIDHeader: strings.Repeat("a", maxRequestIDLength+1)},
+			remoteAddr:       "10.0.0.1:5000",
+			expectedClientIP: "10.0.0.1",
+		},
+		{
+			name:              "client IP from trusted proxy",
+			headers:           map[string]string{forwardedForHeader: "192.168.0.1, 10.0.0.2"},
+			remoteAddr:        "10.0.0.1:5000",
+			trustForwardedFor: true,
+			expectedClientIP:  "192.168.0.1",
+		},
+		{
+			name:             "X-Forwarded-For is ignored without trusted proxy",
+			headers:          map[string]string{forwardedForHeader: "192.168.0.1"},
+			remoteAddr:       "10.0.0.1:5000",
+			expectedClientIP: "10.0.0.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				metadata         *requestmeta.Metadata
+				loggedRequestID  string
+				outgoingMetadata grpcmetadata.MD
+			)
+
+			// Request ID is created for logs by outermost middleware, as in controller:
+			handler := middlewares.RequestIDMiddleware(
+				requestMetadataMiddleware(
+					http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+						metadata = requestmeta.FromContext(r.Context())
+						loggedRequestID, _ = contextlib.ValueFromContext[string](r.Context(), requestid.Key)
+
+						err := interceptors.UnaryClientMetadataInterceptor()(
+							r.Context(),
+							"/Service/Method",
+							nil,
+							nil,
+							nil,
+							func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+								outgoingMetadata, _ = grpcmetadata.FromOutgoingContext(ctx)
+
+								return nil
+							},
+						)
+						require.NoError(t, err)
+					}),
+					tc.trustForwardedFor,
+				),
+			)
+
+			request := httptest.NewRequest(http.MethodPost, "/query", nil)
+			request.RemoteAddr = tc.remoteAddr
+
+			for key, value := range tc.headers {
+				request.Header.Set(key, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.NotNil(t, metadata)
+			assert.Equal(t, tc.expectedClientIP, metadata.ClientIP())
+			assert.Equal(t, metadata.RequestID(), recorder.Header().Get(requestIDHeader))
+
+			// Logs and downstream services receive the same request ID:
+			assert.NotEmpty(t, loggedRequestID)
+			assert.Equal(t, loggedRequestID, metadata.RequestID())
+			assert.Equal(t, []string{loggedRequestID}, outgoingMetadata.Get(interceptors.RequestIDMetadataKey))
+
+			if tc.expectedRequestID != "" {
+				assert.Equal(t, tc.expectedRequestID, metadata.RequestID())
+			}
+		})
+	}
+}
+
+func TestOperationNameMiddleware(t *testing.T) {
+	ctx := requestmeta.WithMetadata(context.Background(), requestmeta.New("request-id", "10.0.0.1"))
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{OperationName: "myToys"})
+
+	operationNameMiddleware(ctx, func(context.Context) graphql.ResponseHandler {
+		return nil
+	})
+
+	assert.Equal(t, "myToys", requestmeta.FromContext(ctx).OperationName())
+}
+
+func TestRequestCacheMiddleware(t *testing.T) {
+	var calls int
+
+	requestCacheMiddleware(context.Background(), func(ctx context.Context) graphql.ResponseHandler {
+		for range 2 {
+			_, err := requestcache.Get(ctx, "me", func() (int, error) {
+				calls++
+
+				return calls, nil
+			})
+			require.NoError(t, err)
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, 1, calls)
+}
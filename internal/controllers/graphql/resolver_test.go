@@ -242,3 +242,24 @@ func TestResolver_TicketsFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestResolver_User(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resolver *Resolver
+		expected *userResolver
+	}{
+		{
+			name:     "success",
+			resolver: &Resolver{},
+			expected: &userResolver{Resolver: &Resolver{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.resolver.User()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -225,11 +225,12 @@ func (r *mutationResolver) UpdateUserProfile(ctx context.Context, input graphqla
 	}
 
 	userProfileData := entities.RawUpdateUserProfileDTO{
-		AccessToken: accessToken.Value,
-		DisplayName: input.DisplayName,
-		Phone:       input.Phone,
-		Telegram:    input.Telegram,
-		Avatar:      input.Avatar,
+		AccessToken:    accessToken.Value,
+		DisplayName:    input.DisplayName,
+		Phone:          input.Phone,
+		Telegram:       input.Telegram,
+		Avatar:         input.Avatar,
+		AvatarUploadID: input.AvatarUploadID,
 	}
 
 	if err = r.useCases.UpdateUserProfile(ctx, userProfileData); err != nil {
@@ -294,14 +295,15 @@ func (r *mutationResolver) AddToy(ctx context.Context, input graphqlapi.AddToyIn
 	}
 
 	toyData := entities.RawAddToyDTO{
-		AccessToken: accessToken.Value,
-		CategoryID:  uint32(categoryID),
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       float32(input.Price),
-		Quantity:    uint32(input.Quantity),
-		Tags:        input.Tags,
-		Attachments: input.Attachments,
+		AccessToken:         accessToken.Value,
+		CategoryID:          uint32(categoryID),
+		Name:                input.Name,
+		Description:         input.Description,
+		Price:               float32(input.Price),
+		Quantity:            uint32(input.Quantity),
+		Tags:                input.Tags,
+		Attachments:         input.Attachments,
+		AttachmentUploadIDs: input.AttachmentUploadIds,
 	}
 
 	toyID, err := r.useCases.AddToy(ctx, toyData)
@@ -398,14 +400,15 @@ func (r *mutationResolver) CreateTicket(ctx context.Context, input graphqlapi.Cr
 	}
 
 	ticketData := entities.RawCreateTicketDTO{
-		AccessToken: accessToken.Value,
-		CategoryID:  uint32(categoryID),
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       price,
-		Quantity:    uint32(input.Quantity),
-		Tags:        input.Tags,
-		Attachments: input.Attachments,
+		AccessToken:         accessToken.Value,
+		CategoryID:          uint32(categoryID),
+		Name:                input.Name,
+		Description:         input.Description,
+		Price:               price,
+		Quantity:            uint32(input.Quantity),
+		Tags:                input.Tags,
+		Attachments:         input.Attachments,
+		AttachmentUploadIDs: input.AttachmentUploadIds,
 	}
 
 	ticketID, err := r.useCases.CreateTicket(ctx, ticketData)
@@ -1157,6 +1160,38 @@ func (r *ticketResolver) Quantity(ctx context.Context, obj *entities.Ticket) (in
 	return quantity, nil
 }
 
+// Attachments is the resolver for the attachments field.
+func (r *ticketResolver) Attachments(ctx context.Context, obj *entities.Ticket) ([]*entities.TicketAttachment, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	// Anonymous Users are not allowed to see private attachments:
+	accessToken, err := contextlib.ValueFromContext[*http.Cookie](ctx, accessTokenCookieName)
+	if err != nil {
+		return nil, nil
+	}
+
+	attachments, err := r.useCases.GetTicketAttachments(ctx, *obj, accessToken.Value)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			r.logger,
+			fmt.Sprintf("Failed to get Attachments for Ticket with ID=%d", obj.ID),
+			err,
+		)
+
+		return nil, err
+	}
+
+	response := make([]*entities.TicketAttachment, len(attachments))
+	for i, attachment := range attachments {
+		response[i] = &attachment
+	}
+
+	return response, nil
+}
+
 // Master is the resolver for the master field.
 func (r *toyResolver) Master(ctx context.Context, obj *entities.Toy) (*entities.Master, error) {
 	if obj == nil {
@@ -1215,6 +1250,20 @@ func (r *toyResolver) Quantity(ctx context.Context, obj *entities.Toy) (int, err
 	return quantity, nil
 }
 
+// StorageUsage is the resolver for the storageUsage field.
+func (r *userResolver) StorageUsage(ctx context.Context, obj *entities.User) (*entities.StorageUsage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	accessToken, err := contextlib.ValueFromContext[*http.Cookie](ctx, accessTokenCookieName)
+	if err != nil {
+		return nil, &cookies.NotFoundError{Message: accessTokenCookieName}
+	}
+
+	return r.useCases.GetUserStorageUsage(ctx, accessToken.Value, obj.ID)
+}
+
 // Limit is the resolver for the limit field.
 func (r *paginationResolver) Limit(ctx context.Context, obj *entities.Pagination, data *int) error {
 	if obj != nil && data != nil {
@@ -1308,6 +1357,9 @@ func (r *Resolver) Ticket() graphqlapi.TicketResolver { return &ticketResolver{r
 // Toy returns graphqlapi.ToyResolver implementation.
 func (r *Resolver) Toy() graphqlapi.ToyResolver { return &toyResolver{r} }
 
+// User returns graphqlapi.UserResolver implementation.
+func (r *Resolver) User() graphqlapi.UserResolver { return &userResolver{r} }
+
 // Pagination returns graphqlapi.PaginationResolver implementation.
 func (r *Resolver) Pagination() graphqlapi.PaginationResolver { return &paginationResolver{r} }
 
@@ -1326,6 +1378,7 @@ type queryResolver struct{ *Resolver }
 type respondResolver struct{ *Resolver }
 type ticketResolver struct{ *Resolver }
 type toyResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
 type paginationResolver struct{ *Resolver }
 type ticketsFiltersResolver struct{ *Resolver }
 type toysFiltersResolver struct{ *Resolver }
@@ -203,6 +203,7 @@ func TestMutationResolver_RegisterUser(t *testing.T) {
 		setupMocks    func(useCases *mockusecases.MockUseCases)
 		expected      string
 		errorExpected bool
+		expectedError error
 	}{
 		{
 			name:  "success",
@@ -233,10 +234,11 @@ func TestMutationResolver_RegisterUser(t *testing.T) {
 						gomock.Any(),
 						gomock.Any(),
 					).
-					Return(uint64(0), errors.New("registration error")).
+					Return(uint64(0), &customerrors.AlreadyExistsError{Message: "user already exists"}).
 					Times(1)
 			},
 			errorExpected: true,
+			expectedError: &customerrors.AlreadyExistsError{},
 		},
 	}
 
@@ -255,6 +257,10 @@ func TestMutationResolver_RegisterUser(t *testing.T) {
 			actual, err := testedResolver.RegisterUser(ctx, tc.input)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.expected, actual)
@@ -2684,6 +2690,7 @@ func TestQueryResolver_User(t *testing.T) {
 		setupMocks    func(useCases *mockusecases.MockUseCases)
 		expected      *entities.User
 		errorExpected bool
+		expectedError error
 	}{
 		{
 			name: "successful user retrieval",
@@ -2709,11 +2716,12 @@ func TestQueryResolver_User(t *testing.T) {
 				useCases.
 					EXPECT().
 					GetUserByID(gomock.Any(), userID).
-					Return(nil, errors.New("get user error")).
+					Return(nil, &customerrors.NotFoundError{Message: "user not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
+			expectedError: &customerrors.NotFoundError{},
 		},
 	}
 
@@ -2739,6 +2747,10 @@ func TestQueryResolver_User(t *testing.T) {
 			actual, err := resolver.User(testCtx, tc.id)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -2860,9 +2872,10 @@ func TestQueryResolver_Me(t *testing.T) {
 				useCases.
 					EXPECT().
 					GetMe(gomock.Any(), "invalid_token").
-					Return(nil, errors.New("test")).
+					Return(nil, &customerrors.UnauthenticatedError{Message: "invalid token"}).
 					Times(1)
 			},
+			expectedError: &customerrors.UnauthenticatedError{},
 			errorExpected: true,
 		},
 	}
@@ -2894,7 +2907,7 @@ func TestQueryResolver_Me(t *testing.T) {
 			if tc.errorExpected {
 				require.Error(t, err)
 				if tc.expectedError != nil {
-					require.IsType(t, err, tc.expectedError)
+					require.IsType(t, tc.expectedError, err)
 				}
 			} else {
 				require.NoError(t, err)
@@ -4250,11 +4263,12 @@ func TestQueryResolver_Toy(t *testing.T) {
 				useCases.
 					EXPECT().
 					GetToyByID(gomock.Any(), toyID).
-					Return(nil, errors.New("get toy error")).
+					Return(nil, &customerrors.NotFoundError{Message: "toy not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
+			expectedError: &customerrors.NotFoundError{},
 		},
 	}
 
@@ -4280,6 +4294,10 @@ func TestQueryResolver_Toy(t *testing.T) {
 			actual, err := resolver.Toy(testCtx, tc.id)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -5135,11 +5153,12 @@ func TestQueryResolver_Ticket(t *testing.T) {
 				useCases.
 					EXPECT().
 					GetTicketByID(gomock.Any(), ticketID).
-					Return(nil, errors.New("get ticket error")).
+					Return(nil, &customerrors.NotFoundError{Message: "ticket not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
+			expectedError: &customerrors.NotFoundError{},
 		},
 	}
 
@@ -5165,6 +5184,10 @@ func TestQueryResolver_Ticket(t *testing.T) {
 			actual, err := resolver.Ticket(testCtx, tc.id)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -6306,6 +6329,103 @@ func TestRespondResolver_Price(t *testing.T) {
 	}
 }
 
+func TestUserResolver_StorageUsage(t *testing.T) {
+	validAccessToken := &http.Cookie{
+		Name:  accessTokenCookieName,
+		Value: "valid_access_token",
+	}
+
+	mockUser := &entities.User{ID: userID}
+	mockStorageUsage := &entities.StorageUsage{
+		UsedBytes:  1024,
+		LimitBytes: 2048,
+	}
+
+	testCases := []struct {
+		name           string
+		obj            *entities.User
+		prepareContext func(ctx context.Context) context.Context
+		setupMocks     func(useCases *mockusecases.MockUseCases)
+		expected       *entities.StorageUsage
+		errorExpected  bool
+	}{
+		{
+			name: "successful get storage usage",
+			obj:  mockUser,
+			prepareContext: func(ctx context.Context) context.Context {
+				return contextlib.WithValue(ctx, accessTokenCookieName, validAccessToken)
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserStorageUsage(gomock.Any(), validAccessToken.Value, userID).
+					Return(mockStorageUsage, nil).
+					Times(1)
+			},
+			expected: mockStorageUsage,
+		},
+		{
+			name: "use case error",
+			obj:  mockUser,
+			prepareContext: func(ctx context.Context) context.Context {
+				return contextlib.WithValue(ctx, accessTokenCookieName, validAccessToken)
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetUserStorageUsage(gomock.Any(), validAccessToken.Value, userID).
+					Return(nil, errors.New("test")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:          "access token not found",
+			obj:           mockUser,
+			errorExpected: true,
+		},
+		{
+			name:     "nil obj",
+			obj:      nil,
+			expected: nil,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogger.NewMockLogger(ctrl)
+	resolver := &userResolver{
+		Resolver: NewResolver(
+			useCases,
+			logger,
+			config.CookiesConfig{},
+		),
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.prepareContext != nil {
+				ctx = tc.prepareContext(ctx)
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases)
+			}
+
+			actual, err := resolver.StorageUsage(ctx, tc.obj)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestTicketResolver_User(t *testing.T) {
 	mockTicket := &entities.Ticket{
 		ID:        ticketID,
@@ -6572,6 +6692,114 @@ func TestTicketResolver_Quantity(t *testing.T) {
 	}
 }
 
+func TestTicketResolver_Attachments(t *testing.T) {
+	validAccessToken := &http.Cookie{
+		Name:  accessTokenCookieName,
+		Value: "valid_access_token",
+	}
+
+	mockTicket := &entities.Ticket{
+		ID:     ticketID,
+		UserID: userID,
+		Attachments: []entities.TicketAttachment{
+			{ID: 1, TicketID: ticketID, Link: "https://bucket/file.jpg"},
+		},
+	}
+
+	presignedAttachments := []entities.TicketAttachment{
+		{ID: 1, TicketID: ticketID, Link: "https://presigned/file.jpg"},
+	}
+
+	testCases := []struct {
+		name           string
+		obj            *entities.Ticket
+		prepareContext func(ctx context.Context) context.Context
+		setupMocks     func(useCases *mockusecases.MockUseCases, logger *mocklogger.MockLogger)
+		expected       []*entities.TicketAttachment
+		errorExpected  bool
+	}{
+		{
+			name: "successful get attachments",
+			obj:  mockTicket,
+			prepareContext: func(ctx context.Context) context.Context {
+				return contextlib.WithValue(ctx, accessTokenCookieName, validAccessToken)
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					GetTicketAttachments(gomock.Any(), *mockTicket, validAccessToken.Value).
+					Return(presignedAttachments, nil).
+					Times(1)
+			},
+			expected: []*entities.TicketAttachment{&presignedAttachments[0]},
+		},
+		{
+			name: "use case error",
+			obj:  mockTicket,
+			prepareContext: func(ctx context.Context) context.Context {
+				return contextlib.WithValue(ctx, accessTokenCookieName, validAccessToken)
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					GetTicketAttachments(gomock.Any(), *mockTicket, validAccessToken.Value).
+					Return(nil, errors.New("test")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:     "anonymous user",
+			obj:      mockTicket,
+			expected: nil,
+		},
+		{
+			name:     "nil obj",
+			obj:      nil,
+			expected: nil,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogger.NewMockLogger(ctrl)
+	resolver := &ticketResolver{
+		Resolver: NewResolver(
+			useCases,
+			logger,
+			config.CookiesConfig{},
+		),
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.prepareContext != nil {
+				ctx = tc.prepareContext(ctx)
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			actual, err := resolver.Attachments(ctx, tc.obj)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestToyResolver_Master(t *testing.T) {
 	mockToy := &entities.Toy{
 		ID:       toyID,
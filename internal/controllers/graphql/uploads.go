@@ -0,0 +1,265 @@
+package graphqlcontroller
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// Resumable uploads are processed according to tus protocol: https://tus.io/protocols/resumable-upload
+const (
+	uploadsPath = "/files/"
+
+	tusVersion     = "1.0.0"
+	tusExtensions  = "creation"
+	tusContentType = "application/offset+octet-stream"
+
+	tusResumableHeader    = "Tus-Resumable"
+	tusVersionHeader      = "Tus-Version"
+	tusExtensionHeader    = "Tus-Extension"
+	uploadOffsetHeader    = "Upload-Offset"
+	uploadLengthHeader    = "Upload-Length"
+	uploadMetadataHeader  = "Upload-Metadata"
+	authorizationHeader   = "Authorization"
+	bearerTokenPrefix     = "Bearer "
+	filenameMetadataField = "filename"
+)
+
+// uploadsHandler receives files by chunks, so that interrupted upload could be continued from
+// the last received byte. ID of completed upload could be used by mutations instead of file.
+type uploadsHandler struct {
+	useCases      interfaces.UseCases
+	uploadsConfig config.UploadsConfig
+	logger        logging.Logger
+}
+
+func newUploadsHandler(
+	useCases interfaces.UseCases,
+	uploadsConfig config.UploadsConfig,
+	logger logging.Logger,
+) *uploadsHandler {
+	return &uploadsHandler{
+		useCases:      useCases,
+		uploadsConfig: uploadsConfig,
+		logger:        logger,
+	}
+}
+
+func (h *uploadsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(tusResumableHeader, tusVersion)
+
+	// Discovery of server capabilities does not require tus headers and auth:
+	if r.Method == http.MethodOptions {
+		w.Header().Set(tusVersionHeader, tusVersion)
+		w.Header().Set(tusExtensionHeader, tusExtensions)
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	if r.Header.Get(tusResumableHeader) != tusVersion {
+		w.Header().Set(tusVersionHeader, tusVersion)
+		http.Error(w, "unsupported tus version", http.StatusPreconditionFailed)
+
+		return
+	}
+
+	accessToken := accessTokenFromRequest(r)
+	if accessToken == "" {
+		http.Error(w, "access token not found", http.StatusUnauthorized)
+
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, uploadsPath)
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		h.create(w, r, accessToken)
+	case r.Method == http.MethodHead && id != "":
+		h.head(w, r, accessToken, id)
+	case r.Method == http.MethodPatch && id != "":
+		h.patch(w, r, accessToken, id)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *uploadsHandler) create(w http.ResponseWriter, r *http.Request, accessToken string) {
+	length, err := strconv.ParseInt(r.Header.Get(uploadLengthHeader), 10, 64)
+	if err != nil || length <= 0 {
+		http.Error(w, "invalid "+uploadLengthHeader, http.StatusBadRequest)
+
+		return
+	}
+
+	filename := parseUploadMetadata(r.Header.Get(uploadMetadataHeader))[filenameMetadataField]
+	if filename == "" {
+		http.Error(w, "filename not found in "+uploadMetadataHeader, http.StatusBadRequest)
+
+		return
+	}
+
+	upload, err := h.useCases.CreateUpload(r.Context(), accessToken, filename, length)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	w.Header().Set("Location", uploadsPath+upload.ID)
+	w.Header().Set(uploadOffsetHeader, "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *uploadsHandler) head(w http.ResponseWriter, r *http.Request, accessToken, id string) {
+	upload, err := h.useCases.GetUpload(r.Context(), accessToken, id)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *uploadsHandler) patch(w http.ResponseWriter, r *http.Request, accessToken, id string) {
+	if r.Header.Get("Content-Type") != tusContentType {
+		http.Error(w, "invalid Content-Type", http.StatusUnsupportedMediaType)
+
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, "invalid "+uploadOffsetHeader, http.StatusBadRequest)
+
+		return
+	}
+
+	chunk, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.uploadsConfig.MaxChunkSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "chunk is too large", http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		http.Error(w, "failed to read chunk", http.StatusBadRequest)
+
+		return
+	}
+
+	upload, err := h.useCases.WriteUploadChunk(r.Context(), accessToken, id, offset, chunk)
+	if err != nil {
+		h.writeError(w, r, err)
+
+		return
+	}
+
+	h.writeUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *uploadsHandler) writeUploadHeaders(w http.ResponseWriter, upload *entities.ResumableUpload) {
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set(uploadLengthHeader, strconv.FormatInt(upload.Length, 10))
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+func (h *uploadsHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		notFoundErr         *customerrors.UploadNotFoundError
+		offsetMismatchErr   *customerrors.UploadOffsetMismatchError
+		invalidExtensionErr *customerrors.InvalidFileExtensionError
+		invalidSizeErr      *customerrors.InvalidFileSizeError
+		quotaExceededErr    *customerrors.StorageQuotaExceededError
+		unauthenticatedErr  *customerrors.UnauthenticatedError
+	)
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.As(err, &offsetMismatchErr):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.As(err, &invalidExtensionErr):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.As(err, &invalidSizeErr), errors.As(err, &quotaExceededErr):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.As(err, &unauthenticatedErr):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		logging.LogErrorContext(r.Context(), h.logger, "Failed to process resumable upload request", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// newTimeoutHandler limits processing time of requests. Chunk of resumable upload could be received slowly by client
+// with poor connection, so uploads have own timeout, which also replaces read deadline of server for such requests.
+func newTimeoutHandler(handler http.Handler, timeout, uploadsTimeout time.Duration) http.Handler {
+	message := customerrors.HTTPHandlerTimeoutError{}.Error()
+	defaultHandler := http.TimeoutHandler(handler, timeout, message)
+	uploadsHandler := http.TimeoutHandler(handler, uploadsTimeout, message)
+
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, uploadsPath) {
+				defaultHandler.ServeHTTP(w, r)
+
+				return
+			}
+
+			// Error is returned only by writers without connection, for which there is no deadline to replace:
+			_ = http.NewResponseController(w).SetReadDeadline(time.Now().Add(uploadsTimeout))
+			uploadsHandler.ServeHTTP(w, r)
+		},
+	)
+}
+
+// accessTokenFromRequest returns access token from cookie, which is used by GraphQL API,
+// or from Authorization header for clients, which are not able to use cookies.
+func accessTokenFromRequest(r *http.Request) string {
+	if cookie, err := r.Cookie(accessTokenCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	if header := r.Header.Get(authorizationHeader); strings.HasPrefix(header, bearerTokenPrefix) {
+		return strings.TrimPrefix(header, bearerTokenPrefix)
+	}
+
+	return ""
+}
+
+// parseUploadMetadata parses comma-separated "key base64value" pairs. Invalid pairs are skipped.
+func parseUploadMetadata(header string) map[string]string {
+	metadata := make(map[string]string)
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encodedValue, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(encodedValue)
+		if err != nil {
+			continue
+		}
+
+		metadata[key] = string(value)
+	}
+
+	return metadata
+}
@@ -0,0 +1,436 @@
+package graphqlcontroller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	mocklogger "github.com/DKhorkov/libs/logging/mocks"
+	tracingmock "github.com/DKhorkov/libs/tracing/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
+)
+
+func TestUploadsHandler_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		name            string
+		method          string
+		path            string
+		headers         map[string]string
+		cookie          *http.Cookie
+		body            []byte
+		setupMocks      func(useCases *mockusecases.MockUseCases, logger *mocklogger.MockLogger)
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "options",
+			method:         http.MethodOptions,
+			path:           "/files/",
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				tusVersionHeader:   tusVersion,
+				tusExtensionHeader: tusExtensions,
+			},
+		},
+		{
+			name:           "unsupported tus version",
+			method:         http.MethodPost,
+			path:           "/files/",
+			headers:        map[string]string{tusResumableHeader: "0.2.2"},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "no access token",
+			method:         http.MethodPost,
+			path:           "/files/",
+			headers:        map[string]string{tusResumableHeader: tusVersion},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "create with cookie",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:   tusVersion,
+				uploadLengthHeader:   "10",
+				uploadMetadataHeader: "filename cGhvdG8uanBn,filetype aW1hZ2UvanBlZw==",
+			},
+			cookie: &http.Cookie{Name: accessTokenCookieName, Value: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					CreateUpload(gomock.Any(), "token", "photo.jpg", int64(10)).
+					Return(&entities.ResumableUpload{ID: "id", Length: 10}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHeaders: map[string]string{
+				"Location":         "/files/id",
+				uploadOffsetHeader: "0",
+			},
+		},
+		{
+			name:   "create with bearer token",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:   tusVersion,
+				authorizationHeader:  "Bearer token",
+				uploadLengthHeader:   "10",
+				uploadMetadataHeader: "filename cGhvdG8uanBn",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					CreateUpload(gomock.Any(), "token", "photo.jpg", int64(10)).
+					Return(&entities.ResumableUpload{ID: "id", Length: 10}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "create without length",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:   tusVersion,
+				authorizationHeader:  "Bearer token",
+				uploadMetadataHeader: "filename cGhvdG8uanBn",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create without filename",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadLengthHeader:  "10",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create with invalid extension",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:   tusVersion,
+				authorizationHeader:  "Bearer token",
+				uploadLengthHeader:   "10",
+				uploadMetadataHeader: "filename cGhvdG8uZXhl",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					CreateUpload(gomock.Any(), "token", "photo.exe", int64(10)).
+					Return(nil, &customerrors.InvalidFileExtensionError{Message: ".exe"}).
+					Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create with invalid token",
+			method: http.MethodPost,
+			path:   "/files/",
+			headers: map[string]string{
+				tusResumableHeader:   tusVersion,
+				authorizationHeader:  "Bearer token",
+				uploadLengthHeader:   "10",
+				uploadMetadataHeader: "filename cGhvdG8uanBn",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					CreateUpload(gomock.Any(), "token", "photo.jpg", int64(10)).
+					Return(nil, &customerrors.UnauthenticatedError{Message: "invalid token"}).
+					Times(1)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "head",
+			method: http.MethodHead,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					GetUpload(gomock.Any(), "token", "id").
+					Return(&entities.ResumableUpload{ID: "id", Length: 10, Offset: 4}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				uploadOffsetHeader: "4",
+				uploadLengthHeader: "10",
+				"Cache-Control":    "no-store",
+			},
+		},
+		{
+			name:   "head not found",
+			method: http.MethodHead,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					GetUpload(gomock.Any(), "token", "id").
+					Return(nil, &customerrors.UploadNotFoundError{Message: "id"}).
+					Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "patch",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "4",
+				"Content-Type":      tusContentType,
+			},
+			body: []byte("chunk"),
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					WriteUploadChunk(gomock.Any(), "token", "id", int64(4), []byte("chunk")).
+					Return(&entities.ResumableUpload{ID: "id", Length: 10, Offset: 9}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				uploadOffsetHeader: "9",
+			},
+		},
+		{
+			name:   "patch with invalid content type",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "4",
+			},
+			body:           []byte("chunk"),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "patch without offset",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				"Content-Type":      tusContentType,
+			},
+			body:           []byte("chunk"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "patch with too large chunk",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "0",
+				"Content-Type":      tusContentType,
+			},
+			body:           []byte("too large chunk"),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "patch with offset mismatch",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "2",
+				"Content-Type":      tusContentType,
+			},
+			body: []byte("chunk"),
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					WriteUploadChunk(gomock.Any(), "token", "id", int64(2), []byte("chunk")).
+					Return(nil, &customerrors.UploadOffsetMismatchError{}).
+					Times(1)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "patch with exceeded quota",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "5",
+				"Content-Type":      tusContentType,
+			},
+			body: []byte("chunk"),
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					WriteUploadChunk(gomock.Any(), "token", "id", int64(5), []byte("chunk")).
+					Return(nil, &customerrors.StorageQuotaExceededError{}).
+					Times(1)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "patch with internal error",
+			method: http.MethodPatch,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+				uploadOffsetHeader:  "5",
+				"Content-Type":      tusContentType,
+			},
+			body: []byte("chunk"),
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogger.MockLogger) {
+				useCases.
+					EXPECT().
+					WriteUploadChunk(gomock.Any(), "token", "id", int64(5), []byte("chunk")).
+					Return(nil, errors.New("redis error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodDelete,
+			path:   "/files/id",
+			headers: map[string]string{
+				tusResumableHeader:  tusVersion,
+				authorizationHeader: "Bearer token",
+			},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			useCases := mockusecases.NewMockUseCases(ctrl)
+			logger := mocklogger.NewMockLogger(ctrl)
+			handler := newUploadsHandler(useCases, config.UploadsConfig{MaxChunkSize: 10}, logger)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			request := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(tc.body))
+			for key, value := range tc.headers {
+				request.Header.Set(key, value)
+			}
+
+			if tc.cookie != nil {
+				request.AddCookie(tc.cookie)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.expectedStatus, recorder.Code)
+			require.Equal(t, tusVersion, recorder.Header().Get(tusResumableHeader))
+
+			for key, value := range tc.expectedHeaders {
+				require.Equal(t, value, recorder.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestController_SlowUploadChunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	traceProvider := tracingmock.NewMockProvider(ctrl)
+	chunk := []byte("0123456789")
+
+	traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+				return ctx, trace.SpanFromContext(ctx)
+			},
+		).
+		AnyTimes()
+
+	useCases.
+		EXPECT().
+		WriteUploadChunk(gomock.Any(), "token", "id", int64(0), chunk).
+		Return(&entities.ResumableUpload{ID: "id", Offset: 10, Length: 10}, nil).
+		Times(1)
+
+	controller, err := New(
+		config.HTTPConfig{TimeoutHandlerTimeout: 50 * time.Millisecond},
+		config.CORSConfig{},
+		config.CookiesConfig{},
+		config.UploadsConfig{MaxChunkSize: 10, ChunkTimeout: 5 * time.Second},
+		useCases,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		traceProvider,
+		config.TracingConfig{},
+		nil,
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(controller.Handler())
+	defer server.Close()
+
+	// Second half of chunk is sent after timeout of other requests has passed:
+	body, bodyWriter := io.Pipe()
+	go func() {
+		_, _ = bodyWriter.Write(chunk[:5])
+		time.Sleep(200 * time.Millisecond)
+		_, _ = bodyWriter.Write(chunk[5:])
+		_ = bodyWriter.Close()
+	}()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, server.URL+"/files/id", body)
+	require.NoError(t, err)
+
+	request.Header.Set(tusResumableHeader, tusVersion)
+	request.Header.Set(authorizationHeader, "Bearer token")
+	request.Header.Set("Content-Type", tusContentType)
+	request.Header.Set(uploadOffsetHeader, "0")
+
+	response, err := server.Client().Do(request)
+	require.NoError(t, err)
+
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.Equal(t, "10", response.Header.Get(uploadOffsetHeader))
+}
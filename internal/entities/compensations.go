@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+const (
+	CompensationKindDeleteFiles = "delete_files"
+)
+
+// Compensation describes action, which should undo already completed step of multistep operation.
+type Compensation struct {
+	Saga      string    `json:"saga"`
+	Step      string    `json:"step"`
+	Kind      string    `json:"kind"`
+	FileKeys  []string  `json:"fileKeys,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+}
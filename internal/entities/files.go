@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// FileVisibility defines, who is able to get uploaded file.
+type FileVisibility string
+
+const (
+	// FileVisibilityPublic files are world-readable by their link.
+	FileVisibilityPublic FileVisibility = "public"
+
+	// FileVisibilityPrivate files are available only via short-lived presigned URLs.
+	FileVisibilityPrivate FileVisibility = "private"
+)
+
+// FileKeyFromLink returns storage key of file, which is the last segment of its link.
+func FileKeyFromLink(link string) string {
+	split := strings.Split(link, "/")
+
+	return split[len(split)-1]
+}
+
+type StoredFile struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+type StorageUsage struct {
+	UsedBytes  int64 `json:"usedBytes"`
+	LimitBytes int64 `json:"limitBytes"`
+}
+
+// ResumableUpload is the state of resumable file upload. Link is set, when all chunks were received
+// and file was saved to storage.
+type ResumableUpload struct {
+	ID       string `json:"id"`
+	UserID   uint64 `json:"userId"`
+	Filename string `json:"filename"`
+	Length   int64  `json:"length"`
+	Offset   int64  `json:"offset"`
+	Link     string `json:"link,omitempty"`
+}
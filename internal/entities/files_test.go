@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileKeyFromLink(t *testing.T) {
+	testCases := []struct {
+		name     string
+		link     string
+		expected string
+	}{
+		{
+			name:     "full link",
+			link:     "https://bucket.s3.region.amazonaws.com/key.png",
+			expected: "key.png",
+		},
+		{
+			name:     "key only",
+			link:     "key.png",
+			expected: "key.png",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, FileKeyFromLink(tc.link))
+		})
+	}
+}
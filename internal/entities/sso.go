@@ -43,6 +43,8 @@ type RawUpdateUserProfileDTO struct {
 	Phone       *string         `json:"phone,omitempty"`
 	Telegram    *string         `json:"telegram,omitempty"`
 	Avatar      *graphql.Upload `json:"avatar,omitempty"`
+	// ID of completed resumable upload, which should be used as avatar instead of Avatar:
+	AvatarUploadID *string `json:"avatarUploadId,omitempty"`
 }
 
 type UpdateUserProfileDTO struct {
@@ -15,6 +15,8 @@ type RawAddToyDTO struct {
 	Quantity    uint32            `json:"quantity"`
 	Tags        []string          `json:"tags,omitempty"`
 	Attachments []*graphql.Upload `json:"attachments,omitempty"`
+	// IDs of completed resumable uploads, which should be used as attachments:
+	AttachmentUploadIDs []string `json:"attachmentUploadIds,omitempty"`
 }
 
 type AddToyDTO struct {
@@ -0,0 +1,21 @@
+package errors
+
+import "fmt"
+
+type UnknownCompensationKindError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UnknownCompensationKindError) Error() string {
+	template := "unknown compensation kind=%s"
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.Message, e.BaseErr)
+	}
+
+	return fmt.Sprintf(template, e.Message)
+}
+
+func (e UnknownCompensationKindError) Unwrap() error {
+	return e.BaseErr
+}
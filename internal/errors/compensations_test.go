@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownCompensationKindError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UnknownCompensationKindError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "without base error",
+			err: UnknownCompensationKindError{
+				Message: "kind",
+				BaseErr: nil,
+			},
+			expectedString: "unknown compensation kind=kind",
+			expectedBase:   nil,
+		},
+		{
+			name: "with base error",
+			err: UnknownCompensationKindError{
+				Message: "kind",
+				BaseErr: errors.New("test"),
+			},
+			expectedString: "unknown compensation kind=kind. Base error: test",
+			expectedBase:   errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+		})
+	}
+}
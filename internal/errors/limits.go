@@ -23,3 +23,25 @@ func (e LimitExceededError) Error() string {
 func (e LimitExceededError) Unwrap() error {
 	return e.BaseErr
 }
+
+type StorageQuotaExceededError struct {
+	Message string
+	BaseErr error
+}
+
+func (e StorageQuotaExceededError) Error() string {
+	template := "storage quota exceeded"
+	if e.Message != "" {
+		template = fmt.Sprintf(template+": %s", e.Message)
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e StorageQuotaExceededError) Unwrap() error {
+	return e.BaseErr
+}
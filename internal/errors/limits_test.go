@@ -62,3 +62,59 @@ func TestLimitExceededError(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageQuotaExceededError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            StorageQuotaExceededError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "default message without base error",
+			err: StorageQuotaExceededError{
+				Message: "",
+				BaseErr: nil,
+			},
+			expectedString: "storage quota exceeded",
+			expectedBase:   nil,
+		},
+		{
+			name: "default message with base error",
+			err: StorageQuotaExceededError{
+				Message: "",
+				BaseErr: errors.New("test error"),
+			},
+			expectedString: "storage quota exceeded. Base error: test error",
+			expectedBase:   errors.New("test error"),
+		},
+		{
+			name: "custom message without base error",
+			err: StorageQuotaExceededError{
+				Message: "limit is 100 bytes",
+				BaseErr: nil,
+			},
+			expectedString: "storage quota exceeded: limit is 100 bytes",
+			expectedBase:   nil,
+		},
+		{
+			name: "custom message with base error",
+			err: StorageQuotaExceededError{
+				Message: "limit is 100 bytes",
+				BaseErr: errors.New("test error"),
+			},
+			expectedString: "storage quota exceeded: limit is 100 bytes. Base error: test error",
+			expectedBase:   errors.New("test error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package errors
+
+// Errors below are translated from gRPC status codes of downstream services on repository boundary.
+// Message is message of status, which is safe to show to client, so base error with its gRPC code is
+// not included into error string and is available only via Unwrap.
+
+type NotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e NotFoundError) Error() string {
+	return messageOrDefault(e.Message, "not found")
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+type AlreadyExistsError struct {
+	Message string
+	BaseErr error
+}
+
+func (e AlreadyExistsError) Error() string {
+	return messageOrDefault(e.Message, "already exists")
+}
+
+func (e AlreadyExistsError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidArgumentError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidArgumentError) Error() string {
+	return messageOrDefault(e.Message, "invalid argument")
+}
+
+func (e InvalidArgumentError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UnauthenticatedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UnauthenticatedError) Error() string {
+	return messageOrDefault(e.Message, "unauthenticated")
+}
+
+func (e UnauthenticatedError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UnavailableError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UnavailableError) Error() string {
+	return messageOrDefault(e.Message, "service is unavailable")
+}
+
+func (e UnavailableError) Unwrap() error {
+	return e.BaseErr
+}
+
+type AccessDeniedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e AccessDeniedError) Error() string {
+	return messageOrDefault(e.Message, "access denied")
+}
+
+func (e AccessDeniedError) Unwrap() error {
+	return e.BaseErr
+}
+
+type FailedPreconditionError struct {
+	Message string
+	BaseErr error
+}
+
+func (e FailedPreconditionError) Error() string {
+	return messageOrDefault(e.Message, "failed precondition")
+}
+
+func (e FailedPreconditionError) Unwrap() error {
+	return e.BaseErr
+}
+
+type DeadlineExceededError struct {
+	Message string
+	BaseErr error
+}
+
+func (e DeadlineExceededError) Error() string {
+	return messageOrDefault(e.Message, "service did not respond in time")
+}
+
+func (e DeadlineExceededError) Unwrap() error {
+	return e.BaseErr
+}
+
+// InternalError is translated from codes without domain meaning. Message of such status could describe internals
+// of downstream service, so it is not set on translation and default message is shown to client instead.
+type InternalError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InternalError) Error() string {
+	return messageOrDefault(e.Message, "internal error")
+}
+
+func (e InternalError) Unwrap() error {
+	return e.BaseErr
+}
+
+func messageOrDefault(message, defaultMessage string) string {
+	if message != "" {
+		return message
+	}
+
+	return defaultMessage
+}
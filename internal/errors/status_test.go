@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusErrors(t *testing.T) {
+	baseErr := errors.New("rpc error: code = NotFound desc = toy not found")
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "not found without message",
+			err:            NotFoundError{},
+			expectedString: "not found",
+		},
+		{
+			name:           "not found with base error",
+			err:            NotFoundError{Message: "toy not found", BaseErr: baseErr},
+			expectedString: "toy not found",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "already exists without message",
+			err:            AlreadyExistsError{},
+			expectedString: "already exists",
+		},
+		{
+			name:           "already exists with base error",
+			err:            AlreadyExistsError{Message: "user already exists", BaseErr: baseErr},
+			expectedString: "user already exists",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "invalid argument without message",
+			err:            InvalidArgumentError{},
+			expectedString: "invalid argument",
+		},
+		{
+			name:           "invalid argument with base error",
+			err:            InvalidArgumentError{Message: "invalid email", BaseErr: baseErr},
+			expectedString: "invalid email",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "unauthenticated without message",
+			err:            UnauthenticatedError{},
+			expectedString: "unauthenticated",
+		},
+		{
+			name:           "unauthenticated with base error",
+			err:            UnauthenticatedError{Message: "token expired", BaseErr: baseErr},
+			expectedString: "token expired",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "unavailable without message",
+			err:            UnavailableError{},
+			expectedString: "service is unavailable",
+		},
+		{
+			name:           "unavailable with base error",
+			err:            UnavailableError{Message: "upstream toys is unavailable", BaseErr: baseErr},
+			expectedString: "upstream toys is unavailable",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "access denied without message",
+			err:            AccessDeniedError{},
+			expectedString: "access denied",
+		},
+		{
+			name:           "access denied with base error",
+			err:            AccessDeniedError{Message: "user is not a master", BaseErr: baseErr},
+			expectedString: "user is not a master",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "failed precondition without message",
+			err:            FailedPreconditionError{},
+			expectedString: "failed precondition",
+		},
+		{
+			name:           "failed precondition with base error",
+			err:            FailedPreconditionError{Message: "password must differ", BaseErr: baseErr},
+			expectedString: "password must differ",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "deadline exceeded without message",
+			err:            DeadlineExceededError{},
+			expectedString: "service did not respond in time",
+		},
+		{
+			name:           "deadline exceeded with base error",
+			err:            DeadlineExceededError{BaseErr: baseErr},
+			expectedString: "service did not respond in time",
+			expectedBase:   baseErr,
+		},
+		{
+			name:           "internal without message",
+			err:            InternalError{},
+			expectedString: "internal error",
+		},
+		{
+			name:           "internal with base error",
+			err:            InternalError{BaseErr: baseErr},
+			expectedString: "internal error",
+			expectedBase:   baseErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+			require.Equal(t, tc.expectedBase, errors.Unwrap(tc.err))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package errors
+
+import "fmt"
+
+type UploadNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UploadNotFoundError) Error() string {
+	template := "upload with id=%s not found"
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.Message, e.BaseErr)
+	}
+
+	return fmt.Sprintf(template, e.Message)
+}
+
+func (e UploadNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UploadOffsetMismatchError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UploadOffsetMismatchError) Error() string {
+	template := "upload offset mismatch: %s"
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.Message, e.BaseErr)
+	}
+
+	return fmt.Sprintf(template, e.Message)
+}
+
+func (e UploadOffsetMismatchError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UploadNotCompletedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UploadNotCompletedError) Error() string {
+	template := "upload with id=%s is not completed"
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.Message, e.BaseErr)
+	}
+
+	return fmt.Sprintf(template, e.Message)
+}
+
+func (e UploadNotCompletedError) Unwrap() error {
+	return e.BaseErr
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadNotFoundError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UploadNotFoundError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "without base error",
+			err: UploadNotFoundError{
+				Message: "id",
+				BaseErr: nil,
+			},
+			expectedString: "upload with id=id not found",
+			expectedBase:   nil,
+		},
+		{
+			name: "with base error",
+			err: UploadNotFoundError{
+				Message: "id",
+				BaseErr: errors.New("test"),
+			},
+			expectedString: "upload with id=id not found. Base error: test",
+			expectedBase:   errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+		})
+	}
+}
+
+func TestUploadOffsetMismatchError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UploadOffsetMismatchError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "without base error",
+			err: UploadOffsetMismatchError{
+				Message: "expected offset 10, received 5",
+				BaseErr: nil,
+			},
+			expectedString: "upload offset mismatch: expected offset 10, received 5",
+			expectedBase:   nil,
+		},
+		{
+			name: "with base error",
+			err: UploadOffsetMismatchError{
+				Message: "expected offset 10, received 5",
+				BaseErr: errors.New("test"),
+			},
+			expectedString: "upload offset mismatch: expected offset 10, received 5. Base error: test",
+			expectedBase:   errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+		})
+	}
+}
+
+func TestUploadNotCompletedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UploadNotCompletedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "without base error",
+			err: UploadNotCompletedError{
+				Message: "id",
+				BaseErr: nil,
+			},
+			expectedString: "upload with id=id is not completed",
+			expectedBase:   nil,
+		},
+		{
+			name: "with base error",
+			err: UploadNotCompletedError{
+				Message: "id",
+				BaseErr: errors.New("test"),
+			},
+			expectedString: "upload with id=id is not completed. Base error: test",
+			expectedBase:   errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+		})
+	}
+}
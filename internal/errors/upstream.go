@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpstreamUnavailableError is returned without calling downstream service, when its circuit breaker
+// is open or limit of concurrent calls is reached. Message is name of downstream service.
+type UpstreamUnavailableError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UpstreamUnavailableError) Error() string {
+	template := "upstream %s is unavailable"
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.Message, e.BaseErr)
+	}
+
+	return fmt.Sprintf(template, e.Message)
+}
+
+func (e UpstreamUnavailableError) Unwrap() error {
+	return e.BaseErr
+}
+
+// GRPCStatus allows to handle error as any other Unavailable error of gRPC client.
+func (e UpstreamUnavailableError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
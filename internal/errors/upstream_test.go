@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpstreamUnavailableError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UpstreamUnavailableError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name: "without base error",
+			err: UpstreamUnavailableError{
+				Message: "Tickets",
+				BaseErr: nil,
+			},
+			expectedString: "upstream Tickets is unavailable",
+			expectedBase:   nil,
+		},
+		{
+			name: "with base error",
+			err: UpstreamUnavailableError{
+				Message: "Tickets",
+				BaseErr: errors.New("circuit breaker is open"),
+			},
+			expectedString: "upstream Tickets is unavailable. Base error: circuit breaker is open",
+			expectedBase:   errors.New("circuit breaker is open"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualString := tc.err.Error()
+			actualBase := tc.err.Unwrap()
+
+			require.Equal(t, tc.expectedString, actualString)
+			require.Equal(t, tc.expectedBase, actualBase)
+			require.Equal(t, codes.Unavailable, status.Code(tc.err))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package fakes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+var _ interfaces.Job = (*FilesServer)(nil)
+
+// FilesServer serves files of local file storage, so that links to uploaded files work in fake mode.
+type FilesServer struct {
+	httpServer *http.Server
+	logger     logging.Logger
+}
+
+func NewFilesServer(fakeConfig config.FakeConfig, logger logging.Logger) *FilesServer {
+	return &FilesServer{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", fakeConfig.FilesHost, fakeConfig.FilesPort),
+			Handler:           http.FileServer(http.Dir(fakeConfig.FilesDir)),
+			ReadHeaderTimeout: time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// FilesURL returns base URL of files, served by FilesServer.
+func FilesURL(fakeConfig config.FakeConfig) string {
+	return fmt.Sprintf("http://%s:%d", fakeConfig.FilesHost, fakeConfig.FilesPort)
+}
+
+func (server *FilesServer) Run() {
+	logging.LogInfo(server.logger, "Starting fake files server at "+server.httpServer.Addr)
+
+	if err := server.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		logging.LogError(server.logger, "Fake files server error", err)
+	}
+}
+
+func (server *FilesServer) Stop() {
+	if err := server.httpServer.Shutdown(context.Background()); err != nil {
+		logging.LogError(server.logger, "Fake files server shutdown error", err)
+	}
+}
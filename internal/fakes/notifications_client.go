@@ -0,0 +1,77 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
+	"google.golang.org/grpc"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+var _ interfaces.NotificationsClient = (*NotificationsClient)(nil)
+
+// NotificationsClient is in-memory implementation of Notifications service client. Emails are not sent,
+// but stored by other fake clients, when real services would send them.
+type NotificationsClient struct {
+	store *Store
+}
+
+func NewNotificationsClient(store *Store) *NotificationsClient {
+	return &NotificationsClient{store: store}
+}
+
+func (c *NotificationsClient) GetUserEmailCommunications(
+	_ context.Context,
+	in *notifications.GetUserEmailCommunicationsIn,
+	_ ...grpc.CallOption,
+) (*notifications.GetUserEmailCommunicationsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	emails := paginate(c.userEmails(in.GetUserID()), notificationsPage(in.GetPagination()))
+
+	out := &notifications.GetUserEmailCommunicationsOut{Emails: make([]*notifications.Email, len(emails))}
+	for i, e := range emails {
+		out.Emails[i] = &notifications.Email{
+			ID:      e.id,
+			UserID:  e.userID,
+			Email:   e.email,
+			Content: e.content,
+			SentAt:  timestamp(e.sentAt),
+		}
+	}
+
+	return out, nil
+}
+
+func (c *NotificationsClient) CountUserEmailCommunications(
+	_ context.Context,
+	in *notifications.CountUserEmailCommunicationsIn,
+	_ ...grpc.CallOption,
+) (*notifications.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return &notifications.CountOut{Count: uint64(len(c.userEmails(in.GetUserID())))}, nil
+}
+
+func (c *NotificationsClient) userEmails(userID uint64) []*email {
+	var emails []*email
+
+	for _, e := range sortedValues(c.store.emails, false) {
+		if e.userID == userID {
+			emails = append(emails, e)
+		}
+	}
+
+	return emails
+}
+
+func notificationsPage(pagination *notifications.Pagination) page {
+	if pagination == nil {
+		return page{}
+	}
+
+	return page{limit: pagination.Limit, offset: pagination.Offset}
+}
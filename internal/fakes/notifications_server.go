@@ -0,0 +1,34 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
+	"google.golang.org/grpc"
+)
+
+// RegisterNotificationsServer registers gRPC services of Notifications, which delegate calls to fake client, so that
+// real gRPC clients could be tested against in-process server.
+func RegisterNotificationsServer(registrar grpc.ServiceRegistrar, client *NotificationsClient) {
+	notifications.RegisterEmailsServiceServer(registrar, &emailsServer{client: client})
+}
+
+type emailsServer struct {
+	notifications.UnimplementedEmailsServiceServer
+
+	client *NotificationsClient
+}
+
+func (s *emailsServer) GetUserEmailCommunications(
+	ctx context.Context,
+	in *notifications.GetUserEmailCommunicationsIn,
+) (*notifications.GetUserEmailCommunicationsOut, error) {
+	return s.client.GetUserEmailCommunications(ctx, in)
+}
+
+func (s *emailsServer) CountUserEmailCommunications(
+	ctx context.Context,
+	in *notifications.CountUserEmailCommunicationsIn,
+) (*notifications.CountOut, error) {
+	return s.client.CountUserEmailCommunications(ctx, in)
+}
@@ -0,0 +1,201 @@
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+var (
+	_ interfaces.S3Client    = (*S3Client)(nil)
+	_ interfaces.S3Presigner = (*S3Client)(nil)
+)
+
+type s3Object struct {
+	content      []byte
+	acl          types.ObjectCannedACL
+	lastModified time.Time
+}
+
+// S3Client is in-memory implementation of S3 client and presigner. Objects of all buckets are stored
+// together, because BFF uses only one bucket.
+type S3Client struct {
+	mu      sync.RWMutex
+	objects map[string]s3Object
+	now     func() time.Time
+}
+
+func NewS3Client() *S3Client {
+	return &S3Client{
+		objects: make(map[string]s3Object),
+		now:     time.Now,
+	}
+}
+
+// Object returns content and ACL of stored object.
+func (c *S3Client) Object(key string) ([]byte, types.ObjectCannedACL, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	object, ok := c.objects[key]
+
+	return object.content, object.acl, ok
+}
+
+func (c *S3Client) PutObject(
+	_ context.Context,
+	params *s3.PutObjectInput,
+	_ ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	var content []byte
+
+	if params.Body != nil {
+		var err error
+		if content, err = io.ReadAll(params.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.objects[aws.ToString(params.Key)] = s3Object{
+		content:      content,
+		acl:          params.ACL,
+		lastModified: c.now(),
+	}
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (c *S3Client) DeleteObject(
+	_ context.Context,
+	params *s3.DeleteObjectInput,
+	_ ...func(*s3.Options),
+) (*s3.DeleteObjectOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.objects, aws.ToString(params.Key))
+
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+// DeleteObjects reports missing objects as deleted, as S3 does.
+func (c *S3Client) DeleteObjects(
+	_ context.Context,
+	params *s3.DeleteObjectsInput,
+	_ ...func(*s3.Options),
+) (*s3.DeleteObjectsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := &s3.DeleteObjectsOutput{}
+	if params.Delete == nil {
+		return out, nil
+	}
+
+	for _, object := range params.Delete.Objects {
+		delete(c.objects, aws.ToString(object.Key))
+		out.Deleted = append(out.Deleted, types.DeletedObject{Key: object.Key})
+	}
+
+	return out, nil
+}
+
+// HeadObject returns types.NotFound for missing object, which is expected by waiters of deletion.
+func (c *S3Client) HeadObject(
+	_ context.Context,
+	params *s3.HeadObjectInput,
+	_ ...func(*s3.Options),
+) (*s3.HeadObjectOutput, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	object, ok := c.objects[aws.ToString(params.Key)]
+	if !ok {
+		return nil, &types.NotFound{Message: aws.String("object not found")}
+	}
+
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(object.content))),
+		LastModified:  aws.Time(object.lastModified),
+	}, nil
+}
+
+// ListObjectsV2 returns all objects in one page.
+func (c *S3Client) ListObjectsV2(
+	_ context.Context,
+	_ *s3.ListObjectsV2Input,
+	_ ...func(*s3.Options),
+) (*s3.ListObjectsV2Output, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.objects))
+	for key := range c.objects {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	out := &s3.ListObjectsV2Output{
+		Contents: make([]types.Object, len(keys)),
+	}
+
+	for i, key := range keys {
+		object := c.objects[key]
+		out.Contents[i] = types.Object{
+			Key:          aws.String(key),
+			Size:         aws.Int64(int64(len(object.content))),
+			LastModified: aws.Time(object.lastModified),
+		}
+	}
+
+	return out, nil
+}
+
+func (c *S3Client) PutObjectAcl(
+	_ context.Context,
+	params *s3.PutObjectAclInput,
+	_ ...func(*s3.Options),
+) (*s3.PutObjectAclOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := aws.ToString(params.Key)
+
+	object, ok := c.objects[key]
+	if !ok {
+		return nil, &types.NoSuchKey{Message: aws.String("object not found")}
+	}
+
+	object.acl = params.ACL
+	c.objects[key] = object
+
+	return &s3.PutObjectAclOutput{}, nil
+}
+
+// PresignGetObject returns not signed URL, which only contains bucket and key of object.
+func (c *S3Client) PresignGetObject(
+	_ context.Context,
+	params *s3.GetObjectInput,
+	_ ...func(*s3.PresignOptions),
+) (*v4.PresignedHTTPRequest, error) {
+	return &v4.PresignedHTTPRequest{
+		URL:    fmt.Sprintf("https://%s.s3.fake/%s?presigned", aws.ToString(params.Bucket), aws.ToString(params.Key)),
+		Method: http.MethodGet,
+	}, nil
+}
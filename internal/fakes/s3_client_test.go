@@ -0,0 +1,61 @@
+package fakes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Client(t *testing.T) {
+	client := NewS3Client()
+	ctx := context.Background()
+
+	_, err := client.PutObject(
+		ctx,
+		&s3.PutObjectInput{
+			Key:  aws.String("toys/1/file.png"),
+			Body: bytes.NewReader([]byte("content")),
+			ACL:  types.ObjectCannedACLPrivate,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = client.PutObjectAcl(
+		ctx,
+		&s3.PutObjectAclInput{Key: aws.String("toys/1/file.png"), ACL: types.ObjectCannedACLPublicRead},
+	)
+	require.NoError(t, err)
+
+	content, acl, ok := client.Object("toys/1/file.png")
+	require.True(t, ok)
+	require.Equal(t, []byte("content"), content)
+	require.Equal(t, types.ObjectCannedACLPublicRead, acl)
+
+	objects, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{})
+	require.NoError(t, err)
+	require.Len(t, objects.Contents, 1)
+	require.Equal(t, int64(len("content")), aws.ToInt64(objects.Contents[0].Size))
+
+	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{Key: aws.String("toys/1/file.png")})
+	require.NoError(t, err)
+
+	// Waiter of deletion succeeds only, if missing object is reported as not found:
+	require.NoError(
+		t,
+		s3.NewObjectNotExistsWaiter(client).Wait(
+			ctx,
+			&s3.HeadObjectInput{Key: aws.String("toys/1/file.png")},
+			time.Second,
+		),
+	)
+
+	var noSuchKey *types.NoSuchKey
+	_, err = client.PutObjectAcl(ctx, &s3.PutObjectAclInput{Key: aws.String("toys/1/file.png")})
+	require.ErrorAs(t, err, &noSuchKey)
+}
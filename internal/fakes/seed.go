@@ -0,0 +1,306 @@
+package fakes
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	seedPeriod = 30 * 24 * time.Hour
+	seedStep   = 6 * time.Hour
+)
+
+// seed fills store with data, which looks like production one. Entities are created one after another in
+// the past, so that order of creation matches order of IDs, as it does in downstream services.
+func seed(s *Store) {
+	createdAt := s.now().Add(-seedPeriod)
+	next := func() time.Time {
+		createdAt = createdAt.Add(seedStep)
+
+		return createdAt
+	}
+
+	categoryIDs := make(map[string]uint32)
+	for _, name := range []string{"Soft toys", "Wooden toys", "Knitted toys", "Dolls", "Other"} {
+		id := s.nextDictionaryID()
+		s.categories[id] = &category{id: id, name: name}
+		categoryIDs[name] = id
+	}
+
+	tagIDs := make(map[string]uint32)
+	for _, name := range []string{"gift", "eco", "handmade", "for kids", "collectible"} {
+		id := s.nextDictionaryID()
+		at := next()
+		s.tags[id] = &tag{id: id, name: name, createdAt: at, updatedAt: at}
+		tagIDs[name] = id
+	}
+
+	tags := func(names ...string) []uint32 {
+		ids := make([]uint32, len(names))
+		for i, name := range names {
+			ids[i] = tagIDs[name]
+		}
+
+		return ids
+	}
+
+	userIDs := make(map[string]uint64)
+	for _, u := range []struct {
+		displayName string
+		email       string
+		telegram    *string
+	}{
+		{displayName: "Anna Smirnova", email: "anna@example.com", telegram: ptr("@anna_knits")},
+		{displayName: "Boris Ivanov", email: "boris@example.com", telegram: ptr("@boris_wood")},
+		{displayName: "Elena Petrova", email: "elena@example.com"},
+		{displayName: "Dmitry Kozlov", email: "dmitry@example.com"},
+		{displayName: "Olga Sokolova", email: "olga@example.com", telegram: ptr("@olga_s")},
+	} {
+		id := s.nextID()
+		at := next()
+		s.users[id] = &user{
+			id:                id,
+			displayName:       u.displayName,
+			email:             u.email,
+			emailConfirmed:    true,
+			telegram:          u.telegram,
+			telegramConfirmed: u.telegram != nil,
+			avatar:            ptr(fmt.Sprintf("https://i.pravatar.cc/300?u=%s", u.email)),
+			createdAt:         at,
+			updatedAt:         at,
+		}
+		userIDs[u.email] = id
+	}
+
+	masterIDs := make(map[string]uint64)
+	for _, m := range []struct {
+		email string
+		info  string
+	}{
+		{email: "anna@example.com", info: "Knitting amigurumi for ten years, every toy is unique."},
+		{email: "boris@example.com", info: "Eco-friendly wooden toys, covered with natural oils only."},
+		{email: "elena@example.com", info: "Collectible textile dolls in folk costumes."},
+	} {
+		id := s.nextID()
+		at := next()
+		s.masters[id] = &master{id: id, userID: userIDs[m.email], info: ptr(m.info), createdAt: at, updatedAt: at}
+		masterIDs[m.email] = id
+	}
+
+	for _, t := range []struct {
+		master      string
+		category    string
+		name        string
+		description string
+		price       float32
+		quantity    uint32
+		tags        []uint32
+	}{
+		{
+			master:      "anna@example.com",
+			category:    "Knitted toys",
+			name:        "Knitted bunny",
+			description: "Soft bunny, knitted from hypoallergenic cotton yarn. Height is 25 cm.",
+			price:       1500,
+			quantity:    3,
+			tags:        tags("handmade", "for kids", "gift"),
+		},
+		{
+			master:      "anna@example.com",
+			category:    "Knitted toys",
+			name:        "Amigurumi fox",
+			description: "Small fox with a fluffy tail, which fits in a palm.",
+			price:       900,
+			quantity:    5,
+			tags:        tags("handmade", "gift"),
+		},
+		{
+			master:      "anna@example.com",
+			category:    "Soft toys",
+			name:        "Sleepy bear",
+			description: "Plush bear for calm sleep, filled with holofiber.",
+			price:       2100,
+			quantity:    1,
+			tags:        tags("for kids"),
+		},
+		{
+			master:      "boris@example.com",
+			category:    "Wooden toys",
+			name:        "Wooden train",
+			description: "Train with three carriages, made of birch and covered with linseed oil.",
+			price:       3200,
+			quantity:    2,
+			tags:        tags("eco", "for kids"),
+		},
+		{
+			master:      "boris@example.com",
+			category:    "Wooden toys",
+			name:        "Stacking pyramid",
+			description: "Classic pyramid of seven rings, painted with food-safe colors.",
+			price:       1200,
+			quantity:    7,
+			tags:        tags("eco", "for kids", "gift"),
+		},
+		{
+			master:      "boris@example.com",
+			category:    "Other",
+			name:        "Wooden puzzle cube",
+			description: "Puzzle for adults and teenagers, made of oak.",
+			price:       1800,
+			quantity:    4,
+			tags:        tags("eco"),
+		},
+		{
+			master:      "elena@example.com",
+			category:    "Dolls",
+			name:        "Doll in sarafan",
+			description: "Textile doll in traditional costume with hand embroidery.",
+			price:       4500,
+			quantity:    1,
+			tags:        tags("handmade", "collectible"),
+		},
+		{
+			master:      "elena@example.com",
+			category:    "Dolls",
+			name:        "Angel doll",
+			description: "Linen angel, which is often bought as a christening gift.",
+			price:       2500,
+			quantity:    2,
+			tags:        tags("handmade", "collectible", "gift"),
+		},
+	} {
+		id := s.nextID()
+		at := next()
+		attachments := s.newAttachments([]string{fmt.Sprintf("https://picsum.photos/seed/toy-%d/600/400", id)})
+
+		for i := range attachments {
+			attachments[i].createdAt = at
+			attachments[i].updatedAt = at
+		}
+
+		s.toys[id] = &toy{
+			id:          id,
+			masterID:    masterIDs[t.master],
+			categoryID:  categoryIDs[t.category],
+			name:        t.name,
+			description: t.description,
+			price:       t.price,
+			quantity:    t.quantity,
+			tagIDs:      t.tags,
+			attachments: attachments,
+			createdAt:   at,
+			updatedAt:   at,
+		}
+	}
+
+	ticketIDs := make(map[string]uint64)
+	for _, t := range []struct {
+		user        string
+		category    string
+		name        string
+		description string
+		price       *float32
+		quantity    uint32
+		tags        []uint32
+	}{
+		{
+			user:        "dmitry@example.com",
+			category:    "Soft toys",
+			name:        "Plush dinosaur",
+			description: "Looking for a green plush dinosaur for my son, about 40 cm high.",
+			price:       ptr[float32](3000),
+			quantity:    1,
+			tags:        tags("for kids", "gift"),
+		},
+		{
+			user:        "dmitry@example.com",
+			category:    "Wooden toys",
+			name:        "Wooden road set",
+			description: "Road pieces with crossroads and bridges, compatible with popular railways.",
+			quantity:    1,
+			tags:        tags("eco", "for kids"),
+		},
+		{
+			user:        "olga@example.com",
+			category:    "Knitted toys",
+			name:        "Knitted cat like mine",
+			description: "Knitted copy of my ginger cat, photos will be sent in messages.",
+			price:       ptr[float32](2000),
+			quantity:    1,
+			tags:        tags("handmade", "gift"),
+		},
+		{
+			user:        "olga@example.com",
+			category:    "Dolls",
+			name:        "Wedding dolls",
+			description: "Pair of dolls in wedding costumes as a present for friends.",
+			price:       ptr[float32](6000),
+			quantity:    2,
+			tags:        tags("handmade", "collectible", "gift"),
+		},
+	} {
+		id := s.nextID()
+		at := next()
+		s.tickets[id] = &ticket{
+			id:          id,
+			userID:      userIDs[t.user],
+			categoryID:  categoryIDs[t.category],
+			name:        t.name,
+			description: t.description,
+			price:       t.price,
+			quantity:    t.quantity,
+			tagIDs:      t.tags,
+			attachments: []attachment{},
+			createdAt:   at,
+			updatedAt:   at,
+		}
+		ticketIDs[t.name] = id
+	}
+
+	for _, r := range []struct {
+		ticket  string
+		master  string
+		price   float32
+		comment *string
+	}{
+		{ticket: "Knitted cat like mine", master: "anna@example.com", price: 2200, comment: ptr("Will be ready in a week.")},
+		{ticket: "Wooden road set", master: "boris@example.com", price: 5000},
+		{ticket: "Wedding dolls", master: "elena@example.com", price: 5500, comment: ptr("Can add names on aprons.")},
+	} {
+		id := s.nextID()
+		at := next()
+		s.responds[id] = &respond{
+			id:        id,
+			ticketID:  ticketIDs[r.ticket],
+			masterID:  masterIDs[r.master],
+			price:     r.price,
+			comment:   r.comment,
+			createdAt: at,
+			updatedAt: at,
+		}
+	}
+
+	for _, u := range sortedValues(s.users, true) {
+		id := s.nextID()
+		s.emails[id] = &email{
+			id:      id,
+			userID:  u.id,
+			email:   u.email,
+			content: "Welcome to Handmade Toys Marketplace! Please, confirm your email.",
+			sentAt:  u.createdAt,
+		}
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// clonePtr copies value, so that store does not share memory with requests.
+func clonePtr[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	return ptr(*value)
+}
@@ -0,0 +1,356 @@
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// Tokens of fake SSO service are not signed and only contain ID of user after prefix. Any password is
+// accepted for existing email, so that every seeded user can be logged in.
+const (
+	accessTokenPrefix         = "access-"
+	refreshTokenPrefix        = "refresh-"
+	verifyEmailTokenPrefix    = "verify-"
+	forgetPasswordTokenPrefix = "forget-"
+)
+
+var _ interfaces.SsoClient = (*SsoClient)(nil)
+
+// SsoClient is in-memory implementation of SSO service client.
+type SsoClient struct {
+	store *Store
+}
+
+func NewSsoClient(store *Store) *SsoClient {
+	return &SsoClient{store: store}
+}
+
+func (c *SsoClient) Register(
+	_ context.Context,
+	in *sso.RegisterIn,
+	_ ...grpc.CallOption,
+) (*sso.RegisterOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if c.store.userByEmail(in.GetEmail()) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "user with email %s already exists", in.GetEmail())
+	}
+
+	id := c.store.nextID()
+	now := c.store.now()
+	c.store.users[id] = &user{
+		id:          id,
+		displayName: in.GetDisplayName(),
+		email:       in.GetEmail(),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+
+	c.store.addEmail(id, "Please, confirm your email with token "+token(verifyEmailTokenPrefix, id))
+
+	return &sso.RegisterOut{UserID: id}, nil
+}
+
+func (c *SsoClient) Login(
+	_ context.Context,
+	in *sso.LoginIn,
+	_ ...grpc.CallOption,
+) (*sso.LoginOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	u := c.store.userByEmail(in.GetEmail())
+	if u == nil {
+		return nil, status.Errorf(codes.NotFound, "user with email %s not found", in.GetEmail())
+	}
+
+	return tokens(u.id), nil
+}
+
+func (c *SsoClient) Logout(
+	_ context.Context,
+	in *sso.LogoutIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, err := c.authenticate(accessTokenPrefix, in.GetAccessToken()); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) RefreshTokens(
+	_ context.Context,
+	in *sso.RefreshTokensIn,
+	_ ...grpc.CallOption,
+) (*sso.LoginOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	u, err := c.authenticate(refreshTokenPrefix, in.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens(u.id), nil
+}
+
+func (c *SsoClient) VerifyEmail(
+	_ context.Context,
+	in *sso.VerifyEmailIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	u, err := c.authenticate(verifyEmailTokenPrefix, in.GetVerifyEmailToken())
+	if err != nil {
+		return nil, err
+	}
+
+	u.emailConfirmed = true
+	u.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) ForgetPassword(
+	_ context.Context,
+	in *sso.ForgetPasswordIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, err := c.authenticate(forgetPasswordTokenPrefix, in.GetForgetPasswordToken()); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) SendVerifyEmailMessage(
+	_ context.Context,
+	in *sso.SendVerifyEmailMessageIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	u := c.store.userByEmail(in.GetEmail())
+	if u == nil {
+		return nil, status.Errorf(codes.NotFound, "user with email %s not found", in.GetEmail())
+	}
+
+	c.store.addEmail(u.id, "Please, confirm your email with token "+token(verifyEmailTokenPrefix, u.id))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) SendForgetPasswordMessage(
+	_ context.Context,
+	in *sso.SendForgetPasswordMessageIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	u := c.store.userByEmail(in.GetEmail())
+	if u == nil {
+		return nil, status.Errorf(codes.NotFound, "user with email %s not found", in.GetEmail())
+	}
+
+	c.store.addEmail(u.id, "Use token "+token(forgetPasswordTokenPrefix, u.id)+" to set new password")
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) ChangePassword(
+	_ context.Context,
+	in *sso.ChangePasswordIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, err := c.authenticate(accessTokenPrefix, in.GetAccessToken()); err != nil {
+		return nil, err
+	}
+
+	if in.GetOldPassword() == in.GetNewPassword() {
+		return nil, status.Error(codes.FailedPrecondition, "new password must differ from old one")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *SsoClient) GetUser(
+	_ context.Context,
+	in *sso.GetUserIn,
+	_ ...grpc.CallOption,
+) (*sso.GetUserOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	u, ok := c.store.users[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user with ID %d not found", in.GetID())
+	}
+
+	return userOut(u), nil
+}
+
+func (c *SsoClient) GetUserByEmail(
+	_ context.Context,
+	in *sso.GetUserByEmailIn,
+	_ ...grpc.CallOption,
+) (*sso.GetUserOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	u := c.store.userByEmail(in.GetEmail())
+	if u == nil {
+		return nil, status.Errorf(codes.NotFound, "user with email %s not found", in.GetEmail())
+	}
+
+	return userOut(u), nil
+}
+
+func (c *SsoClient) GetUsers(
+	_ context.Context,
+	in *sso.GetUsersIn,
+	_ ...grpc.CallOption,
+) (*sso.GetUsersOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	users := paginate(sortedValues(c.store.users, false), ssoPage(in.GetPagination()))
+
+	out := &sso.GetUsersOut{Users: make([]*sso.GetUserOut, len(users))}
+	for i, u := range users {
+		out.Users[i] = userOut(u)
+	}
+
+	return out, nil
+}
+
+func (c *SsoClient) GetMe(
+	_ context.Context,
+	in *sso.GetMeIn,
+	_ ...grpc.CallOption,
+) (*sso.GetUserOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	u, err := c.authenticate(accessTokenPrefix, in.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return userOut(u), nil
+}
+
+func (c *SsoClient) UpdateUserProfile(
+	_ context.Context,
+	in *sso.UpdateUserProfileIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	u, err := c.authenticate(accessTokenPrefix, in.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if in.DisplayName != nil {
+		u.displayName = *in.DisplayName
+	}
+
+	if in.Phone != nil && (u.phone == nil || *u.phone != *in.Phone) {
+		u.phone = clonePtr(in.Phone)
+		u.phoneConfirmed = false
+	}
+
+	if in.Telegram != nil && (u.telegram == nil || *u.telegram != *in.Telegram) {
+		u.telegram = clonePtr(in.Telegram)
+		u.telegramConfirmed = false
+	}
+
+	if in.Avatar != nil {
+		u.avatar = clonePtr(in.Avatar)
+	}
+
+	u.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+// authenticate returns owner of token with provided prefix. Store lock must be held by caller.
+func (c *SsoClient) authenticate(prefix, value string) (*user, error) {
+	rawID, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	u, ok := c.store.users[id]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "token owner not found")
+	}
+
+	return u, nil
+}
+
+func token(prefix string, userID uint64) string {
+	return fmt.Sprintf("%s%d", prefix, userID)
+}
+
+func tokens(userID uint64) *sso.LoginOut {
+	return &sso.LoginOut{
+		AccessToken:  token(accessTokenPrefix, userID),
+		RefreshToken: token(refreshTokenPrefix, userID),
+	}
+}
+
+func ssoPage(pagination *sso.Pagination) page {
+	if pagination == nil {
+		return page{}
+	}
+
+	return page{limit: pagination.Limit, offset: pagination.Offset}
+}
+
+func userOut(u *user) *sso.GetUserOut {
+	return &sso.GetUserOut{
+		ID:                u.id,
+		DisplayName:       u.displayName,
+		Email:             u.email,
+		EmailConfirmed:    u.emailConfirmed,
+		Phone:             clonePtr(u.phone),
+		PhoneConfirmed:    u.phoneConfirmed,
+		Telegram:          clonePtr(u.telegram),
+		TelegramConfirmed: u.telegramConfirmed,
+		Avatar:            clonePtr(u.avatar),
+		CreatedAt:         timestamp(u.createdAt),
+		UpdatedAt:         timestamp(u.updatedAt),
+	}
+}
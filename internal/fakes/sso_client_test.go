@@ -0,0 +1,146 @@
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSsoClient_Login(t *testing.T) {
+	client := NewSsoClient(NewStore())
+
+	testCases := []struct {
+		name         string
+		email        string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "seeded user with any password",
+			email:        "anna@example.com",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "email in other case",
+			email:        "ANNA@example.com",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "unknown email",
+			email:        "unknown@example.com",
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := client.Login(
+				context.Background(),
+				&sso.LoginIn{Email: tc.email, Password: "any password"},
+			)
+			require.Equal(t, tc.expectedCode, status.Code(err))
+
+			if tc.expectedCode != codes.OK {
+				return
+			}
+
+			me, err := client.GetMe(context.Background(), &sso.GetMeIn{AccessToken: tokens.GetAccessToken()})
+			require.NoError(t, err)
+			require.Equal(t, "anna@example.com", me.GetEmail())
+		})
+	}
+}
+
+func TestSsoClient_GetMe(t *testing.T) {
+	client := NewSsoClient(NewStore())
+
+	testCases := []struct {
+		name         string
+		accessToken  string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "valid token",
+			accessToken:  "access-1",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "refresh token instead of access one",
+			accessToken:  "refresh-1",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "malformed token",
+			accessToken:  "access-abc",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "owner not found",
+			accessToken:  "access-100500",
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.GetMe(context.Background(), &sso.GetMeIn{AccessToken: tc.accessToken})
+			require.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestSsoClient_Register(t *testing.T) {
+	store := NewStore()
+	client := NewSsoClient(store)
+	notificationsClient := NewNotificationsClient(store)
+
+	out, err := client.Register(
+		context.Background(),
+		&sso.RegisterIn{DisplayName: "New user", Email: "new@example.com", Password: "password"},
+	)
+	require.NoError(t, err)
+
+	// Verification email is stored, so that token could be found by frontend developer:
+	emails, err := notificationsClient.CountUserEmailCommunications(
+		context.Background(),
+		&notifications.CountUserEmailCommunicationsIn{UserID: out.GetUserID()},
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), emails.GetCount())
+
+	_, err = client.VerifyEmail(
+		context.Background(),
+		&sso.VerifyEmailIn{VerifyEmailToken: token(verifyEmailTokenPrefix, out.GetUserID())},
+	)
+	require.NoError(t, err)
+
+	user, err := client.GetUser(context.Background(), &sso.GetUserIn{ID: out.GetUserID()})
+	require.NoError(t, err)
+	require.True(t, user.GetEmailConfirmed())
+
+	_, err = client.Register(
+		context.Background(),
+		&sso.RegisterIn{DisplayName: "Same email", Email: "new@example.com", Password: "password"},
+	)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestSsoClient_GetUsers(t *testing.T) {
+	client := NewSsoClient(NewStore())
+	limit, offset := uint64(2), uint64(1)
+
+	out, err := client.GetUsers(
+		context.Background(),
+		&sso.GetUsersIn{Pagination: &sso.Pagination{Limit: &limit, Offset: &offset}},
+	)
+	require.NoError(t, err)
+	require.Len(t, out.GetUsers(), 2)
+
+	// Newest users go first, so offset skips the last seeded one:
+	require.Equal(t, "dmitry@example.com", out.GetUsers()[0].GetEmail())
+	require.Equal(t, "elena@example.com", out.GetUsers()[1].GetEmail())
+}
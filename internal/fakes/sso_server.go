@@ -0,0 +1,126 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RegisterSsoServer registers gRPC services of SSO, which delegate calls to fake client, so that
+// real gRPC clients could be tested against in-process server.
+func RegisterSsoServer(registrar grpc.ServiceRegistrar, client *SsoClient) {
+	sso.RegisterAuthServiceServer(registrar, &authServer{client: client})
+	sso.RegisterUsersServiceServer(registrar, &usersServer{client: client})
+}
+
+type authServer struct {
+	sso.UnimplementedAuthServiceServer
+
+	client *SsoClient
+}
+
+func (s *authServer) Register(
+	ctx context.Context,
+	in *sso.RegisterIn,
+) (*sso.RegisterOut, error) {
+	return s.client.Register(ctx, in)
+}
+
+func (s *authServer) Login(
+	ctx context.Context,
+	in *sso.LoginIn,
+) (*sso.LoginOut, error) {
+	return s.client.Login(ctx, in)
+}
+
+func (s *authServer) Logout(
+	ctx context.Context,
+	in *sso.LogoutIn,
+) (*emptypb.Empty, error) {
+	return s.client.Logout(ctx, in)
+}
+
+func (s *authServer) RefreshTokens(
+	ctx context.Context,
+	in *sso.RefreshTokensIn,
+) (*sso.LoginOut, error) {
+	return s.client.RefreshTokens(ctx, in)
+}
+
+func (s *authServer) VerifyEmail(
+	ctx context.Context,
+	in *sso.VerifyEmailIn,
+) (*emptypb.Empty, error) {
+	return s.client.VerifyEmail(ctx, in)
+}
+
+func (s *authServer) ForgetPassword(
+	ctx context.Context,
+	in *sso.ForgetPasswordIn,
+) (*emptypb.Empty, error) {
+	return s.client.ForgetPassword(ctx, in)
+}
+
+func (s *authServer) SendVerifyEmailMessage(
+	ctx context.Context,
+	in *sso.SendVerifyEmailMessageIn,
+) (*emptypb.Empty, error) {
+	return s.client.SendVerifyEmailMessage(ctx, in)
+}
+
+func (s *authServer) SendForgetPasswordMessage(
+	ctx context.Context,
+	in *sso.SendForgetPasswordMessageIn,
+) (*emptypb.Empty, error) {
+	return s.client.SendForgetPasswordMessage(ctx, in)
+}
+
+func (s *authServer) ChangePassword(
+	ctx context.Context,
+	in *sso.ChangePasswordIn,
+) (*emptypb.Empty, error) {
+	return s.client.ChangePassword(ctx, in)
+}
+
+type usersServer struct {
+	sso.UnimplementedUsersServiceServer
+
+	client *SsoClient
+}
+
+func (s *usersServer) GetUser(
+	ctx context.Context,
+	in *sso.GetUserIn,
+) (*sso.GetUserOut, error) {
+	return s.client.GetUser(ctx, in)
+}
+
+func (s *usersServer) GetUserByEmail(
+	ctx context.Context,
+	in *sso.GetUserByEmailIn,
+) (*sso.GetUserOut, error) {
+	return s.client.GetUserByEmail(ctx, in)
+}
+
+func (s *usersServer) GetUsers(
+	ctx context.Context,
+	in *sso.GetUsersIn,
+) (*sso.GetUsersOut, error) {
+	return s.client.GetUsers(ctx, in)
+}
+
+func (s *usersServer) GetMe(
+	ctx context.Context,
+	in *sso.GetMeIn,
+) (*sso.GetUserOut, error) {
+	return s.client.GetMe(ctx, in)
+}
+
+func (s *usersServer) UpdateUserProfile(
+	ctx context.Context,
+	in *sso.UpdateUserProfileIn,
+) (*emptypb.Empty, error) {
+	return s.client.UpdateUserProfile(ctx, in)
+}
@@ -0,0 +1,321 @@
+package fakes
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type user struct {
+	id                uint64
+	displayName       string
+	email             string
+	emailConfirmed    bool
+	phone             *string
+	phoneConfirmed    bool
+	telegram          *string
+	telegramConfirmed bool
+	avatar            *string
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+type master struct {
+	id        uint64
+	userID    uint64
+	info      *string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type category struct {
+	id   uint32
+	name string
+}
+
+type tag struct {
+	id        uint32
+	name      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type attachment struct {
+	id        uint64
+	link      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type toy struct {
+	id          uint64
+	masterID    uint64
+	categoryID  uint32
+	name        string
+	description string
+	price       float32
+	quantity    uint32
+	tagIDs      []uint32
+	attachments []attachment
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+type ticket struct {
+	id          uint64
+	userID      uint64
+	categoryID  uint32
+	name        string
+	description string
+	price       *float32
+	quantity    uint32
+	tagIDs      []uint32
+	attachments []attachment
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+type respond struct {
+	id        uint64
+	ticketID  uint64
+	masterID  uint64
+	price     float32
+	comment   *string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type email struct {
+	id      uint64
+	userID  uint64
+	email   string
+	content string
+	sentAt  time.Time
+}
+
+// Store keeps data of all fake downstream services, so that they reference each other consistently:
+// masters of Toys service are users of SSO service, responds of Tickets service are made by masters etc.
+type Store struct {
+	mu sync.RWMutex
+
+	users      map[uint64]*user
+	masters    map[uint64]*master
+	categories map[uint32]*category
+	tags       map[uint32]*tag
+	toys       map[uint64]*toy
+	tickets    map[uint64]*ticket
+	responds   map[uint64]*respond
+	emails     map[uint64]*email
+
+	lastID           uint64 // IDs are unique across entities, so that mixed up IDs are noticed during development
+	lastDictionaryID uint32 // IDs of categories and tags
+	now              func() time.Time
+}
+
+// NewStore returns store with seeded data.
+func NewStore() *Store {
+	store := &Store{
+		users:      make(map[uint64]*user),
+		masters:    make(map[uint64]*master),
+		categories: make(map[uint32]*category),
+		tags:       make(map[uint32]*tag),
+		toys:       make(map[uint64]*toy),
+		tickets:    make(map[uint64]*ticket),
+		responds:   make(map[uint64]*respond),
+		emails:     make(map[uint64]*email),
+		now:        time.Now,
+	}
+
+	seed(store)
+
+	return store
+}
+
+func (s *Store) nextID() uint64 {
+	s.lastID++
+
+	return s.lastID
+}
+
+func (s *Store) nextDictionaryID() uint32 {
+	s.lastDictionaryID++
+
+	return s.lastDictionaryID
+}
+
+func (s *Store) userByEmail(address string) *user {
+	for _, u := range s.users {
+		if strings.EqualFold(u.email, address) {
+			return u
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) masterByUser(userID uint64) *master {
+	for _, m := range s.masters {
+		if m.userID == userID {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) addEmail(userID uint64, content string) {
+	u, ok := s.users[userID]
+	if !ok {
+		return
+	}
+
+	id := s.nextID()
+	s.emails[id] = &email{
+		id:      id,
+		userID:  userID,
+		email:   u.email,
+		content: content,
+		sentAt:  s.now(),
+	}
+}
+
+func (s *Store) newAttachments(links []string) []attachment {
+	now := s.now()
+	attachments := make([]attachment, len(links))
+
+	for i, link := range links {
+		attachments[i] = attachment{
+			id:        s.nextID(),
+			link:      link,
+			createdAt: now,
+			updatedAt: now,
+		}
+	}
+
+	return attachments
+}
+
+// replaceAttachments returns attachments for new links. Attachments of links, which are still used, are kept.
+func (s *Store) replaceAttachments(attachments []attachment, links []string) []attachment {
+	replaced := make([]attachment, 0, len(links))
+
+	var newLinks []string
+
+	for _, link := range links {
+		i := slices.IndexFunc(attachments, func(a attachment) bool { return a.link == link })
+		if i < 0 {
+			newLinks = append(newLinks, link)
+
+			continue
+		}
+
+		replaced = append(replaced, attachments[i])
+	}
+
+	return append(replaced, s.newAttachments(newLinks)...)
+}
+
+// itemFilters are common filters of toys and tickets.
+type itemFilters struct {
+	search        *string
+	priceCeil     *float32
+	priceFloor    *float32
+	quantityFloor *uint32
+	categoryIDs   []uint32
+	tagIDs        []uint32
+	ascending     bool
+}
+
+type item struct {
+	name        string
+	description string
+	price       *float32
+	quantity    uint32
+	categoryID  uint32
+	tagIDs      []uint32
+}
+
+func (f itemFilters) match(i item) bool {
+	if f.search != nil {
+		search := strings.ToLower(*f.search)
+		if !strings.Contains(strings.ToLower(i.name), search) &&
+			!strings.Contains(strings.ToLower(i.description), search) {
+			return false
+		}
+	}
+
+	if f.priceCeil != nil && (i.price == nil || *i.price > *f.priceCeil) {
+		return false
+	}
+
+	if f.priceFloor != nil && (i.price == nil || *i.price < *f.priceFloor) {
+		return false
+	}
+
+	if f.quantityFloor != nil && i.quantity < *f.quantityFloor {
+		return false
+	}
+
+	if len(f.categoryIDs) > 0 && !slices.Contains(f.categoryIDs, i.categoryID) {
+		return false
+	}
+
+	if len(f.tagIDs) > 0 && !slices.ContainsFunc(f.tagIDs, func(id uint32) bool {
+		return slices.Contains(i.tagIDs, id)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// sortedValues returns values of map ordered by creation, which is the same as order of IDs. Newest values
+// go first, unless ascending order is set.
+func sortedValues[K cmp.Ordered, V any](values map[K]*V, ascending bool) []*V {
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	if !ascending {
+		slices.Reverse(keys)
+	}
+
+	sorted := make([]*V, len(keys))
+	for i, key := range keys {
+		sorted[i] = values[key]
+	}
+
+	return sorted
+}
+
+// page is pagination of request, which is the same for all downstream services.
+type page struct {
+	limit  *uint64
+	offset *uint64
+}
+
+// paginate returns page of values. All values are returned, if limit is not set.
+func paginate[V any](values []V, p page) []V {
+	start := uint64(0)
+	if p.offset != nil {
+		start = min(*p.offset, uint64(len(values)))
+	}
+
+	end := uint64(len(values))
+	if p.limit != nil {
+		end = min(start+*p.limit, end)
+	}
+
+	return values[start:end]
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(t)
+}
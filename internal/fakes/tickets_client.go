@@ -0,0 +1,433 @@
+package fakes
+
+import (
+	"context"
+	"slices"
+
+	"github.com/DKhorkov/hmtm-tickets/api/protobuf/generated/go/tickets"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+var _ interfaces.TicketsClient = (*TicketsClient)(nil)
+
+// TicketsClient is in-memory implementation of Tickets service client.
+type TicketsClient struct {
+	store *Store
+}
+
+func NewTicketsClient(store *Store) *TicketsClient {
+	return &TicketsClient{store: store}
+}
+
+func (c *TicketsClient) CreateTicket(
+	_ context.Context,
+	in *tickets.CreateTicketIn,
+	_ ...grpc.CallOption,
+) (*tickets.CreateTicketOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if _, ok := c.store.users[in.GetUserID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "user with ID %d not found", in.GetUserID())
+	}
+
+	if _, ok := c.store.categories[in.GetCategoryID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "category with ID %d not found", in.GetCategoryID())
+	}
+
+	id := c.store.nextID()
+	now := c.store.now()
+	c.store.tickets[id] = &ticket{
+		id:          id,
+		userID:      in.GetUserID(),
+		categoryID:  in.GetCategoryID(),
+		name:        in.GetName(),
+		description: in.GetDescription(),
+		price:       clonePtr(in.Price),
+		quantity:    in.GetQuantity(),
+		tagIDs:      slices.Clone(in.GetTagIDs()),
+		attachments: c.store.newAttachments(in.GetAttachments()),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+
+	return &tickets.CreateTicketOut{TicketID: id}, nil
+}
+
+func (c *TicketsClient) GetTicket(
+	_ context.Context,
+	in *tickets.GetTicketIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetTicketOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	t, ok := c.store.tickets[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "ticket with ID %d not found", in.GetID())
+	}
+
+	return ticketOut(t), nil
+}
+
+func (c *TicketsClient) GetTickets(
+	_ context.Context,
+	in *tickets.GetTicketsIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetTicketsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return ticketsOut(c.filterTickets(in.GetFilters(), nil), ticketsPage(in.GetPagination())), nil
+}
+
+func (c *TicketsClient) CountTickets(
+	_ context.Context,
+	in *tickets.CountTicketsIn,
+	_ ...grpc.CallOption,
+) (*tickets.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return &tickets.CountOut{Count: uint64(len(c.filterTickets(in.GetFilters(), nil)))}, nil
+}
+
+func (c *TicketsClient) GetUserTickets(
+	_ context.Context,
+	in *tickets.GetUserTicketsIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetTicketsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	userID := in.GetUserID()
+
+	return ticketsOut(c.filterTickets(in.GetFilters(), &userID), ticketsPage(in.GetPagination())), nil
+}
+
+func (c *TicketsClient) CountUserTickets(
+	_ context.Context,
+	in *tickets.CountUserTicketsIn,
+	_ ...grpc.CallOption,
+) (*tickets.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	userID := in.GetUserID()
+
+	return &tickets.CountOut{Count: uint64(len(c.filterTickets(in.GetFilters(), &userID)))}, nil
+}
+
+func (c *TicketsClient) UpdateTicket(
+	_ context.Context,
+	in *tickets.UpdateTicketIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	t, ok := c.store.tickets[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "ticket with ID %d not found", in.GetID())
+	}
+
+	if in.CategoryID != nil {
+		if _, ok = c.store.categories[*in.CategoryID]; !ok {
+			return nil, status.Errorf(codes.NotFound, "category with ID %d not found", *in.CategoryID)
+		}
+
+		t.categoryID = *in.CategoryID
+	}
+
+	if in.Name != nil {
+		t.name = *in.Name
+	}
+
+	if in.Description != nil {
+		t.description = *in.Description
+	}
+
+	if in.Price != nil {
+		t.price = clonePtr(in.Price)
+	}
+
+	if in.Quantity != nil {
+		t.quantity = *in.Quantity
+	}
+
+	// Tags and attachments are always replaced, because BFF sends their full new lists:
+	t.tagIDs = slices.Clone(in.GetTagIDs())
+	t.attachments = c.store.replaceAttachments(t.attachments, in.GetAttachments())
+	t.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteTicket deletes ticket together with its responds.
+func (c *TicketsClient) DeleteTicket(
+	_ context.Context,
+	in *tickets.DeleteTicketIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if _, ok := c.store.tickets[in.GetID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "ticket with ID %d not found", in.GetID())
+	}
+
+	delete(c.store.tickets, in.GetID())
+
+	for id, r := range c.store.responds {
+		if r.ticketID == in.GetID() {
+			delete(c.store.responds, id)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *TicketsClient) RespondToTicket(
+	_ context.Context,
+	in *tickets.RespondToTicketIn,
+	_ ...grpc.CallOption,
+) (*tickets.RespondToTicketOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	t, ok := c.store.tickets[in.GetTicketID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "ticket with ID %d not found", in.GetTicketID())
+	}
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "user with ID %d is not a master", in.GetUserID())
+	}
+
+	if t.userID == m.userID {
+		return nil, status.Error(codes.PermissionDenied, "master can not respond to own ticket")
+	}
+
+	for _, r := range c.store.responds {
+		if r.ticketID == t.id && r.masterID == m.id {
+			return nil, status.Errorf(codes.AlreadyExists, "master already responded to ticket with ID %d", t.id)
+		}
+	}
+
+	id := c.store.nextID()
+	now := c.store.now()
+	c.store.responds[id] = &respond{
+		id:        id,
+		ticketID:  t.id,
+		masterID:  m.id,
+		price:     in.GetPrice(),
+		comment:   clonePtr(in.Comment),
+		createdAt: now,
+		updatedAt: now,
+	}
+
+	return &tickets.RespondToTicketOut{RespondID: id}, nil
+}
+
+func (c *TicketsClient) GetRespond(
+	_ context.Context,
+	in *tickets.GetRespondIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetRespondOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	r, ok := c.store.responds[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "respond with ID %d not found", in.GetID())
+	}
+
+	return respondOut(r), nil
+}
+
+func (c *TicketsClient) GetTicketResponds(
+	_ context.Context,
+	in *tickets.GetTicketRespondsIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetRespondsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, ok := c.store.tickets[in.GetTicketID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "ticket with ID %d not found", in.GetTicketID())
+	}
+
+	return c.respondsOut(func(r *respond) bool { return r.ticketID == in.GetTicketID() }), nil
+}
+
+// GetUserResponds returns responds of master, which belongs to user.
+func (c *TicketsClient) GetUserResponds(
+	_ context.Context,
+	in *tickets.GetUserRespondsIn,
+	_ ...grpc.CallOption,
+) (*tickets.GetRespondsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.NotFound, "master for user with ID %d not found", in.GetUserID())
+	}
+
+	return c.respondsOut(func(r *respond) bool { return r.masterID == m.id }), nil
+}
+
+func (c *TicketsClient) UpdateRespond(
+	_ context.Context,
+	in *tickets.UpdateRespondIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	r, ok := c.store.responds[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "respond with ID %d not found", in.GetID())
+	}
+
+	if in.Price != nil {
+		r.price = *in.Price
+	}
+
+	if in.Comment != nil {
+		r.comment = clonePtr(in.Comment)
+	}
+
+	r.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *TicketsClient) DeleteRespond(
+	_ context.Context,
+	in *tickets.DeleteRespondIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if _, ok := c.store.responds[in.GetID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "respond with ID %d not found", in.GetID())
+	}
+
+	delete(c.store.responds, in.GetID())
+
+	return &emptypb.Empty{}, nil
+}
+
+// filterTickets returns tickets, which match filters. Tickets are additionally filtered by owner, if it is provided.
+func (c *TicketsClient) filterTickets(filters *tickets.TicketsFilters, userID *uint64) []*ticket {
+	f := ticketsFilters(filters)
+
+	return slices.DeleteFunc(sortedValues(c.store.tickets, f.ascending), func(t *ticket) bool {
+		if userID != nil && t.userID != *userID {
+			return true
+		}
+
+		return !f.match(item{
+			name:        t.name,
+			description: t.description,
+			price:       t.price,
+			quantity:    t.quantity,
+			categoryID:  t.categoryID,
+			tagIDs:      t.tagIDs,
+		})
+	})
+}
+
+func (c *TicketsClient) respondsOut(match func(r *respond) bool) *tickets.GetRespondsOut {
+	out := &tickets.GetRespondsOut{Responds: make([]*tickets.GetRespondOut, 0)}
+	for _, r := range sortedValues(c.store.responds, false) {
+		if match(r) {
+			out.Responds = append(out.Responds, respondOut(r))
+		}
+	}
+
+	return out
+}
+
+func ticketsOut(filtered []*ticket, p page) *tickets.GetTicketsOut {
+	filtered = paginate(filtered, p)
+
+	out := &tickets.GetTicketsOut{Tickets: make([]*tickets.GetTicketOut, len(filtered))}
+	for i, t := range filtered {
+		out.Tickets[i] = ticketOut(t)
+	}
+
+	return out
+}
+
+func ticketOut(t *ticket) *tickets.GetTicketOut {
+	out := &tickets.GetTicketOut{
+		ID:          t.id,
+		UserID:      t.userID,
+		CategoryID:  t.categoryID,
+		Name:        t.name,
+		Description: t.description,
+		Price:       clonePtr(t.price),
+		Quantity:    t.quantity,
+		CreatedAt:   timestamp(t.createdAt),
+		UpdatedAt:   timestamp(t.updatedAt),
+		TagIDs:      slices.Clone(t.tagIDs),
+		Attachments: make([]*tickets.Attachment, len(t.attachments)),
+	}
+
+	for i, a := range t.attachments {
+		out.Attachments[i] = &tickets.Attachment{
+			ID:        a.id,
+			TicketID:  t.id,
+			Link:      a.link,
+			CreatedAt: timestamp(a.createdAt),
+			UpdatedAt: timestamp(a.updatedAt),
+		}
+	}
+
+	return out
+}
+
+func respondOut(r *respond) *tickets.GetRespondOut {
+	return &tickets.GetRespondOut{
+		ID:        r.id,
+		TicketID:  r.ticketID,
+		MasterID:  r.masterID,
+		Price:     r.price,
+		Comment:   clonePtr(r.comment),
+		CreatedAt: timestamp(r.createdAt),
+		UpdatedAt: timestamp(r.updatedAt),
+	}
+}
+
+func ticketsFilters(filters *tickets.TicketsFilters) itemFilters {
+	if filters == nil {
+		return itemFilters{}
+	}
+
+	return itemFilters{
+		search:        filters.Search,
+		priceCeil:     filters.PriceCeil,
+		priceFloor:    filters.PriceFloor,
+		quantityFloor: filters.QuantityFloor,
+		categoryIDs:   filters.CategoryIDs,
+		tagIDs:        filters.TagIDs,
+		ascending:     filters.CreatedAtOrderByAsc != nil && *filters.CreatedAtOrderByAsc,
+	}
+}
+
+func ticketsPage(pagination *tickets.Pagination) page {
+	if pagination == nil {
+		return page{}
+	}
+
+	return page{limit: pagination.Limit, offset: pagination.Offset}
+}
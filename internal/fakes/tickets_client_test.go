@@ -0,0 +1,124 @@
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DKhorkov/hmtm-tickets/api/protobuf/generated/go/tickets"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTicketsClient_RespondToTicket(t *testing.T) {
+	store := NewStore()
+	client := NewTicketsClient(store)
+
+	// Oldest ticket of Dmitry has no responds:
+	userID := store.userByEmail("dmitry@example.com").id
+	userTickets, err := client.GetUserTickets(context.Background(), &tickets.GetUserTicketsIn{UserID: userID})
+	require.NoError(t, err)
+
+	dinosaurTicket := userTickets.GetTickets()[len(userTickets.GetTickets())-1]
+	require.Equal(t, "Plush dinosaur", dinosaurTicket.GetName())
+
+	testCases := []struct {
+		name         string
+		email        string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "master responds",
+			email:        "anna@example.com",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "master responds again",
+			email:        "anna@example.com",
+			expectedCode: codes.AlreadyExists,
+		},
+		{
+			name:         "user is not master",
+			email:        "olga@example.com",
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err = client.RespondToTicket(
+				context.Background(),
+				&tickets.RespondToTicketIn{
+					TicketID: dinosaurTicket.GetID(),
+					UserID:   store.userByEmail(tc.email).id,
+					Price:    2500,
+				},
+			)
+			require.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+
+	responds, err := client.GetTicketResponds(
+		context.Background(),
+		&tickets.GetTicketRespondsIn{TicketID: dinosaurTicket.GetID()},
+	)
+	require.NoError(t, err)
+	require.Len(t, responds.GetResponds(), 1)
+}
+
+func TestTicketsClient_DeleteTicket(t *testing.T) {
+	store := NewStore()
+	client := NewTicketsClient(store)
+
+	respondsOut, err := client.GetUserResponds(
+		context.Background(),
+		&tickets.GetUserRespondsIn{UserID: store.userByEmail("elena@example.com").id},
+	)
+	require.NoError(t, err)
+	require.Len(t, respondsOut.GetResponds(), 1)
+
+	respond := respondsOut.GetResponds()[0]
+
+	_, err = client.DeleteTicket(context.Background(), &tickets.DeleteTicketIn{ID: respond.GetTicketID()})
+	require.NoError(t, err)
+
+	_, err = client.GetTicket(context.Background(), &tickets.GetTicketIn{ID: respond.GetTicketID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetRespond(context.Background(), &tickets.GetRespondIn{ID: respond.GetID()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestTicketsClient_CountTickets(t *testing.T) {
+	client := NewTicketsClient(NewStore())
+	priceFloor := float32(2500)
+
+	testCases := []struct {
+		name          string
+		filters       *tickets.TicketsFilters
+		expectedCount uint64
+	}{
+		{
+			name:          "without filters",
+			expectedCount: 4,
+		},
+		{
+			name:          "tickets without price do not match price filter",
+			filters:       &tickets.TicketsFilters{PriceFloor: &priceFloor},
+			expectedCount: 2,
+		},
+		{
+			name:          "any of tags",
+			filters:       &tickets.TicketsFilters{TagIDs: []uint32{7, 10}},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := client.CountTickets(context.Background(), &tickets.CountTicketsIn{Filters: tc.filters})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCount, out.GetCount())
+		})
+	}
+}
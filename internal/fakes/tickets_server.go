@@ -0,0 +1,126 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-tickets/api/protobuf/generated/go/tickets"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RegisterTicketsServer registers gRPC services of Tickets, which delegate calls to fake client, so that
+// real gRPC clients could be tested against in-process server.
+func RegisterTicketsServer(registrar grpc.ServiceRegistrar, client *TicketsClient) {
+	tickets.RegisterTicketsServiceServer(registrar, &ticketsServer{client: client})
+	tickets.RegisterRespondsServiceServer(registrar, &respondsServer{client: client})
+}
+
+type ticketsServer struct {
+	tickets.UnimplementedTicketsServiceServer
+
+	client *TicketsClient
+}
+
+func (s *ticketsServer) CreateTicket(
+	ctx context.Context,
+	in *tickets.CreateTicketIn,
+) (*tickets.CreateTicketOut, error) {
+	return s.client.CreateTicket(ctx, in)
+}
+
+func (s *ticketsServer) GetTicket(
+	ctx context.Context,
+	in *tickets.GetTicketIn,
+) (*tickets.GetTicketOut, error) {
+	return s.client.GetTicket(ctx, in)
+}
+
+func (s *ticketsServer) GetTickets(
+	ctx context.Context,
+	in *tickets.GetTicketsIn,
+) (*tickets.GetTicketsOut, error) {
+	return s.client.GetTickets(ctx, in)
+}
+
+func (s *ticketsServer) CountTickets(
+	ctx context.Context,
+	in *tickets.CountTicketsIn,
+) (*tickets.CountOut, error) {
+	return s.client.CountTickets(ctx, in)
+}
+
+func (s *ticketsServer) GetUserTickets(
+	ctx context.Context,
+	in *tickets.GetUserTicketsIn,
+) (*tickets.GetTicketsOut, error) {
+	return s.client.GetUserTickets(ctx, in)
+}
+
+func (s *ticketsServer) CountUserTickets(
+	ctx context.Context,
+	in *tickets.CountUserTicketsIn,
+) (*tickets.CountOut, error) {
+	return s.client.CountUserTickets(ctx, in)
+}
+
+func (s *ticketsServer) UpdateTicket(
+	ctx context.Context,
+	in *tickets.UpdateTicketIn,
+) (*emptypb.Empty, error) {
+	return s.client.UpdateTicket(ctx, in)
+}
+
+func (s *ticketsServer) DeleteTicket(
+	ctx context.Context,
+	in *tickets.DeleteTicketIn,
+) (*emptypb.Empty, error) {
+	return s.client.DeleteTicket(ctx, in)
+}
+
+type respondsServer struct {
+	tickets.UnimplementedRespondsServiceServer
+
+	client *TicketsClient
+}
+
+func (s *respondsServer) RespondToTicket(
+	ctx context.Context,
+	in *tickets.RespondToTicketIn,
+) (*tickets.RespondToTicketOut, error) {
+	return s.client.RespondToTicket(ctx, in)
+}
+
+func (s *respondsServer) GetRespond(
+	ctx context.Context,
+	in *tickets.GetRespondIn,
+) (*tickets.GetRespondOut, error) {
+	return s.client.GetRespond(ctx, in)
+}
+
+func (s *respondsServer) GetTicketResponds(
+	ctx context.Context,
+	in *tickets.GetTicketRespondsIn,
+) (*tickets.GetRespondsOut, error) {
+	return s.client.GetTicketResponds(ctx, in)
+}
+
+func (s *respondsServer) GetUserResponds(
+	ctx context.Context,
+	in *tickets.GetUserRespondsIn,
+) (*tickets.GetRespondsOut, error) {
+	return s.client.GetUserResponds(ctx, in)
+}
+
+func (s *respondsServer) UpdateRespond(
+	ctx context.Context,
+	in *tickets.UpdateRespondIn,
+) (*emptypb.Empty, error) {
+	return s.client.UpdateRespond(ctx, in)
+}
+
+func (s *respondsServer) DeleteRespond(
+	ctx context.Context,
+	in *tickets.DeleteRespondIn,
+) (*emptypb.Empty, error) {
+	return s.client.DeleteRespond(ctx, in)
+}
@@ -0,0 +1,587 @@
+package fakes
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-toys/api/protobuf/generated/go/toys"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+var _ interfaces.ToysClient = (*ToysClient)(nil)
+
+// ToysClient is in-memory implementation of Toys service client.
+type ToysClient struct {
+	store *Store
+}
+
+func NewToysClient(store *Store) *ToysClient {
+	return &ToysClient{store: store}
+}
+
+func (c *ToysClient) GetCategories(
+	_ context.Context,
+	_ *emptypb.Empty,
+	_ ...grpc.CallOption,
+) (*toys.GetCategoriesOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	categories := sortedValues(c.store.categories, true)
+
+	out := &toys.GetCategoriesOut{Categories: make([]*toys.GetCategoryOut, len(categories))}
+	for i, ctg := range categories {
+		out.Categories[i] = &toys.GetCategoryOut{ID: ctg.id, Name: ctg.name}
+	}
+
+	return out, nil
+}
+
+func (c *ToysClient) GetCategory(
+	_ context.Context,
+	in *toys.GetCategoryIn,
+	_ ...grpc.CallOption,
+) (*toys.GetCategoryOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	ctg, ok := c.store.categories[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "category with ID %d not found", in.GetID())
+	}
+
+	return &toys.GetCategoryOut{ID: ctg.id, Name: ctg.name}, nil
+}
+
+func (c *ToysClient) GetTags(
+	_ context.Context,
+	_ *emptypb.Empty,
+	_ ...grpc.CallOption,
+) (*toys.GetTagsOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	tags := sortedValues(c.store.tags, true)
+
+	out := &toys.GetTagsOut{Tags: make([]*toys.GetTagOut, len(tags))}
+	for i, t := range tags {
+		out.Tags[i] = tagOut(t)
+	}
+
+	return out, nil
+}
+
+func (c *ToysClient) GetTag(
+	_ context.Context,
+	in *toys.GetTagIn,
+	_ ...grpc.CallOption,
+) (*toys.GetTagOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	t, ok := c.store.tags[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "tag with ID %d not found", in.GetID())
+	}
+
+	return tagOut(t), nil
+}
+
+// CreateTags returns IDs of existing tags for already known names, as Toys service does.
+func (c *ToysClient) CreateTags(
+	_ context.Context,
+	in *toys.CreateTagsIn,
+	_ ...grpc.CallOption,
+) (*toys.CreateTagsOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	out := &toys.CreateTagsOut{Tags: make([]*toys.CreateTagOut, len(in.GetTags()))}
+	for i, tagIn := range in.GetTags() {
+		out.Tags[i] = &toys.CreateTagOut{ID: c.createTag(tagIn.GetName())}
+	}
+
+	return out, nil
+}
+
+func (c *ToysClient) GetMasters(
+	_ context.Context,
+	in *toys.GetMastersIn,
+	_ ...grpc.CallOption,
+) (*toys.GetMastersOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	masters := paginate(c.filterMasters(in.GetFilters()), toysPage(in.GetPagination()))
+
+	out := &toys.GetMastersOut{Masters: make([]*toys.GetMasterOut, len(masters))}
+	for i, m := range masters {
+		out.Masters[i] = masterOut(m)
+	}
+
+	return out, nil
+}
+
+func (c *ToysClient) CountMasters(
+	_ context.Context,
+	in *toys.CountMastersIn,
+	_ ...grpc.CallOption,
+) (*toys.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return &toys.CountOut{Count: uint64(len(c.filterMasters(in.GetFilters())))}, nil
+}
+
+func (c *ToysClient) GetMaster(
+	_ context.Context,
+	in *toys.GetMasterIn,
+	_ ...grpc.CallOption,
+) (*toys.GetMasterOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	m, ok := c.store.masters[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "master with ID %d not found", in.GetID())
+	}
+
+	return masterOut(m), nil
+}
+
+func (c *ToysClient) GetMasterByUser(
+	_ context.Context,
+	in *toys.GetMasterByUserIn,
+	_ ...grpc.CallOption,
+) (*toys.GetMasterOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.NotFound, "master for user with ID %d not found", in.GetUserID())
+	}
+
+	return masterOut(m), nil
+}
+
+func (c *ToysClient) RegisterMaster(
+	_ context.Context,
+	in *toys.RegisterMasterIn,
+	_ ...grpc.CallOption,
+) (*toys.RegisterMasterOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if _, ok := c.store.users[in.GetUserID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "user with ID %d not found", in.GetUserID())
+	}
+
+	if c.store.masterByUser(in.GetUserID()) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "master for user with ID %d already exists", in.GetUserID())
+	}
+
+	id := c.store.nextID()
+	now := c.store.now()
+	c.store.masters[id] = &master{
+		id:        id,
+		userID:    in.GetUserID(),
+		info:      clonePtr(in.Info),
+		createdAt: now,
+		updatedAt: now,
+	}
+
+	return &toys.RegisterMasterOut{MasterID: id}, nil
+}
+
+func (c *ToysClient) UpdateMaster(
+	_ context.Context,
+	in *toys.UpdateMasterIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	m, ok := c.store.masters[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "master with ID %d not found", in.GetID())
+	}
+
+	if in.Info != nil {
+		m.info = clonePtr(in.Info)
+	}
+
+	m.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *ToysClient) AddToy(
+	_ context.Context,
+	in *toys.AddToyIn,
+	_ ...grpc.CallOption,
+) (*toys.AddToyOut, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.NotFound, "master for user with ID %d not found", in.GetUserID())
+	}
+
+	if err := c.validateReferences(in.GetCategoryID(), in.GetTagIDs()); err != nil {
+		return nil, err
+	}
+
+	id := c.store.nextID()
+	now := c.store.now()
+	c.store.toys[id] = &toy{
+		id:          id,
+		masterID:    m.id,
+		categoryID:  in.GetCategoryID(),
+		name:        in.GetName(),
+		description: in.GetDescription(),
+		price:       in.GetPrice(),
+		quantity:    in.GetQuantity(),
+		tagIDs:      slices.Clone(in.GetTagIDs()),
+		attachments: c.store.newAttachments(in.GetAttachments()),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+
+	return &toys.AddToyOut{ToyID: id}, nil
+}
+
+func (c *ToysClient) GetToys(
+	_ context.Context,
+	in *toys.GetToysIn,
+	_ ...grpc.CallOption,
+) (*toys.GetToysOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return c.toysOut(c.filterToys(in.GetFilters(), nil), toysPage(in.GetPagination())), nil
+}
+
+func (c *ToysClient) CountToys(
+	_ context.Context,
+	in *toys.CountToysIn,
+	_ ...grpc.CallOption,
+) (*toys.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	return &toys.CountOut{Count: uint64(len(c.filterToys(in.GetFilters(), nil)))}, nil
+}
+
+func (c *ToysClient) GetMasterToys(
+	_ context.Context,
+	in *toys.GetMasterToysIn,
+	_ ...grpc.CallOption,
+) (*toys.GetToysOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, ok := c.store.masters[in.GetMasterID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "master with ID %d not found", in.GetMasterID())
+	}
+
+	masterID := in.GetMasterID()
+
+	return c.toysOut(c.filterToys(in.GetFilters(), &masterID), toysPage(in.GetPagination())), nil
+}
+
+func (c *ToysClient) CountMasterToys(
+	_ context.Context,
+	in *toys.CountMasterToysIn,
+	_ ...grpc.CallOption,
+) (*toys.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, ok := c.store.masters[in.GetMasterID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "master with ID %d not found", in.GetMasterID())
+	}
+
+	masterID := in.GetMasterID()
+
+	return &toys.CountOut{Count: uint64(len(c.filterToys(in.GetFilters(), &masterID)))}, nil
+}
+
+func (c *ToysClient) GetUserToys(
+	_ context.Context,
+	in *toys.GetUserToysIn,
+	_ ...grpc.CallOption,
+) (*toys.GetToysOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.NotFound, "master for user with ID %d not found", in.GetUserID())
+	}
+
+	return c.toysOut(c.filterToys(in.GetFilters(), &m.id), toysPage(in.GetPagination())), nil
+}
+
+func (c *ToysClient) CountUserToys(
+	_ context.Context,
+	in *toys.CountUserToysIn,
+	_ ...grpc.CallOption,
+) (*toys.CountOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	m := c.store.masterByUser(in.GetUserID())
+	if m == nil {
+		return nil, status.Errorf(codes.NotFound, "master for user with ID %d not found", in.GetUserID())
+	}
+
+	return &toys.CountOut{Count: uint64(len(c.filterToys(in.GetFilters(), &m.id)))}, nil
+}
+
+func (c *ToysClient) GetToy(
+	_ context.Context,
+	in *toys.GetToyIn,
+	_ ...grpc.CallOption,
+) (*toys.GetToyOut, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	t, ok := c.store.toys[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "toy with ID %d not found", in.GetID())
+	}
+
+	return c.toyOut(t), nil
+}
+
+func (c *ToysClient) UpdateToy(
+	_ context.Context,
+	in *toys.UpdateToyIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	t, ok := c.store.toys[in.GetID()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "toy with ID %d not found", in.GetID())
+	}
+
+	categoryID := t.categoryID
+	if in.CategoryID != nil {
+		categoryID = *in.CategoryID
+	}
+
+	if err := c.validateReferences(categoryID, in.GetTagIDs()); err != nil {
+		return nil, err
+	}
+
+	t.categoryID = categoryID
+
+	if in.Name != nil {
+		t.name = *in.Name
+	}
+
+	if in.Description != nil {
+		t.description = *in.Description
+	}
+
+	if in.Price != nil {
+		t.price = *in.Price
+	}
+
+	if in.Quantity != nil {
+		t.quantity = *in.Quantity
+	}
+
+	// Tags and attachments are always replaced, because BFF sends their full new lists:
+	t.tagIDs = slices.Clone(in.GetTagIDs())
+	t.attachments = c.store.replaceAttachments(t.attachments, in.GetAttachments())
+	t.updatedAt = c.store.now()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *ToysClient) DeleteToy(
+	_ context.Context,
+	in *toys.DeleteToyIn,
+	_ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if _, ok := c.store.toys[in.GetID()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "toy with ID %d not found", in.GetID())
+	}
+
+	delete(c.store.toys, in.GetID())
+
+	return &emptypb.Empty{}, nil
+}
+
+// createTag returns ID of tag with provided name, creating it if necessary. Store lock must be held by caller.
+func (c *ToysClient) createTag(name string) uint32 {
+	for _, t := range c.store.tags {
+		if strings.EqualFold(t.name, name) {
+			return t.id
+		}
+	}
+
+	id := c.store.nextDictionaryID()
+	now := c.store.now()
+	c.store.tags[id] = &tag{id: id, name: name, createdAt: now, updatedAt: now}
+
+	return id
+}
+
+func (c *ToysClient) validateReferences(categoryID uint32, tagIDs []uint32) error {
+	if _, ok := c.store.categories[categoryID]; !ok {
+		return status.Errorf(codes.NotFound, "category with ID %d not found", categoryID)
+	}
+
+	for _, tagID := range tagIDs {
+		if _, ok := c.store.tags[tagID]; !ok {
+			return status.Errorf(codes.NotFound, "tag with ID %d not found", tagID)
+		}
+	}
+
+	return nil
+}
+
+// filterMasters searches masters by info and display name of their users.
+func (c *ToysClient) filterMasters(filters *toys.MastersFilters) []*master {
+	ascending := filters != nil && filters.CreatedAtOrderByAsc != nil && *filters.CreatedAtOrderByAsc
+
+	masters := sortedValues(c.store.masters, ascending)
+	if filters == nil || filters.Search == nil {
+		return masters
+	}
+
+	search := strings.ToLower(*filters.Search)
+
+	return slices.DeleteFunc(masters, func(m *master) bool {
+		if m.info != nil && strings.Contains(strings.ToLower(*m.info), search) {
+			return false
+		}
+
+		u, ok := c.store.users[m.userID]
+
+		return !ok || !strings.Contains(strings.ToLower(u.displayName), search)
+	})
+}
+
+// filterToys returns toys, which match filters. Toys are additionally filtered by master, if it is provided.
+func (c *ToysClient) filterToys(filters *toys.ToysFilters, masterID *uint64) []*toy {
+	f := toysFilters(filters)
+
+	return slices.DeleteFunc(sortedValues(c.store.toys, f.ascending), func(t *toy) bool {
+		if masterID != nil && t.masterID != *masterID {
+			return true
+		}
+
+		return !f.match(item{
+			name:        t.name,
+			description: t.description,
+			price:       &t.price,
+			quantity:    t.quantity,
+			categoryID:  t.categoryID,
+			tagIDs:      t.tagIDs,
+		})
+	})
+}
+
+func (c *ToysClient) toysOut(filtered []*toy, p page) *toys.GetToysOut {
+	filtered = paginate(filtered, p)
+
+	out := &toys.GetToysOut{Toys: make([]*toys.GetToyOut, len(filtered))}
+	for i, t := range filtered {
+		out.Toys[i] = c.toyOut(t)
+	}
+
+	return out
+}
+
+func (c *ToysClient) toyOut(t *toy) *toys.GetToyOut {
+	out := &toys.GetToyOut{
+		ID:          t.id,
+		MasterID:    t.masterID,
+		CategoryID:  t.categoryID,
+		Name:        t.name,
+		Description: t.description,
+		Price:       t.price,
+		Quantity:    t.quantity,
+		CreatedAt:   timestamp(t.createdAt),
+		UpdatedAt:   timestamp(t.updatedAt),
+		Tags:        make([]*toys.GetTagOut, 0, len(t.tagIDs)),
+		Attachments: make([]*toys.Attachment, len(t.attachments)),
+	}
+
+	for _, tagID := range t.tagIDs {
+		if tg, ok := c.store.tags[tagID]; ok {
+			out.Tags = append(out.Tags, tagOut(tg))
+		}
+	}
+
+	for i, a := range t.attachments {
+		out.Attachments[i] = &toys.Attachment{
+			ID:        a.id,
+			ToyID:     t.id,
+			Link:      a.link,
+			CreatedAt: timestamp(a.createdAt),
+			UpdatedAt: timestamp(a.updatedAt),
+		}
+	}
+
+	return out
+}
+
+func toysFilters(filters *toys.ToysFilters) itemFilters {
+	if filters == nil {
+		return itemFilters{}
+	}
+
+	return itemFilters{
+		search:        filters.Search,
+		priceCeil:     filters.PriceCeil,
+		priceFloor:    filters.PriceFloor,
+		quantityFloor: filters.QuantityFloor,
+		categoryIDs:   filters.CategoryIDs,
+		tagIDs:        filters.TagIDs,
+		ascending:     filters.CreatedAtOrderByAsc != nil && *filters.CreatedAtOrderByAsc,
+	}
+}
+
+func toysPage(pagination *toys.Pagination) page {
+	if pagination == nil {
+		return page{}
+	}
+
+	return page{limit: pagination.Limit, offset: pagination.Offset}
+}
+
+func tagOut(t *tag) *toys.GetTagOut {
+	return &toys.GetTagOut{
+		ID:        t.id,
+		Name:      t.name,
+		CreatedAt: timestamp(t.createdAt),
+		UpdatedAt: timestamp(t.updatedAt),
+	}
+}
+
+func masterOut(m *master) *toys.GetMasterOut {
+	return &toys.GetMasterOut{
+		ID:        m.id,
+		UserID:    m.userID,
+		Info:      clonePtr(m.info),
+		CreatedAt: timestamp(m.createdAt),
+		UpdatedAt: timestamp(m.updatedAt),
+	}
+}
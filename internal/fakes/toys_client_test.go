@@ -0,0 +1,181 @@
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DKhorkov/hmtm-toys/api/protobuf/generated/go/toys"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToysClient_GetToys(t *testing.T) {
+	client := NewToysClient(NewStore())
+	search := "WOODEN"
+	priceCeil := float32(2000)
+	ascending := true
+	limit := uint64(1)
+
+	testCases := []struct {
+		name          string
+		in            *toys.GetToysIn
+		expectedNames []string
+	}{
+		{
+			name: "newest first by default",
+			in:   &toys.GetToysIn{Pagination: &toys.Pagination{Limit: &limit}},
+			expectedNames: []string{
+				"Angel doll",
+			},
+		},
+		{
+			name: "ascending order",
+			in: &toys.GetToysIn{
+				Pagination: &toys.Pagination{Limit: &limit},
+				Filters:    &toys.ToysFilters{CreatedAtOrderByAsc: &ascending},
+			},
+			expectedNames: []string{
+				"Knitted bunny",
+			},
+		},
+		{
+			name: "search and price",
+			in: &toys.GetToysIn{
+				Filters: &toys.ToysFilters{Search: &search, PriceCeil: &priceCeil},
+			},
+			expectedNames: []string{
+				"Wooden puzzle cube",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := client.GetToys(context.Background(), tc.in)
+			require.NoError(t, err)
+
+			names := make([]string, len(out.GetToys()))
+			for i, toyOut := range out.GetToys() {
+				names[i] = toyOut.GetName()
+			}
+
+			require.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestToysClient_AddToy(t *testing.T) {
+	client := NewToysClient(NewStore())
+
+	testCases := []struct {
+		name         string
+		in           *toys.AddToyIn
+		expectedCode codes.Code
+	}{
+		{
+			name: "success",
+			in: &toys.AddToyIn{
+				UserID:      1,
+				CategoryID:  1,
+				Name:        "New toy",
+				TagIDs:      []uint32{6},
+				Attachments: []string{"http://localhost:8090/toy.png"},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "user is not master",
+			in:           &toys.AddToyIn{UserID: 4, CategoryID: 1},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "unknown category",
+			in:           &toys.AddToyIn{UserID: 1, CategoryID: 100},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "unknown tag",
+			in:           &toys.AddToyIn{UserID: 1, CategoryID: 1, TagIDs: []uint32{100}},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := client.AddToy(context.Background(), tc.in)
+			require.Equal(t, tc.expectedCode, status.Code(err))
+
+			if tc.expectedCode != codes.OK {
+				return
+			}
+
+			toyOut, err := client.GetToy(context.Background(), &toys.GetToyIn{ID: out.GetToyID()})
+			require.NoError(t, err)
+			require.Equal(t, tc.in.GetName(), toyOut.GetName())
+			require.Len(t, toyOut.GetTags(), 1)
+			require.Len(t, toyOut.GetAttachments(), 1)
+		})
+	}
+}
+
+func TestToysClient_UpdateToy(t *testing.T) {
+	client := NewToysClient(NewStore())
+	limit := uint64(1)
+
+	seeded, err := client.GetToys(context.Background(), &toys.GetToysIn{Pagination: &toys.Pagination{Limit: &limit}})
+	require.NoError(t, err)
+
+	before := seeded.GetToys()[0]
+
+	keptLink := before.GetAttachments()[0].GetLink()
+	name := "Renamed toy"
+
+	_, err = client.UpdateToy(
+		context.Background(),
+		&toys.UpdateToyIn{
+			ID:          before.GetID(),
+			Name:        &name,
+			Attachments: []string{keptLink, "http://localhost:8090/new.png"},
+		},
+	)
+	require.NoError(t, err)
+
+	after, err := client.GetToy(context.Background(), &toys.GetToyIn{ID: before.GetID()})
+	require.NoError(t, err)
+	require.Equal(t, name, after.GetName())
+	require.Equal(t, before.GetDescription(), after.GetDescription())
+	require.Empty(t, after.GetTags())
+	require.Len(t, after.GetAttachments(), 2)
+	require.Equal(t, before.GetAttachments()[0].GetID(), after.GetAttachments()[0].GetID())
+
+	_, err = client.UpdateToy(context.Background(), &toys.UpdateToyIn{ID: 100500})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestToysClient_RegisterMaster(t *testing.T) {
+	client := NewToysClient(NewStore())
+
+	out, err := client.RegisterMaster(context.Background(), &toys.RegisterMasterIn{UserID: 4})
+	require.NoError(t, err)
+
+	master, err := client.GetMasterByUser(context.Background(), &toys.GetMasterByUserIn{UserID: 4})
+	require.NoError(t, err)
+	require.Equal(t, out.GetMasterID(), master.GetID())
+
+	_, err = client.RegisterMaster(context.Background(), &toys.RegisterMasterIn{UserID: 4})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestToysClient_CreateTags(t *testing.T) {
+	client := NewToysClient(NewStore())
+
+	out, err := client.CreateTags(
+		context.Background(),
+		&toys.CreateTagsIn{Tags: []*toys.CreateTagIn{{Name: "Gift"}, {Name: "new tag"}}},
+	)
+	require.NoError(t, err)
+	require.Len(t, out.GetTags(), 2)
+	require.Equal(t, uint32(6), out.GetTags()[0].GetID())
+	require.Equal(t, uint32(11), out.GetTags()[1].GetID())
+}
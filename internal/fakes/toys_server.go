@@ -0,0 +1,189 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-toys/api/protobuf/generated/go/toys"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RegisterToysServer registers gRPC services of Toys, which delegate calls to fake client, so that
+// real gRPC clients could be tested against in-process server.
+func RegisterToysServer(registrar grpc.ServiceRegistrar, client *ToysClient) {
+	toys.RegisterCategoriesServiceServer(registrar, &categoriesServer{client: client})
+	toys.RegisterToysServiceServer(registrar, &toysServer{client: client})
+	toys.RegisterTagsServiceServer(registrar, &tagsServer{client: client})
+	toys.RegisterMastersServiceServer(registrar, &mastersServer{client: client})
+}
+
+type categoriesServer struct {
+	toys.UnimplementedCategoriesServiceServer
+
+	client *ToysClient
+}
+
+func (s *categoriesServer) GetCategories(
+	ctx context.Context,
+	in *emptypb.Empty,
+) (*toys.GetCategoriesOut, error) {
+	return s.client.GetCategories(ctx, in)
+}
+
+func (s *categoriesServer) GetCategory(
+	ctx context.Context,
+	in *toys.GetCategoryIn,
+) (*toys.GetCategoryOut, error) {
+	return s.client.GetCategory(ctx, in)
+}
+
+type toysServer struct {
+	toys.UnimplementedToysServiceServer
+
+	client *ToysClient
+}
+
+func (s *toysServer) AddToy(
+	ctx context.Context,
+	in *toys.AddToyIn,
+) (*toys.AddToyOut, error) {
+	return s.client.AddToy(ctx, in)
+}
+
+func (s *toysServer) GetToys(
+	ctx context.Context,
+	in *toys.GetToysIn,
+) (*toys.GetToysOut, error) {
+	return s.client.GetToys(ctx, in)
+}
+
+func (s *toysServer) CountToys(
+	ctx context.Context,
+	in *toys.CountToysIn,
+) (*toys.CountOut, error) {
+	return s.client.CountToys(ctx, in)
+}
+
+func (s *toysServer) GetMasterToys(
+	ctx context.Context,
+	in *toys.GetMasterToysIn,
+) (*toys.GetToysOut, error) {
+	return s.client.GetMasterToys(ctx, in)
+}
+
+func (s *toysServer) CountMasterToys(
+	ctx context.Context,
+	in *toys.CountMasterToysIn,
+) (*toys.CountOut, error) {
+	return s.client.CountMasterToys(ctx, in)
+}
+
+func (s *toysServer) GetUserToys(
+	ctx context.Context,
+	in *toys.GetUserToysIn,
+) (*toys.GetToysOut, error) {
+	return s.client.GetUserToys(ctx, in)
+}
+
+func (s *toysServer) CountUserToys(
+	ctx context.Context,
+	in *toys.CountUserToysIn,
+) (*toys.CountOut, error) {
+	return s.client.CountUserToys(ctx, in)
+}
+
+func (s *toysServer) GetToy(
+	ctx context.Context,
+	in *toys.GetToyIn,
+) (*toys.GetToyOut, error) {
+	return s.client.GetToy(ctx, in)
+}
+
+func (s *toysServer) UpdateToy(
+	ctx context.Context,
+	in *toys.UpdateToyIn,
+) (*emptypb.Empty, error) {
+	return s.client.UpdateToy(ctx, in)
+}
+
+func (s *toysServer) DeleteToy(
+	ctx context.Context,
+	in *toys.DeleteToyIn,
+) (*emptypb.Empty, error) {
+	return s.client.DeleteToy(ctx, in)
+}
+
+type tagsServer struct {
+	toys.UnimplementedTagsServiceServer
+
+	client *ToysClient
+}
+
+func (s *tagsServer) GetTags(
+	ctx context.Context,
+	in *emptypb.Empty,
+) (*toys.GetTagsOut, error) {
+	return s.client.GetTags(ctx, in)
+}
+
+func (s *tagsServer) GetTag(
+	ctx context.Context,
+	in *toys.GetTagIn,
+) (*toys.GetTagOut, error) {
+	return s.client.GetTag(ctx, in)
+}
+
+func (s *tagsServer) CreateTags(
+	ctx context.Context,
+	in *toys.CreateTagsIn,
+) (*toys.CreateTagsOut, error) {
+	return s.client.CreateTags(ctx, in)
+}
+
+type mastersServer struct {
+	toys.UnimplementedMastersServiceServer
+
+	client *ToysClient
+}
+
+func (s *mastersServer) GetMasters(
+	ctx context.Context,
+	in *toys.GetMastersIn,
+) (*toys.GetMastersOut, error) {
+	return s.client.GetMasters(ctx, in)
+}
+
+func (s *mastersServer) CountMasters(
+	ctx context.Context,
+	in *toys.CountMastersIn,
+) (*toys.CountOut, error) {
+	return s.client.CountMasters(ctx, in)
+}
+
+func (s *mastersServer) GetMaster(
+	ctx context.Context,
+	in *toys.GetMasterIn,
+) (*toys.GetMasterOut, error) {
+	return s.client.GetMaster(ctx, in)
+}
+
+func (s *mastersServer) GetMasterByUser(
+	ctx context.Context,
+	in *toys.GetMasterByUserIn,
+) (*toys.GetMasterOut, error) {
+	return s.client.GetMasterByUser(ctx, in)
+}
+
+func (s *mastersServer) RegisterMaster(
+	ctx context.Context,
+	in *toys.RegisterMasterIn,
+) (*toys.RegisterMasterOut, error) {
+	return s.client.RegisterMaster(ctx, in)
+}
+
+func (s *mastersServer) UpdateMaster(
+	ctx context.Context,
+	in *toys.UpdateMasterIn,
+) (*emptypb.Empty, error) {
+	return s.client.UpdateMaster(ctx, in)
+}
@@ -2,21 +2,24 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/DKhorkov/hmtm-notifications/api/protobuf/generated/go/notifications"
 	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
 	"github.com/DKhorkov/hmtm-tickets/api/protobuf/generated/go/tickets"
 	"github.com/DKhorkov/hmtm-toys/api/protobuf/generated/go/toys"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/redis/go-redis/v9"
 )
 
-//go:generate mockgen -source=clients.go -destination=../../mocks/clients/sso_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,NotificationsClient,S3Client
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/sso_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 type SsoClient interface {
 	sso.AuthServiceClient
 	sso.UsersServiceClient
 }
 
-//go:generate mockgen -source=clients.go -destination=../../mocks/clients/toys_client.go -package=mockclients -exclude_interfaces=SsoClient,TicketsClient,NotificationsClient,S3Client
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/toys_client.go -package=mockclients -exclude_interfaces=SsoClient,TicketsClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 type ToysClient interface {
 	toys.CategoriesServiceClient
 	toys.ToysServiceClient
@@ -24,18 +27,18 @@ type ToysClient interface {
 	toys.MastersServiceClient
 }
 
-//go:generate mockgen -source=clients.go -destination=../../mocks/clients/tickets_client.go -package=mockclients -exclude_interfaces=ToysClient,SsoClient,NotificationsClient,S3Client
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/tickets_client.go -package=mockclients -exclude_interfaces=ToysClient,SsoClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 type TicketsClient interface {
 	tickets.TicketsServiceClient
 	tickets.RespondsServiceClient
 }
 
-//go:generate mockgen -source=clients.go -destination=../../mocks/clients/notifications_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,S3Client
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/notifications_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,S3Client,RedisClient,S3Presigner
 type NotificationsClient interface {
 	notifications.EmailsServiceClient
 }
 
-//go:generate mockgen -source=clients.go -destination=../../mocks/clients/s3_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,NotificationsClient
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/s3_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,NotificationsClient,RedisClient,S3Presigner
 type S3Client interface {
 	PutObject(
 		ctx context.Context,
@@ -60,4 +63,36 @@ type S3Client interface {
 		params *s3.HeadObjectInput,
 		optFns ...func(*s3.Options),
 	) (*s3.HeadObjectOutput, error)
+	ListObjectsV2(
+		ctx context.Context,
+		params *s3.ListObjectsV2Input,
+		optFns ...func(*s3.Options),
+	) (*s3.ListObjectsV2Output, error)
+	PutObjectAcl(
+		ctx context.Context,
+		params *s3.PutObjectAclInput,
+		optFns ...func(*s3.Options),
+	) (*s3.PutObjectAclOutput, error)
+}
+
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/s3_presigner.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,NotificationsClient,S3Client,RedisClient
+type S3Presigner interface {
+	PresignGetObject(
+		ctx context.Context,
+		params *s3.GetObjectInput,
+		optFns ...func(*s3.PresignOptions),
+	) (*v4.PresignedHTTPRequest, error)
+}
+
+//go:generate mockgen -source=clients.go -destination=../../mocks/clients/redis_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,SsoClient,NotificationsClient,S3Client,S3Presigner
+type RedisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	StrLen(ctx context.Context, key string) *redis.IntCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+	LPush(ctx context.Context, key string, values ...any) *redis.IntCmd
+	RPop(ctx context.Context, key string) *redis.StringCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 }
@@ -1,6 +1,14 @@
 package interfaces
 
+import "context"
+
 type Controller interface {
 	Run()
 	Stop()
 }
+
+// ReadinessCheck reports state of dependency in readiness output.
+type ReadinessCheck interface {
+	// Ready returns dependency state and whether requests could be served in this state.
+	Ready(ctx context.Context) (state string, ready bool)
+}
@@ -0,0 +1,6 @@
+package interfaces
+
+type Job interface {
+	Run()
+	Stop()
+}
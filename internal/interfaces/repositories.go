@@ -2,11 +2,12 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
 )
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/sso_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/sso_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 type SsoRepository interface {
 	GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error)
 	GetUserByID(ctx context.Context, id uint64) (*entities.User, error)
@@ -24,7 +25,7 @@ type SsoRepository interface {
 	UpdateUserProfile(ctx context.Context, userProfileData entities.UpdateUserProfileDTO) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/toys_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,FileStorageRepository,TicketsRepository,NotificationsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/toys_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 type ToysRepository interface {
 	AddToy(ctx context.Context, toyData entities.AddToyDTO) (toyID uint64, err error)
 	GetToys(ctx context.Context, pagination *entities.Pagination, filters *entities.ToysFilters) ([]entities.Toy, error)
@@ -66,14 +67,19 @@ type ToysRepository interface {
 	UpdateMaster(ctx context.Context, masterData entities.UpdateMasterDTO) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/file_storage_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,SsoRepository,TicketsRepository,NotificationsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/file_storage_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,SsoRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 type FileStorageRepository interface {
-	Upload(ctx context.Context, key string, file []byte) (string, error)
+	Upload(ctx context.Context, key string, file []byte, visibility entities.FileVisibility) (string, error)
 	Delete(ctx context.Context, key string) error
-	DeleteMany(ctx context.Context, keys []string) []error
+	DeleteMany(ctx context.Context, keys []string) []error // Returns DeleteFileError for each not deleted key
+	List(ctx context.Context) ([]entities.StoredFile, error)
+	// GetPresignedURL returns short-lived URL for getting private file.
+	GetPresignedURL(ctx context.Context, key string) (string, error)
+	// SetVisibility changes access to already uploaded file.
+	SetVisibility(ctx context.Context, key string, visibility entities.FileVisibility) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/tickets_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,SsoRepository,NotificationsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/tickets_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,SsoRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 type TicketsRepository interface {
 	CreateTicket(
 		ctx context.Context,
@@ -106,7 +112,7 @@ type TicketsRepository interface {
 	DeleteTicket(ctx context.Context, id uint64) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/notifications_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/notifications_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 type NotificationsRepository interface {
 	GetUserEmailCommunications(
 		ctx context.Context,
@@ -115,3 +121,61 @@ type NotificationsRepository interface {
 	) ([]entities.Email, error)
 	CountUserEmailCommunications(ctx context.Context, userID uint64) (uint64, error)
 }
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/locks_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository,NotificationsRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+type LocksRepository interface {
+	// Acquire tries to take lock with provided key. Returned token must be used for lock releasing.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token string, acquired bool, err error)
+	Release(ctx context.Context, key, token string) error
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/compensations_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository,NotificationsRepository,LocksRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+type CompensationsRepository interface {
+	SaveCompensation(ctx context.Context, compensation entities.Compensation) error
+	// PopCompensation returns nil without error, if there are no saved Compensations.
+	PopCompensation(ctx context.Context) (*entities.Compensation, error)
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/storage_usage_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+type StorageUsageRepository interface {
+	// Reserve accounts file size in User's storage usage, if it does not exceed provided limit.
+	// Overwritten is true, if file with the same key was already accounted.
+	Reserve(ctx context.Context, userID uint64, key string, size, limit int64) (reserved, overwritten bool, err error)
+	// Release removes files from storage usage of their owners.
+	Release(ctx context.Context, keys []string) error
+	GetUsedBytes(ctx context.Context, userID uint64) (int64, error)
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/uploads_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,CacheTagsRepository,LocalCacheRepository
+type UploadsRepository interface {
+	CreateUpload(ctx context.Context, upload entities.ResumableUpload) (string, error)
+	GetUpload(ctx context.Context, id string) (*entities.ResumableUpload, error)
+	// AppendChunk appends chunk to upload data only if offset matches already received data length
+	// and returns new offset.
+	AppendChunk(ctx context.Context, id string, offset int64, chunk []byte) (int64, error)
+	GetUploadData(ctx context.Context, id string) ([]byte, error)
+	// CompleteUpload saves link to assembled file and removes received data.
+	CompleteUpload(ctx context.Context, id, link string) error
+	DeleteUpload(ctx context.Context, id string) error
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/cache_tags_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,LocalCacheRepository
+type CacheTagsRepository interface {
+	// Tag attaches cache key to each of provided tags. Tag lives at least as long as tagged key.
+	// Key is not tagged and false is returned, if any of tags was invalidated after value had been
+	// loaded at loadedAt. Such value is stale and should not be cached.
+	Tag(ctx context.Context, key string, tags []string, ttl time.Duration, loadedAt time.Time) (bool, error)
+	// Invalidate deletes all cache keys, which were attached to provided tags, and tags themselves.
+	// Invalidated tags are published to all subscribers of Invalidations.
+	Invalidate(ctx context.Context, tags ...string) error
+	// Invalidations returns tags, invalidated by any BFF instance. Channel is closed after ctx is done.
+	Invalidations(ctx context.Context) <-chan []string
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/local_cache_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository
+type LocalCacheRepository interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, tags []string)
+	// Invalidate removes all keys, which were attached to any of provided tags.
+	Invalidate(tags ...string)
+}
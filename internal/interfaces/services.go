@@ -1,26 +1,54 @@
 package interfaces
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/sso_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,NotificationsService
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+)
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/sso_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,NotificationsService,CompensationsService,UploadsService
 type SsoService interface {
 	SsoRepository
 }
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/toys_service.go -package=mockservices -exclude_interfaces=SsoService,FileStorageService,TicketsService,NotificationsService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/toys_service.go -package=mockservices -exclude_interfaces=SsoService,FileStorageService,TicketsService,NotificationsService,CompensationsService,UploadsService
 type ToysService interface {
 	ToysRepository
 }
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/file_storage_service.go -package=mockservices -exclude_interfaces=ToysService,SsoService,TicketsService,NotificationsService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/file_storage_service.go -package=mockservices -exclude_interfaces=ToysService,SsoService,TicketsService,NotificationsService,CompensationsService,UploadsService
 type FileStorageService interface {
 	FileStorageRepository
+	// UploadUserFile uploads file only if it fits into User's storage quota and accounts its size.
+	// Overwritten is true, if User's file with the same key already existed and was replaced.
+	UploadUserFile(
+		ctx context.Context,
+		userID uint64,
+		limit int64,
+		key string,
+		file []byte,
+		visibility entities.FileVisibility,
+	) (url string, overwritten bool, err error)
+	GetUsedBytes(ctx context.Context, userID uint64) (int64, error)
 }
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/tickets_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,SsoService,NotificationsService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/tickets_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,SsoService,NotificationsService,CompensationsService,UploadsService
 type TicketsService interface {
 	TicketsRepository
 }
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/notifications_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/notifications_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService,CompensationsService,UploadsService
 type NotificationsService interface {
 	NotificationsRepository
 }
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/compensations_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService,NotificationsService,UploadsService
+type CompensationsService interface {
+	CompensationsRepository
+	ExecuteCompensation(ctx context.Context, compensation entities.Compensation) error
+}
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/uploads_service.go -package=mockservices -exclude_interfaces=SsoService,ToysService,FileStorageService,TicketsService,NotificationsService,CompensationsService
+type UploadsService interface {
+	UploadsRepository
+}
@@ -30,8 +30,30 @@ type UseCases interface {
 	) error
 
 	// Files cases:
-	UploadFile(ctx context.Context, userID uint64, files *graphql.Upload) (string, error)
-	UploadFiles(ctx context.Context, userID uint64, files []*graphql.Upload) ([]string, error)
+	UploadFile(
+		ctx context.Context,
+		userID uint64,
+		file *graphql.Upload,
+		visibility entities.FileVisibility,
+	) (string, error)
+	UploadFiles(
+		ctx context.Context,
+		userID uint64,
+		files []*graphql.Upload,
+		visibility entities.FileVisibility,
+	) ([]string, error)
+	GetUserStorageUsage(ctx context.Context, accessToken string, userID uint64) (*entities.StorageUsage, error)
+	// CreateUpload starts resumable upload of file, which will be received by chunks.
+	CreateUpload(ctx context.Context, accessToken, filename string, length int64) (*entities.ResumableUpload, error)
+	GetUpload(ctx context.Context, accessToken, id string) (*entities.ResumableUpload, error)
+	// WriteUploadChunk appends chunk to upload and saves file to storage after receiving last chunk.
+	WriteUploadChunk(
+		ctx context.Context,
+		accessToken string,
+		id string,
+		offset int64,
+		chunk []byte,
+	) (*entities.ResumableUpload, error)
 
 	// Toys cases:
 	AddToy(ctx context.Context, rawToyData entities.RawAddToyDTO) (toyID uint64, err error)
@@ -78,6 +100,11 @@ type UseCases interface {
 		rawTicketData entities.RawCreateTicketDTO,
 	) (ticketID uint64, err error)
 	GetTicketByID(ctx context.Context, id uint64) (*entities.Ticket, error)
+	GetTicketAttachments(
+		ctx context.Context,
+		ticket entities.Ticket,
+		accessToken string,
+	) ([]entities.TicketAttachment, error)
 	GetTickets(
 		ctx context.Context,
 		pagination *entities.Pagination,
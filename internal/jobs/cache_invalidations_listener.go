@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// CacheInvalidationsListener purges local cache tier by tags, which were invalidated by any
+// BFF instance, so that local tiers are consistent across cluster.
+type CacheInvalidationsListener struct {
+	*periodicJob
+
+	cacheTagsRepository  interfaces.CacheTagsRepository
+	localCacheRepository interfaces.LocalCacheRepository
+}
+
+func NewCacheInvalidationsListener(
+	cacheTagsRepository interfaces.CacheTagsRepository,
+	localCacheRepository interfaces.LocalCacheRepository,
+) *CacheInvalidationsListener {
+	return &CacheInvalidationsListener{
+		periodicJob:          newPeriodicJob(0),
+		cacheTagsRepository:  cacheTagsRepository,
+		localCacheRepository: localCacheRepository,
+	}
+}
+
+// Run listens for invalidations until Stop is called.
+func (listener *CacheInvalidationsListener) Run() {
+	if !listener.start() {
+		return
+	}
+
+	defer close(listener.done)
+
+	for tags := range listener.cacheTagsRepository.Invalidations(listener.ctx) {
+		listener.localCacheRepository.Invalidate(tags...)
+	}
+}
@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+)
+
+func TestCacheInvalidationsListener(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheTagsRepository := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	localCacheRepository := mockrepositories.NewMockLocalCacheRepository(ctrl)
+
+	invalidations := make(chan []string)
+	invalidated := make(chan struct{})
+
+	cacheTagsRepository.
+		EXPECT().
+		Invalidations(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) <-chan []string {
+			go func() {
+				<-ctx.Done()
+				close(invalidations)
+			}()
+
+			return invalidations
+		}).
+		Times(1)
+
+	localCacheRepository.
+		EXPECT().
+		Invalidate("toy:1", "toys:list").
+		Do(func(...string) { close(invalidated) }).
+		Times(1)
+
+	listener := NewCacheInvalidationsListener(cacheTagsRepository, localCacheRepository)
+	go listener.Run()
+
+	invalidations <- []string{"toy:1", "toys:list"}
+
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("local cache was not invalidated")
+	}
+
+	// Stop returns only after listener unsubscribed:
+	listener.Stop()
+	listener.Stop()
+}
@@ -0,0 +1,228 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	cacheWarmupResultSuccess        = "success"
+	cacheWarmupResultError          = "error"
+	cacheWarmupResultBudgetExceeded = "budget_exceeded"
+
+	// Readiness states of cache warmer:
+	cacheWarmupStateWarmingUp = "warming_up"
+	cacheWarmupStateWarmed    = "warmed"
+)
+
+var errCacheWarmupBudgetExceeded = errors.New("cache warmup budget exceeded")
+
+// CacheWarmer pre-populates cache with values, which are read by almost every visitor: categories,
+// tags, first pages of toys and tickets and their counters. Warmup is run on startup and, optionally,
+// every configured interval. Values are read through cached use cases, so that they are cached by
+// regular cache policies.
+type CacheWarmer struct {
+	*periodicJob
+
+	useCases interfaces.UseCases
+	config   config.CacheWarmupConfig
+	logger   logging.Logger
+	metrics  *cacheWarmupMetrics
+	warmed   chan struct{} // Is closed after startup warmup is finished or its budget is exceeded
+}
+
+func NewCacheWarmer(
+	useCases interfaces.UseCases,
+	config config.CacheWarmupConfig,
+	logger logging.Logger,
+	registerer prometheus.Registerer,
+) (*CacheWarmer, error) {
+	metrics, err := newCacheWarmupMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheWarmer{
+		periodicJob: newPeriodicJob(config.Interval),
+		useCases:    useCases,
+		config:      config,
+		logger:      logger,
+		metrics:     metrics,
+		warmed:      make(chan struct{}),
+	}, nil
+}
+
+// Run warms cache on startup and then every configured interval, until Stop is called.
+func (w *CacheWarmer) Run() {
+	if !w.start() {
+		return
+	}
+
+	defer close(w.done)
+
+	w.warmupAndLog(w.ctx)
+	close(w.warmed)
+
+	if w.config.Interval <= 0 {
+		<-w.ctx.Done()
+
+		return
+	}
+
+	w.tick(w.warmupAndLog)
+}
+
+// Ready reports, whether startup warmup is finished. Warmup never delays readiness longer than its budget.
+func (w *CacheWarmer) Ready(context.Context) (string, bool) {
+	select {
+	case <-w.warmed:
+		return cacheWarmupStateWarmed, true
+	default:
+		return cacheWarmupStateWarmingUp, false
+	}
+}
+
+func (w *CacheWarmer) warmupAndLog(ctx context.Context) {
+	if err := w.Warmup(ctx); err != nil {
+		logging.LogError(w.logger, "Cache warmup failed", err)
+	}
+}
+
+// Warmup reads values to be cached with bounded concurrency. Warmup is interrupted after budget is
+// exceeded: cached loads are not canceled by caller, so remaining reads are left to finish in background
+// instead of being waited for. Failure of single value does not interrupt warmup of others.
+func (w *CacheWarmer) Warmup(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Budget)
+	defer cancel()
+
+	start := time.Now()
+	failed := w.warmup(ctx)
+	w.metrics.duration.Observe(time.Since(start).Seconds())
+	w.metrics.failedTasks.Add(float64(failed))
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		w.metrics.runs.WithLabelValues(cacheWarmupResultBudgetExceeded).Inc()
+
+		return fmt.Errorf("%w: %s", errCacheWarmupBudgetExceeded, w.config.Budget)
+	case failed > 0:
+		w.metrics.runs.WithLabelValues(cacheWarmupResultError).Inc()
+
+		return fmt.Errorf("failed to warm %d cached values", failed)
+	default:
+		w.metrics.runs.WithLabelValues(cacheWarmupResultSuccess).Inc()
+		logging.LogInfo(w.logger, fmt.Sprintf("Cache warmup finished in %s", time.Since(start)))
+
+		return nil
+	}
+}
+
+// warmup returns number of values, which failed to be warmed before warmup was finished or its budget was
+// exceeded.
+func (w *CacheWarmer) warmup(ctx context.Context) int64 {
+	var failed atomic.Int64
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		w.warmValues(ctx, &failed)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	return failed.Load()
+}
+
+func (w *CacheWarmer) warmValues(ctx context.Context, failed *atomic.Int64) {
+	var group errgroup.Group
+
+	group.SetLimit(max(w.config.Concurrency, 1))
+
+	warm := func(name string, read func(ctx context.Context) error) {
+		// Values, which are not read yet, are skipped after budget is exceeded:
+		if ctx.Err() != nil {
+			return
+		}
+
+		group.Go(func() error {
+			if err := read(ctx); err != nil {
+				failed.Add(1)
+				logging.LogErrorContext(ctx, w.logger, fmt.Sprintf("Failed to warm cached %s", name), err)
+			}
+
+			// Error is not returned to continue warmup of other values:
+			return nil
+		})
+	}
+
+	// Categories are read before other values to warm each of them:
+	categories, err := w.useCases.GetAllCategories(ctx)
+	if err != nil {
+		failed.Add(1)
+		logging.LogErrorContext(ctx, w.logger, "Failed to warm cached categories", err)
+	}
+
+	for _, category := range categories {
+		warm(fmt.Sprintf("category with ID=%d", category.ID), func(ctx context.Context) error {
+			_, err := w.useCases.GetCategoryByID(ctx, category.ID)
+
+			return err
+		})
+	}
+
+	warm("tags", func(ctx context.Context) error {
+		_, err := w.useCases.GetAllTags(ctx)
+
+		return err
+	})
+
+	warm("toys counter", func(ctx context.Context) error {
+		_, err := w.useCases.CountToys(ctx, nil)
+
+		return err
+	})
+
+	warm("tickets counter", func(ctx context.Context) error {
+		_, err := w.useCases.CountTickets(ctx, nil)
+
+		return err
+	})
+
+	for page := range w.config.Pages {
+		pagination := &entities.Pagination{
+			Limit:  pointers.New(w.config.PageSize),
+			Offset: pointers.New(page * w.config.PageSize),
+		}
+
+		warm(fmt.Sprintf("toys page %d", page), func(ctx context.Context) error {
+			_, err := w.useCases.GetToys(ctx, pagination, nil)
+
+			return err
+		})
+
+		warm(fmt.Sprintf("tickets page %d", page), func(ctx context.Context) error {
+			_, err := w.useCases.GetTickets(ctx, pagination, nil)
+
+			return err
+		})
+	}
+
+	_ = group.Wait()
+}
@@ -0,0 +1,362 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
+)
+
+func TestNewCacheWarmer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	warmer, err := NewCacheWarmer(nil, config.CacheWarmupConfig{}, nil, registry)
+	require.NoError(t, err)
+	require.NotNil(t, warmer)
+
+	// Metrics could not be registered twice:
+	warmer, err = NewCacheWarmer(nil, config.CacheWarmupConfig{}, nil, registry)
+	require.Error(t, err)
+	require.Nil(t, warmer)
+}
+
+func TestCacheWarmer_Warmup(t *testing.T) {
+	warmupConfig := config.CacheWarmupConfig{
+		Budget:      time.Second,
+		Concurrency: 2,
+		Pages:       2,
+		PageSize:    10,
+	}
+
+	categories := []entities.Category{{ID: 1, Name: "First"}, {ID: 2, Name: "Second"}}
+	firstPage := &entities.Pagination{Limit: pointers.New[uint64](10), Offset: pointers.New[uint64](0)}
+	secondPage := &entities.Pagination{Limit: pointers.New[uint64](10), Offset: pointers.New[uint64](10)}
+
+	// release unblocks loads, which ignore cancellation, after all cases are finished:
+	release := make(chan struct{})
+	defer close(release)
+
+	// expectWarmup sets up reads of all values except categories:
+	expectWarmup := func(useCases *mockusecases.MockUseCases) {
+		for _, category := range categories {
+			useCases.
+				EXPECT().
+				GetCategoryByID(gomock.Any(), category.ID).
+				Return(&category, nil).
+				Times(1)
+		}
+
+		useCases.
+			EXPECT().
+			GetAllTags(gomock.Any()).
+			Return([]entities.Tag{{ID: 1, Name: "Tag"}}, nil).
+			Times(1)
+
+		useCases.
+			EXPECT().
+			CountToys(gomock.Any(), nil).
+			Return(uint64(15), nil).
+			Times(1)
+
+		useCases.
+			EXPECT().
+			CountTickets(gomock.Any(), nil).
+			Return(uint64(15), nil).
+			Times(1)
+
+		for _, page := range []*entities.Pagination{firstPage, secondPage} {
+			useCases.
+				EXPECT().
+				GetToys(gomock.Any(), page, nil).
+				Return([]entities.Toy{}, nil).
+				Times(1)
+
+			useCases.
+				EXPECT().
+				GetTickets(gomock.Any(), page, nil).
+				Return([]entities.Ticket{}, nil).
+				Times(1)
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		budget         time.Duration
+		setupMocks     func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedResult string
+		errorExpected  bool
+		expectedFailed float64
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetAllCategories(gomock.Any()).
+					Return(categories, nil).
+					Times(1)
+
+				expectWarmup(useCases)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any()).
+					Times(1)
+			},
+			expectedResult: cacheWarmupResultSuccess,
+		},
+		{
+			name: "categories error",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetAllCategories(gomock.Any()).
+					Return(nil, errors.New("categories error")).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetAllTags(gomock.Any()).
+					Return(nil, errors.New("tags error")).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					CountToys(gomock.Any(), nil).
+					Return(uint64(15), nil).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					CountTickets(gomock.Any(), nil).
+					Return(uint64(15), nil).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToys(gomock.Any(), gomock.Any(), nil).
+					Return([]entities.Toy{}, nil).
+					Times(2)
+
+				useCases.
+					EXPECT().
+					GetTickets(gomock.Any(), gomock.Any(), nil).
+					Return([]entities.Ticket{}, nil).
+					Times(2)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+			},
+			expectedResult: cacheWarmupResultError,
+			errorExpected:  true,
+			expectedFailed: 2,
+		},
+		{
+			name:   "budget exceeded",
+			budget: time.Millisecond * 10,
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				// Cached load is not canceled after budget is exceeded, and other values are not read:
+				useCases.
+					EXPECT().
+					GetAllCategories(gomock.Any()).
+					DoAndReturn(func(context.Context) ([]entities.Category, error) {
+						<-release
+
+						return categories, nil
+					}).
+					Times(1)
+			},
+			expectedResult: cacheWarmupResultBudgetExceeded,
+			errorExpected:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			useCases := mockusecases.NewMockUseCases(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			warmupConfig := warmupConfig
+			if tc.budget > 0 {
+				warmupConfig.Budget = tc.budget
+			}
+
+			warmer, err := NewCacheWarmer(useCases, warmupConfig, logger, prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			tc.setupMocks(useCases, logger)
+
+			err = warmer.Warmup(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, float64(1), testutil.ToFloat64(warmer.metrics.runs.WithLabelValues(tc.expectedResult)))
+			assert.Equal(t, tc.expectedFailed, testutil.ToFloat64(warmer.metrics.failedTasks))
+		})
+	}
+}
+
+func TestCacheWarmer_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	warmer, err := NewCacheWarmer(
+		useCases,
+		config.CacheWarmupConfig{Budget: time.Second},
+		logger,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+
+	useCases.
+		EXPECT().
+		GetAllCategories(gomock.Any()).
+		DoAndReturn(func(context.Context) ([]entities.Category, error) {
+			<-release
+
+			return []entities.Category{}, nil
+		}).
+		Times(1)
+
+	useCases.
+		EXPECT().
+		GetAllTags(gomock.Any()).
+		Return([]entities.Tag{}, nil).
+		Times(1)
+
+	useCases.
+		EXPECT().
+		CountToys(gomock.Any(), nil).
+		Return(uint64(0), nil).
+		Times(1)
+
+	useCases.
+		EXPECT().
+		CountTickets(gomock.Any(), nil).
+		Return(uint64(0), nil).
+		Times(1)
+
+	logger.
+		EXPECT().
+		Info(gomock.Any()).
+		Times(1)
+
+	go warmer.Run()
+
+	state, ready := warmer.Ready(context.Background())
+	assert.Equal(t, cacheWarmupStateWarmingUp, state)
+	assert.False(t, ready)
+
+	close(release)
+
+	require.Eventually(
+		t,
+		func() bool {
+			_, ready = warmer.Ready(context.Background())
+
+			return ready
+		},
+		time.Second,
+		time.Millisecond*10,
+	)
+
+	state, _ = warmer.Ready(context.Background())
+	assert.Equal(t, cacheWarmupStateWarmed, state)
+
+	// Stop returns, even if periodic warmup is disabled:
+	warmer.Stop()
+}
+
+func TestCacheWarmer_ReadyAfterBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	warmer, err := NewCacheWarmer(
+		useCases,
+		config.CacheWarmupConfig{Budget: time.Millisecond * 50, Concurrency: 1},
+		logger,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	useCases.
+		EXPECT().
+		GetAllCategories(gomock.Any()).
+		Return([]entities.Category{}, nil).
+		Times(1)
+
+	useCases.
+		EXPECT().
+		GetAllTags(gomock.Any()).
+		Return([]entities.Tag{}, nil).
+		Times(1)
+
+	useCases.
+		EXPECT().
+		CountToys(gomock.Any(), nil).
+		Return(uint64(0), nil).
+		Times(1)
+
+	// Cached load is not canceled after budget is exceeded:
+	useCases.
+		EXPECT().
+		CountTickets(gomock.Any(), nil).
+		DoAndReturn(func(context.Context, *entities.TicketsFilters) (uint64, error) {
+			<-release
+
+			return 0, nil
+		}).
+		Times(1)
+
+	logger.
+		EXPECT().
+		Error(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	go warmer.Run()
+
+	require.Eventually(
+		t,
+		func() bool {
+			_, ready := warmer.Ready(context.Background())
+
+			return ready
+		},
+		time.Second,
+		time.Millisecond*10,
+	)
+
+	assert.Equal(
+		t,
+		float64(1),
+		testutil.ToFloat64(warmer.metrics.runs.WithLabelValues(cacheWarmupResultBudgetExceeded)),
+	)
+
+	warmer.Stop()
+}
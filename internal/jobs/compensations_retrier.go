@@ -0,0 +1,202 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	compensationsResultSuccess = "success"
+	compensationsResultFailure = "failure"
+	compensationsResultDropped = "dropped"
+)
+
+// CompensationsRetrier periodically retries Compensations, which failed during saga rollback.
+type CompensationsRetrier struct {
+	*periodicJob
+
+	compensationsService interfaces.CompensationsService
+	fileStorageService   interfaces.FileStorageService
+	ssoService           interfaces.SsoService
+	toysService          interfaces.ToysService
+	ticketsService       interfaces.TicketsService
+	config               config.CompensationsConfig
+	logger               logging.Logger
+	metrics              *compensationsMetrics
+}
+
+func NewCompensationsRetrier(
+	compensationsService interfaces.CompensationsService,
+	fileStorageService interfaces.FileStorageService,
+	ssoService interfaces.SsoService,
+	toysService interfaces.ToysService,
+	ticketsService interfaces.TicketsService,
+	config config.CompensationsConfig,
+	logger logging.Logger,
+	registerer prometheus.Registerer,
+) (*CompensationsRetrier, error) {
+	metrics, err := newCompensationsMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompensationsRetrier{
+		periodicJob:          newPeriodicJob(config.RetryInterval),
+		compensationsService: compensationsService,
+		fileStorageService:   fileStorageService,
+		ssoService:           ssoService,
+		toysService:          toysService,
+		ticketsService:       ticketsService,
+		config:               config,
+		logger:               logger,
+		metrics:              metrics,
+	}, nil
+}
+
+// Run launches retrier, which is triggered every configured interval, until Stop is called.
+func (retrier *CompensationsRetrier) Run() {
+	retrier.run(func(ctx context.Context) {
+		if err := retrier.Retry(ctx); err != nil {
+			logging.LogError(retrier.logger, "Compensations retry failed", err)
+		}
+	})
+}
+
+// Retry executes no more than configured batch size of saved Compensations. Failed Compensations
+// are saved again, until max attempts are reached. Keys of files are derived from names of uploaded
+// files, so file could be uploaded again and used after saga failed. Such files are not deleted.
+func (retrier *CompensationsRetrier) Retry(ctx context.Context) error {
+	var files *unusedFiles
+
+	for range retrier.config.RetryBatchSize {
+		compensation, err := retrier.compensationsService.PopCompensation(ctx)
+		if err != nil {
+			return err
+		}
+
+		if compensation == nil {
+			return nil
+		}
+
+		if compensation.Kind == entities.CompensationKindDeleteFiles && len(compensation.FileKeys) > 0 {
+			// Files are checked once per retry, because all toys, tickets and users are fetched:
+			if files == nil {
+				if files, err = retrier.unusedFiles(ctx); err != nil {
+					// Compensation is kept for next retry. Error is logged by CompensationsService:
+					_ = retrier.compensationsService.SaveCompensation(ctx, *compensation)
+
+					return err
+				}
+			}
+
+			retrier.skipUsedFiles(compensation, files)
+		}
+
+		if err = retrier.compensationsService.ExecuteCompensation(ctx, *compensation); err == nil {
+			retrier.metrics.retries.WithLabelValues(compensationsResultSuccess).Inc()
+
+			continue
+		}
+
+		compensation.Attempts++
+		compensation.Error = err.Error()
+
+		if compensation.Attempts >= retrier.config.MaxAttempts {
+			retrier.metrics.retries.WithLabelValues(compensationsResultDropped).Inc()
+			logging.LogErrorContext(
+				ctx,
+				retrier.logger,
+				fmt.Sprintf("Dropping Compensation=%+v due to max attempts are reached", *compensation),
+				err,
+			)
+
+			continue
+		}
+
+		retrier.metrics.retries.WithLabelValues(compensationsResultFailure).Inc()
+
+		if err = retrier.compensationsService.SaveCompensation(ctx, *compensation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unusedFiles describes stored files, which are not used by any toy, ticket or user.
+type unusedFiles struct {
+	lastModified map[string]time.Time
+}
+
+func (retrier *CompensationsRetrier) unusedFiles(ctx context.Context) (*unusedFiles, error) {
+	referenced, err := collectReferencedKeys(
+		ctx,
+		retrier.config.PageSize,
+		retrier.ssoService,
+		retrier.toysService,
+		retrier.ticketsService,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are listed after references are collected, so that file uploaded again during collection is
+	// younger than Compensation:
+	stored, err := retrier.fileStorageService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &unusedFiles{lastModified: make(map[string]time.Time, len(stored))}
+
+	for _, file := range stored {
+		if _, ok := referenced[file.Key]; !ok {
+			files.lastModified[file.Key] = file.LastModified
+		}
+	}
+
+	return files, nil
+}
+
+// skipUsedFiles removes keys of files from Compensation, which are used, already deleted or were uploaded
+// again after Compensation was created.
+func (retrier *CompensationsRetrier) skipUsedFiles(
+	compensation *entities.Compensation,
+	files *unusedFiles,
+) {
+	keys := make([]string, 0, len(compensation.FileKeys))
+	skipped := make([]string, 0)
+
+	for _, key := range compensation.FileKeys {
+		lastModified, ok := files.lastModified[key]
+		if ok && !lastModified.After(compensation.CreatedAt) {
+			keys = append(keys, key)
+
+			continue
+		}
+
+		skipped = append(skipped, key)
+	}
+
+	if len(skipped) > 0 {
+		logging.LogInfo(
+			retrier.logger,
+			fmt.Sprintf(
+				"Skipping deletion of Files with keys=%s for step=%s of saga=%s, which are used, uploaded again or deleted",
+				skipped,
+				compensation.Step,
+				compensation.Saga,
+			),
+		)
+	}
+
+	compensation.FileKeys = keys
+}
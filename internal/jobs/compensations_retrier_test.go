@@ -0,0 +1,415 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
+)
+
+func TestNewCompensationsRetrier(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	compensationsConfig := config.CompensationsConfig{RetryInterval: time.Minute}
+
+	retrier, err := NewCompensationsRetrier(nil, nil, nil, nil, nil, compensationsConfig, nil, registry)
+	require.NoError(t, err)
+	require.NotNil(t, retrier)
+
+	// Metrics could not be registered twice:
+	retrier, err = NewCompensationsRetrier(nil, nil, nil, nil, nil, compensationsConfig, nil, registry)
+	require.Error(t, err)
+	require.Nil(t, retrier)
+}
+
+// expectNoReferences sets up services, which do not use any file.
+func expectNoReferences(
+	ssoService *mockservices.MockSsoService,
+	toysService *mockservices.MockToysService,
+	ticketsService *mockservices.MockTicketsService,
+) {
+	toysService.
+		EXPECT().
+		GetToys(gomock.Any(), gomock.Any(), nil).
+		Return(nil, nil).
+		Times(1)
+
+	ticketsService.
+		EXPECT().
+		GetTickets(gomock.Any(), gomock.Any(), nil).
+		Return(nil, nil).
+		Times(1)
+
+	ssoService.
+		EXPECT().
+		GetUsers(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+}
+
+func TestCompensationsRetrier_Retry(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	compensation := entities.Compensation{
+		Saga:      "AddToy",
+		Step:      "UploadFiles",
+		Kind:      entities.CompensationKindDeleteFiles,
+		FileKeys:  []string{"key"},
+		Attempts:  1,
+		CreatedAt: createdAt,
+	}
+
+	// Files of Compensation are checked once per retry:
+	expectUnusedFiles := func(
+		fileStorageService *mockservices.MockFileStorageService,
+		ssoService *mockservices.MockSsoService,
+		toysService *mockservices.MockToysService,
+		ticketsService *mockservices.MockTicketsService,
+	) {
+		expectNoReferences(ssoService, toysService, ticketsService)
+
+		fileStorageService.
+			EXPECT().
+			List(gomock.Any()).
+			Return([]entities.StoredFile{{Key: "key", LastModified: createdAt.Add(-time.Minute)}}, nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			compensationsService *mockservices.MockCompensationsService,
+			fileStorageService *mockservices.MockFileStorageService,
+			ssoService *mockservices.MockSsoService,
+			toysService *mockservices.MockToysService,
+			ticketsService *mockservices.MockTicketsService,
+			logger *mocklogging.MockLogger,
+		)
+		errorExpected bool
+	}{
+		{
+			name: "success until empty",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				expectUnusedFiles(fileStorageService, ssoService, toysService, ticketsService)
+
+				gomock.InOrder(
+					compensationsService.
+						EXPECT().
+						PopCompensation(gomock.Any()).
+						Return(&compensation, nil),
+					compensationsService.
+						EXPECT().
+						ExecuteCompensation(gomock.Any(), compensation).
+						Return(nil),
+					compensationsService.
+						EXPECT().
+						PopCompensation(gomock.Any()).
+						Return(nil, nil),
+				)
+			},
+		},
+		{
+			name: "failed compensation is saved again",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				failed := compensation
+				failed.Attempts = 2
+				failed.Error = "test error"
+
+				expectUnusedFiles(fileStorageService, ssoService, toysService, ticketsService)
+
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&entities.Compensation{
+						Saga:      compensation.Saga,
+						Step:      compensation.Step,
+						Kind:      compensation.Kind,
+						FileKeys:  compensation.FileKeys,
+						Attempts:  compensation.Attempts,
+						CreatedAt: compensation.CreatedAt,
+					}, nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), compensation).
+					Return(errors.New("test error")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(gomock.Any(), failed).
+					Return(nil).
+					Times(1)
+
+				// Batch size is reached:
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "used files are not deleted",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				logger *mocklogging.MockLogger,
+			) {
+				toysService.
+					EXPECT().
+					GetToys(gomock.Any(), gomock.Any(), nil).
+					DoAndReturn(
+						func(_ context.Context, pagination *entities.Pagination, _ *entities.ToysFilters) ([]entities.Toy, error) {
+							if *pagination.Offset > 0 {
+								return nil, nil
+							}
+
+							return []entities.Toy{
+								{Attachments: []entities.ToyAttachment{{Link: "https://bucket/used-key"}}},
+							}, nil
+						},
+					).
+					Times(2)
+
+				ticketsService.
+					EXPECT().
+					GetTickets(gomock.Any(), gomock.Any(), nil).
+					Return(nil, nil).
+					Times(1)
+
+				ssoService.
+					EXPECT().
+					GetUsers(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(
+						[]entities.StoredFile{
+							{Key: "key", LastModified: createdAt.Add(-time.Minute)},
+							{Key: "used-key", LastModified: createdAt.Add(-time.Minute)},
+							{Key: "uploaded-again-key", LastModified: createdAt.Add(time.Minute)},
+						},
+						nil,
+					).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&entities.Compensation{
+						Kind:      entities.CompensationKindDeleteFiles,
+						FileKeys:  []string{"key", "used-key", "uploaded-again-key", "deleted-key"},
+						CreatedAt: createdAt,
+					}, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any()).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Kind:      entities.CompensationKindDeleteFiles,
+							FileKeys:  []string{"key"},
+							CreatedAt: createdAt,
+						},
+					).
+					Return(nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "compensation is kept, when files could not be checked",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				_ *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&compensation, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetToys(gomock.Any(), gomock.Any(), nil).
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(gomock.Any(), compensation).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "compensation is dropped after max attempts",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockTicketsService,
+				logger *mocklogging.MockLogger,
+			) {
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&entities.Compensation{Kind: entities.CompensationKindDeleteFiles, Attempts: 2}, nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "pop error",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(nil, errors.New("test error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "save error",
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				compensationsService.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&entities.Compensation{Kind: entities.CompensationKindDeleteFiles}, nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("test error")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("test error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			compensationsService := mockservices.NewMockCompensationsService(ctrl)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			toysService := mockservices.NewMockToysService(ctrl)
+			ticketsService := mockservices.NewMockTicketsService(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			retrier, err := NewCompensationsRetrier(
+				compensationsService,
+				fileStorageService,
+				ssoService,
+				toysService,
+				ticketsService,
+				config.CompensationsConfig{
+					RetryInterval:  time.Minute,
+					RetryBatchSize: 2,
+					MaxAttempts:    3,
+					PageSize:       testPageSize,
+				},
+				logger,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(compensationsService, fileStorageService, ssoService, toysService, ticketsService, logger)
+			}
+
+			err = retrier.Retry(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	filesGCLockKey = "files_gc_lock"
+
+	// S3 DeleteObjects API accepts no more than 1000 keys per request:
+	filesGCDeleteBatchSize = 1000
+
+	filesGCResultSuccess = "success"
+	filesGCResultError   = "error"
+	filesGCResultSkipped = "skipped"
+)
+
+// FilesGarbageCollector periodically removes files from storage, which are not referenced
+// by any toy, ticket attachment or user avatar.
+type FilesGarbageCollector struct {
+	*periodicJob
+
+	fileStorageService interfaces.FileStorageService
+	ssoService         interfaces.SsoService
+	toysService        interfaces.ToysService
+	ticketsService     interfaces.TicketsService
+	locksRepository    interfaces.LocksRepository
+	config             config.FilesGCConfig
+	logger             logging.Logger
+	metrics            *filesGCMetrics
+}
+
+func NewFilesGarbageCollector(
+	fileStorageService interfaces.FileStorageService,
+	ssoService interfaces.SsoService,
+	toysService interfaces.ToysService,
+	ticketsService interfaces.TicketsService,
+	locksRepository interfaces.LocksRepository,
+	config config.FilesGCConfig,
+	logger logging.Logger,
+	registerer prometheus.Registerer,
+) (*FilesGarbageCollector, error) {
+	metrics, err := newFilesGCMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilesGarbageCollector{
+		periodicJob:        newPeriodicJob(config.Interval),
+		fileStorageService: fileStorageService,
+		ssoService:         ssoService,
+		toysService:        toysService,
+		ticketsService:     ticketsService,
+		locksRepository:    locksRepository,
+		config:             config,
+		logger:             logger,
+		metrics:            metrics,
+	}, nil
+}
+
+// Run launches collector, which is triggered every configured interval, until Stop is called.
+func (gc *FilesGarbageCollector) Run() {
+	gc.run(func(ctx context.Context) {
+		if err := gc.Collect(ctx); err != nil {
+			logging.LogError(gc.logger, "Files garbage collection failed", err)
+		}
+	})
+}
+
+// Collect performs single garbage collection pass. Only one pass across all instances
+// is processed at the same time due to distributed lock.
+func (gc *FilesGarbageCollector) Collect(ctx context.Context) error {
+	token, acquired, err := gc.locksRepository.Acquire(ctx, filesGCLockKey, gc.config.LockTTL)
+	if err != nil {
+		gc.metrics.runs.WithLabelValues(filesGCResultError).Inc()
+
+		return err
+	}
+
+	if !acquired {
+		gc.metrics.runs.WithLabelValues(filesGCResultSkipped).Inc()
+		logging.LogInfo(gc.logger, "Files garbage collection is already processing by another instance")
+
+		return nil
+	}
+
+	defer func() {
+		// Using separate context for releasing lock even if provided one was canceled:
+		if releaseErr := gc.locksRepository.Release(context.WithoutCancel(ctx), filesGCLockKey, token); releaseErr != nil {
+			logging.LogErrorContext(ctx, gc.logger, "Failed to release files garbage collection lock", releaseErr)
+		}
+	}()
+
+	// Lock is valid only during TTL, so collection should not last longer:
+	ctx, cancel := context.WithTimeout(ctx, gc.config.LockTTL)
+	defer cancel()
+
+	start := time.Now()
+	err = gc.collect(ctx)
+	gc.metrics.duration.Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		gc.metrics.runs.WithLabelValues(filesGCResultError).Inc()
+
+		return err
+	}
+
+	gc.metrics.runs.WithLabelValues(filesGCResultSuccess).Inc()
+
+	return nil
+}
+
+func (gc *FilesGarbageCollector) collect(ctx context.Context) error {
+	// Listing files before collecting references guarantees, that file uploaded during
+	// collection either is not listed or is younger than grace period:
+	files, err := gc.fileStorageService.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	gc.metrics.scanned.Add(float64(len(files)))
+
+	// Any error during references collection aborts run, because incomplete set of
+	// references will lead to deletion of files, which are still in use:
+	referenced, err := collectReferencedKeys(
+		ctx,
+		gc.config.PageSize,
+		gc.ssoService,
+		gc.toysService,
+		gc.ticketsService,
+	)
+	if err != nil {
+		return err
+	}
+
+	threshold := time.Now().Add(-gc.config.GracePeriod)
+	orphans := make([]string, 0)
+
+	for _, file := range files {
+		if _, ok := referenced[file.Key]; ok || file.LastModified.After(threshold) {
+			continue
+		}
+
+		orphans = append(orphans, file.Key)
+		gc.metrics.orphaned.Inc()
+		gc.metrics.orphanedBytes.Add(float64(file.Size))
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if gc.config.DryRun {
+		logging.LogInfo(
+			gc.logger,
+			fmt.Sprintf("Files garbage collection dry run found orphaned Files with keys=%s", orphans),
+		)
+
+		return nil
+	}
+
+	for start := 0; start < len(orphans); start += filesGCDeleteBatchSize {
+		batch := orphans[start:min(start+filesGCDeleteBatchSize, len(orphans))]
+		// Error is returned for each not deleted key, so other keys of batch are deleted:
+		deleteErrors := gc.fileStorageService.DeleteMany(ctx, batch)
+		gc.metrics.deleteErrors.Add(float64(len(deleteErrors)))
+		gc.metrics.deleted.Add(float64(len(batch) - len(deleteErrors)))
+	}
+
+	logging.LogInfo(
+		gc.logger,
+		fmt.Sprintf("Files garbage collection processed %d orphaned Files", len(orphans)),
+	)
+
+	return nil
+}
+
+// collectReferencedKeys returns storage keys of files, which are used by toys, ticket attachments and
+// user avatars.
+func collectReferencedKeys(
+	ctx context.Context,
+	pageSize uint64,
+	ssoService interfaces.SsoService,
+	toysService interfaces.ToysService,
+	ticketsService interfaces.TicketsService,
+) (map[string]struct{}, error) {
+	referenced := make(map[string]struct{})
+
+	err := paginate(
+		ctx,
+		pageSize,
+		func(ctx context.Context, pagination *entities.Pagination) ([]entities.Toy, error) {
+			return toysService.GetToys(ctx, pagination, nil)
+		},
+		func(toy entities.Toy) {
+			for _, attachment := range toy.Attachments {
+				referenced[entities.FileKeyFromLink(attachment.Link)] = struct{}{}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = paginate(
+		ctx,
+		pageSize,
+		func(ctx context.Context, pagination *entities.Pagination) ([]entities.RawTicket, error) {
+			return ticketsService.GetTickets(ctx, pagination, nil)
+		},
+		func(ticket entities.RawTicket) {
+			for _, attachment := range ticket.Attachments {
+				referenced[entities.FileKeyFromLink(attachment.Link)] = struct{}{}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = paginate(
+		ctx,
+		pageSize,
+		ssoService.GetUsers,
+		func(user entities.User) {
+			if user.Avatar != nil {
+				referenced[entities.FileKeyFromLink(*user.Avatar)] = struct{}{}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return referenced, nil
+}
+
+// paginate fetches pages until empty one is received and visits every fetched item.
+func paginate[T any](
+	ctx context.Context,
+	pageSize uint64,
+	fetch func(ctx context.Context, pagination *entities.Pagination) ([]T, error),
+	visit func(item T),
+) error {
+	var offset uint64
+
+	for {
+		limit, currentOffset := pageSize, offset
+		items, err := fetch(ctx, &entities.Pagination{Limit: &limit, Offset: &currentOffset})
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			visit(item)
+		}
+
+		offset += uint64(len(items))
+	}
+}
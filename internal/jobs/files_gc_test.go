@@ -0,0 +1,440 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/pointers"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
+)
+
+const (
+	testLockToken = "token"
+	testPageSize  = 2
+)
+
+func TestNewFilesGarbageCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	gc, err := NewFilesGarbageCollector(nil, nil, nil, nil, nil, config.FilesGCConfig{}, nil, registry)
+	require.NoError(t, err)
+	require.NotNil(t, gc)
+
+	// Metrics could not be registered twice:
+	gc, err = NewFilesGarbageCollector(nil, nil, nil, nil, nil, config.FilesGCConfig{}, nil, registry)
+	require.Error(t, err)
+	require.Nil(t, gc)
+}
+
+func TestFilesGarbageCollector_RunStop(t *testing.T) {
+	gc, err := NewFilesGarbageCollector(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		config.FilesGCConfig{Interval: time.Hour},
+		nil,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	go gc.Run()
+
+	stopped := make(chan struct{})
+	go func() {
+		gc.Stop()
+		gc.Stop() // Second call should not block
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("garbage collector was not stopped")
+	}
+}
+
+func TestFilesGarbageCollector_Collect(t *testing.T) {
+	oldTime := time.Now().Add(-48 * time.Hour)
+	newTime := time.Now()
+
+	setupReferences := func(
+		ssoService *mockservices.MockSsoService,
+		toysService *mockservices.MockToysService,
+		ticketsService *mockservices.MockTicketsService,
+	) {
+		toysService.
+			EXPECT().
+			GetToys(gomock.Any(), gomock.Any(), nil).
+			DoAndReturn(
+				func(_ context.Context, pagination *entities.Pagination, _ *entities.ToysFilters) ([]entities.Toy, error) {
+					if *pagination.Offset > 0 {
+						return nil, nil
+					}
+
+					return []entities.Toy{
+						{Attachments: []entities.ToyAttachment{{Link: "https://bucket/toy-file"}}},
+					}, nil
+				},
+			).
+			Times(2)
+
+		ticketsService.
+			EXPECT().
+			GetTickets(gomock.Any(), gomock.Any(), nil).
+			DoAndReturn(
+				func(_ context.Context, pagination *entities.Pagination, _ *entities.TicketsFilters) ([]entities.RawTicket, error) {
+					if *pagination.Offset > 0 {
+						return nil, nil
+					}
+
+					return []entities.RawTicket{
+						{Attachments: []entities.TicketAttachment{{Link: "https://bucket/ticket-file"}}},
+					}, nil
+				},
+			).
+			Times(2)
+
+		ssoService.
+			EXPECT().
+			GetUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(
+				func(_ context.Context, pagination *entities.Pagination) ([]entities.User, error) {
+					if *pagination.Offset > 0 {
+						return nil, nil
+					}
+
+					return []entities.User{
+						{Avatar: pointers.New("https://bucket/avatar-file")},
+						{},
+					}, nil
+				},
+			).
+			Times(2)
+	}
+
+	storedFiles := []entities.StoredFile{
+		{Key: "toy-file", LastModified: oldTime},
+		{Key: "ticket-file", LastModified: oldTime},
+		{Key: "avatar-file", LastModified: oldTime},
+		{Key: "orphaned-file", Size: 10, LastModified: oldTime},
+		{Key: "second-orphaned-file", Size: 20, LastModified: oldTime},
+		{Key: "new-orphaned-file", LastModified: newTime},
+	}
+
+	testCases := []struct {
+		name       string
+		dryRun     bool
+		setupMocks func(
+			fileStorageService *mockservices.MockFileStorageService,
+			ssoService *mockservices.MockSsoService,
+			toysService *mockservices.MockToysService,
+			ticketsService *mockservices.MockTicketsService,
+			locksRepository *mockrepositories.MockLocksRepository,
+			logger *mocklogging.MockLogger,
+		)
+		expectedDeleted      float64
+		expectedDeleteErrors float64
+		errorExpected        bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return(testLockToken, true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(storedFiles, nil).
+					Times(1)
+
+				setupReferences(ssoService, toysService, ticketsService)
+
+				fileStorageService.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"orphaned-file", "second-orphaned-file"}).
+					Return(nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				locksRepository.
+					EXPECT().
+					Release(gomock.Any(), filesGCLockKey, testLockToken).
+					Return(nil).
+					Times(1)
+			},
+			expectedDeleted: 2,
+		},
+		{
+			name: "partial deletion failure",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return(testLockToken, true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(storedFiles, nil).
+					Times(1)
+
+				setupReferences(ssoService, toysService, ticketsService)
+
+				fileStorageService.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"orphaned-file", "second-orphaned-file"}).
+					Return([]error{&customerrors.DeleteFileError{Message: "second-orphaned-file"}}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				locksRepository.
+					EXPECT().
+					Release(gomock.Any(), filesGCLockKey, testLockToken).
+					Return(nil).
+					Times(1)
+			},
+			expectedDeleted:      1,
+			expectedDeleteErrors: 1,
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return(testLockToken, true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(storedFiles, nil).
+					Times(1)
+
+				setupReferences(ssoService, toysService, ticketsService)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				locksRepository.
+					EXPECT().
+					Release(gomock.Any(), filesGCLockKey, testLockToken).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "lock is held by another instance",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return("", false, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+		},
+		{
+			name: "lock error",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return("", false, errors.New("test error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "list error",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return(testLockToken, true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				locksRepository.
+					EXPECT().
+					Release(gomock.Any(), filesGCLockKey, testLockToken).
+					Return(errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "references error aborts deletion",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				ticketsService *mockservices.MockTicketsService,
+				locksRepository *mockrepositories.MockLocksRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				locksRepository.
+					EXPECT().
+					Acquire(gomock.Any(), filesGCLockKey, time.Minute).
+					Return(testLockToken, true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					List(gomock.Any()).
+					Return(storedFiles, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetToys(gomock.Any(), gomock.Any(), nil).
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				locksRepository.
+					EXPECT().
+					Release(gomock.Any(), filesGCLockKey, testLockToken).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			toysService := mockservices.NewMockToysService(ctrl)
+			ticketsService := mockservices.NewMockTicketsService(ctrl)
+			locksRepository := mockrepositories.NewMockLocksRepository(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			gc, err := NewFilesGarbageCollector(
+				fileStorageService,
+				ssoService,
+				toysService,
+				ticketsService,
+				locksRepository,
+				config.FilesGCConfig{
+					DryRun:      tc.dryRun,
+					GracePeriod: 24 * time.Hour,
+					LockTTL:     time.Minute,
+					PageSize:    testPageSize,
+				},
+				logger,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					fileStorageService,
+					ssoService,
+					toysService,
+					ticketsService,
+					locksRepository,
+					logger,
+				)
+			}
+
+			err = gc.Collect(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedDeleted, testutil.ToFloat64(gc.metrics.deleted))
+			require.Equal(t, tc.expectedDeleteErrors, testutil.ToFloat64(gc.metrics.deleteErrors))
+		})
+	}
+}
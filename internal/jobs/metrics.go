@@ -0,0 +1,180 @@
+package jobs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace        = "hmtm_bff"
+	filesGCMetricsSubsystem = "files_gc"
+
+	compensationsMetricsSubsystem = "compensations"
+
+	cacheWarmupMetricsSubsystem = "cache_warmup"
+)
+
+type filesGCMetrics struct {
+	runs          *prometheus.CounterVec
+	duration      prometheus.Histogram
+	scanned       prometheus.Counter
+	orphaned      prometheus.Counter
+	orphanedBytes prometheus.Counter
+	deleted       prometheus.Counter
+	deleteErrors  prometheus.Counter
+}
+
+func newFilesGCMetrics(registerer prometheus.Registerer) (*filesGCMetrics, error) {
+	metrics := &filesGCMetrics{
+		runs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "runs_total",
+				Help:      "Number of files garbage collection runs by result.",
+			},
+			[]string{"result"},
+		),
+		duration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "run_duration_seconds",
+				Help:      "Duration of files garbage collection runs.",
+				Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8),
+			},
+		),
+		scanned: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "scanned_files_total",
+				Help:      "Number of files, listed from storage.",
+			},
+		),
+		orphaned: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "orphaned_files_total",
+				Help:      "Number of files, which are not referenced and are older than grace period.",
+			},
+		),
+		orphanedBytes: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "orphaned_bytes_total",
+				Help:      "Size of orphaned files in bytes.",
+			},
+		),
+		deleted: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "deleted_files_total",
+				Help:      "Number of deleted orphaned files.",
+			},
+		),
+		deleteErrors: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: filesGCMetricsSubsystem,
+				Name:      "delete_errors_total",
+				Help:      "Number of errors, occurred while deleting orphaned files.",
+			},
+		),
+	}
+
+	collectors := []prometheus.Collector{
+		metrics.runs,
+		metrics.duration,
+		metrics.scanned,
+		metrics.orphaned,
+		metrics.orphanedBytes,
+		metrics.deleted,
+		metrics.deleteErrors,
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return metrics, nil
+}
+
+type compensationsMetrics struct {
+	retries *prometheus.CounterVec
+}
+
+func newCompensationsMetrics(registerer prometheus.Registerer) (*compensationsMetrics, error) {
+	metrics := &compensationsMetrics{
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: compensationsMetricsSubsystem,
+				Name:      "retries_total",
+				Help:      "Number of saved compensations retries by result.",
+			},
+			[]string{"result"},
+		),
+	}
+
+	if err := registerer.Register(metrics.retries); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+type cacheWarmupMetrics struct {
+	runs        *prometheus.CounterVec
+	duration    prometheus.Histogram
+	failedTasks prometheus.Counter
+}
+
+func newCacheWarmupMetrics(registerer prometheus.Registerer) (*cacheWarmupMetrics, error) {
+	metrics := &cacheWarmupMetrics{
+		runs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheWarmupMetricsSubsystem,
+				Name:      "runs_total",
+				Help:      "Number of cache warmup runs by result.",
+			},
+			[]string{"result"},
+		),
+		duration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheWarmupMetricsSubsystem,
+				Name:      "run_duration_seconds",
+				Help:      "Duration of cache warmup runs.",
+				Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+			},
+		),
+		failedTasks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheWarmupMetricsSubsystem,
+				Name:      "failed_tasks_total",
+				Help:      "Number of values, which failed to be warmed.",
+			},
+		),
+	}
+
+	collectors := []prometheus.Collector{
+		metrics.runs,
+		metrics.duration,
+		metrics.failedTasks,
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// periodicJob runs action every interval until Stop is called.
+type periodicJob struct {
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func newPeriodicJob(interval time.Duration) *periodicJob {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &periodicJob{
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// start marks job as running. Job, which has started, must close done on return. False is returned,
+// if job was stopped before it started, so that it should not run at all.
+func (job *periodicJob) start() bool {
+	var started bool
+
+	job.startOnce.Do(func() {
+		started = true
+	})
+
+	return started
+}
+
+func (job *periodicJob) run(action func(ctx context.Context)) {
+	if !job.start() {
+		return
+	}
+
+	defer close(job.done)
+
+	job.tick(action)
+}
+
+// tick calls action every interval until Stop is called.
+func (job *periodicJob) tick(action func(ctx context.Context)) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-job.ctx.Done():
+			return
+		case <-ticker.C:
+			action(job.ctx)
+		}
+	}
+}
+
+// Stop cancels current action and waits for job to return. Job, which has not started yet, is not waited.
+func (job *periodicJob) Stop() {
+	job.stopOnce.Do(func() {
+		job.cancel()
+
+		// If job has not started, it would never close done, so done is closed here:
+		job.startOnce.Do(func() {
+			close(job.done)
+		})
+
+		<-job.done
+	})
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPeriodicJob_StopBeforeRun(t *testing.T) {
+	job := newPeriodicJob(time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		job.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("job, which has not started, was not stopped")
+	}
+
+	// Job, which was stopped before it started, does not run at all:
+	job.run(func(context.Context) {
+		t.Error("action of stopped job was called")
+	})
+}
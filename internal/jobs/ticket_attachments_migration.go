@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// TicketAttachmentsMigration makes attachments of Tickets, which were uploaded as public files before
+// attachments became private, available only via presigned URLs. Migration is run once on startup and
+// is idempotent, so that it could be run by several instances and repeated after failure.
+type TicketAttachmentsMigration struct {
+	*periodicJob
+
+	fileStorageService interfaces.FileStorageService
+	ticketsService     interfaces.TicketsService
+	config             config.MigrationsConfig
+	logger             logging.Logger
+}
+
+func NewTicketAttachmentsMigration(
+	fileStorageService interfaces.FileStorageService,
+	ticketsService interfaces.TicketsService,
+	config config.MigrationsConfig,
+	logger logging.Logger,
+) *TicketAttachmentsMigration {
+	return &TicketAttachmentsMigration{
+		periodicJob:        newPeriodicJob(0),
+		fileStorageService: fileStorageService,
+		ticketsService:     ticketsService,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+// Run migrates attachments and waits, until Stop is called.
+func (migration *TicketAttachmentsMigration) Run() {
+	if !migration.start() {
+		return
+	}
+
+	defer close(migration.done)
+
+	if err := migration.Migrate(migration.ctx); err != nil {
+		logging.LogError(migration.logger, "Ticket attachments migration failed", err)
+	}
+
+	<-migration.ctx.Done()
+}
+
+// Migrate makes attachments of all Tickets private. Failure of single attachment does not interrupt
+// migration of others.
+func (migration *TicketAttachmentsMigration) Migrate(ctx context.Context) error {
+	var migrated, failed int
+
+	err := paginate(
+		ctx,
+		migration.config.PageSize,
+		func(ctx context.Context, pagination *entities.Pagination) ([]entities.RawTicket, error) {
+			return migration.ticketsService.GetTickets(ctx, pagination, nil)
+		},
+		func(ticket entities.RawTicket) {
+			for _, attachment := range ticket.Attachments {
+				// Error is logged by FileStorageService:
+				err := migration.fileStorageService.SetVisibility(
+					ctx,
+					entities.FileKeyFromLink(attachment.Link),
+					entities.FileVisibilityPrivate,
+				)
+				if err != nil {
+					failed++
+
+					continue
+				}
+
+				migrated++
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to make %d of %d Ticket attachments private", failed, failed+migrated)
+	}
+
+	logging.LogInfo(
+		migration.logger,
+		fmt.Sprintf("Ticket attachments migration made %d Files private", migrated),
+	)
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
+)
+
+func TestTicketAttachmentsMigration_Migrate(t *testing.T) {
+	tickets := []entities.RawTicket{
+		{
+			Attachments: []entities.TicketAttachment{
+				{Link: "https://bucket/first-file"},
+				{Link: "https://bucket/second-file"},
+			},
+		},
+		{},
+	}
+
+	// expectTickets sets up single page of Tickets:
+	expectTickets := func(ticketsService *mockservices.MockTicketsService) {
+		ticketsService.
+			EXPECT().
+			GetTickets(gomock.Any(), gomock.Any(), nil).
+			DoAndReturn(
+				func(_ context.Context, pagination *entities.Pagination, _ *entities.TicketsFilters) ([]entities.RawTicket, error) {
+					if *pagination.Offset > 0 {
+						return nil, nil
+					}
+
+					return tickets, nil
+				},
+			).
+			Times(2)
+	}
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			fileStorageService *mockservices.MockFileStorageService,
+			ticketsService *mockservices.MockTicketsService,
+			logger *mocklogging.MockLogger,
+		)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ticketsService *mockservices.MockTicketsService,
+				logger *mocklogging.MockLogger,
+			) {
+				expectTickets(ticketsService)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), "first-file", entities.FileVisibilityPrivate).
+					Return(nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), "second-file", entities.FileVisibilityPrivate).
+					Return(nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Info(gomock.Any()).
+					Times(1)
+			},
+		},
+		{
+			name: "failed attachment does not interrupt migration",
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				ticketsService *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				expectTickets(ticketsService)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), "first-file", entities.FileVisibilityPrivate).
+					Return(errors.New("test error")).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), "second-file", entities.FileVisibilityPrivate).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "tickets error",
+			setupMocks: func(
+				_ *mockservices.MockFileStorageService,
+				ticketsService *mockservices.MockTicketsService,
+				_ *mocklogging.MockLogger,
+			) {
+				ticketsService.
+					EXPECT().
+					GetTickets(gomock.Any(), gomock.Any(), nil).
+					Return(nil, errors.New("test error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			ticketsService := mockservices.NewMockTicketsService(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			migration := NewTicketAttachmentsMigration(
+				fileStorageService,
+				ticketsService,
+				config.MigrationsConfig{PrivateTicketAttachments: true, PageSize: testPageSize},
+				logger,
+			)
+
+			tc.setupMocks(fileStorageService, ticketsService, logger)
+
+			err := migration.Migrate(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTicketAttachmentsMigration_RunStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ticketsService := mockservices.NewMockTicketsService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	ticketsService.
+		EXPECT().
+		GetTickets(gomock.Any(), gomock.Any(), nil).
+		Return(nil, nil).
+		Times(1)
+
+	migrated := make(chan struct{})
+	logger.
+		EXPECT().
+		Info(gomock.Any()).
+		Do(func(string, ...any) { close(migrated) }).
+		Times(1)
+
+	migration := NewTicketAttachmentsMigration(
+		nil,
+		ticketsService,
+		config.MigrationsConfig{PageSize: testPageSize},
+		logger,
+	)
+
+	go migration.Run()
+
+	select {
+	case <-migrated:
+	case <-time.After(time.Second):
+		t.Fatal("attachments were not migrated")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		migration.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("migration was not stopped")
+	}
+}
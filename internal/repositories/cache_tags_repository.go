@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	cacheTagKeyPrefix             = "cache_tags:"
+	cacheTagInvalidationKeyPrefix = "cache_tag_invalidations:"
+
+	// Time of tag invalidation is kept longer than any load of cached value could last:
+	cacheTagInvalidationTTL = 10 * time.Minute
+
+	// Tagged keys are deleted by batches, so that single command would not block Redis for long:
+	cacheTagsDeleteBatchSize = 1000
+
+	// Invalidated tags are published as space-separated list:
+	cacheInvalidationsChannel = "cache_invalidations"
+
+	// KEYS are tag sets followed by invalidation times of the same tags. Key is not tagged, if any of tags
+	// was invalidated after value had been loaded, which is ARGV[3] milliseconds ago by Redis clock.
+	// Tag set expiration is prolonged up to key's TTL, so that tag could not expire before tagged key:
+	tagCacheKeyScript = `
+local tags = #KEYS / 2
+local now = redis.call("TIME")
+local loadedAt = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000) - tonumber(ARGV[3])
+for i = tags + 1, #KEYS do
+	local invalidatedAt = redis.call("GET", KEYS[i])
+	if invalidatedAt and tonumber(invalidatedAt) >= loadedAt then
+		return 0
+	end
+end
+local ttl = tonumber(ARGV[2])
+for i = 1, tags do
+	redis.call("SADD", KEYS[i], ARGV[1])
+	if redis.call("PTTL", KEYS[i]) < ttl then
+		redis.call("PEXPIRE", KEYS[i], ttl)
+	end
+end
+return 1
+`
+
+	// KEYS are tag sets followed by invalidation times of the same tags. Invalidation time is remembered
+	// before tagged keys are returned, so that loads, which are still in progress, could not tag them again:
+	markCacheTagsInvalidatedScript = `
+local tags = #KEYS / 2
+local now = redis.call("TIME")
+local invalidatedAt = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+for i = tags + 1, #KEYS do
+	redis.call("SET", KEYS[i], invalidatedAt, "PX", ARGV[1])
+end
+return redis.call("SUNION", unpack(KEYS, 1, tags))
+`
+
+	// Tag sets are deleted after tagged keys. Notification is published in the same script, so it could
+	// not be lost after successful deletion:
+	invalidateCacheTagsScript = `
+redis.call("DEL", unpack(KEYS))
+redis.call("PUBLISH", ARGV[1], table.concat(ARGV, " ", 2))
+return 1
+`
+)
+
+// RedisCacheTagsRepository stores tags as Redis sets of cache keys. Invalidation by tag costs
+// O(number of tagged keys) instead of scanning whole keyspace by pattern. Scripts access only keys,
+// which are declared in KEYS, as required by ACL key permissions. Tagged keys are deleted by client.
+type RedisCacheTagsRepository struct {
+	client interfaces.RedisClient
+}
+
+func NewRedisCacheTagsRepository(client interfaces.RedisClient) *RedisCacheTagsRepository {
+	return &RedisCacheTagsRepository{client: client}
+}
+
+func (repo *RedisCacheTagsRepository) Tag(
+	ctx context.Context,
+	key string,
+	tags []string,
+	ttl time.Duration,
+	loadedAt time.Time,
+) (bool, error) {
+	if len(tags) == 0 {
+		return true, nil
+	}
+
+	// Load duration is passed instead of load time, so that clocks of BFF instances could differ from Redis:
+	tagged, err := repo.client.Eval(
+		ctx,
+		tagCacheKeyScript,
+		append(cacheTagKeys(tags), cacheTagInvalidationKeys(tags)...),
+		key,
+		ttl.Milliseconds(),
+		time.Since(loadedAt).Milliseconds(),
+	).Bool()
+
+	return tagged, err
+}
+
+func (repo *RedisCacheTagsRepository) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagKeys := cacheTagKeys(tags)
+
+	keys, err := repo.client.Eval(
+		ctx,
+		markCacheTagsInvalidatedScript,
+		append(tagKeys, cacheTagInvalidationKeys(tags)...),
+		cacheTagInvalidationTTL.Milliseconds(),
+	).StringSlice()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(keys); i += cacheTagsDeleteBatchSize {
+		if err = repo.client.Del(ctx, keys[i:min(i+cacheTagsDeleteBatchSize, len(keys))]...).Err(); err != nil {
+			return err
+		}
+	}
+
+	args := make([]any, 0, len(tags)+1)
+	args = append(args, cacheInvalidationsChannel)
+
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+
+	return repo.client.Eval(ctx, invalidateCacheTagsScript, tagKeys, args...).Err()
+}
+
+func (repo *RedisCacheTagsRepository) Invalidations(ctx context.Context) <-chan []string {
+	invalidations := make(chan []string)
+	pubSub := repo.client.Subscribe(ctx, cacheInvalidationsChannel)
+
+	go func() {
+		defer close(invalidations)
+		defer pubSub.Close()
+
+		// Channel of PubSub reconnects automatically and is closed only after PubSub is closed:
+		messages := pubSub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-messages:
+				select {
+				case <-ctx.Done():
+					return
+				case invalidations <- strings.Fields(message.Payload):
+				}
+			}
+		}
+	}()
+
+	return invalidations
+}
+
+func cacheTagKeys(tags []string) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, cacheTagKeyPrefix+tag)
+	}
+
+	return keys
+}
+
+func cacheTagInvalidationKeys(tags []string) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, cacheTagInvalidationKeyPrefix+tag)
+	}
+
+	return keys
+}
@@ -0,0 +1,339 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+func TestRedisCacheTagsRepository_Tag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisCacheTagsRepository(redisClient)
+
+	testCases := []struct {
+		name           string
+		tags           []string
+		setupMocks     func(redisClient *mockclients.MockRedisClient)
+		expectedTagged bool
+		errorExpected  bool
+	}{
+		{
+			name: "success",
+			tags: []string{"toy:1", "toys:list"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						tagCacheKeyScript,
+						[]string{
+							"cache_tags:toy:1",
+							"cache_tags:toys:list",
+							"cache_tag_invalidations:toy:1",
+							"cache_tag_invalidations:toys:list",
+						},
+						"key",
+						time.Hour.Milliseconds(),
+						gomock.Any(),
+					).
+					Return(redis.NewCmdResult(int64(1), nil)).
+					Times(1)
+			},
+			expectedTagged: true,
+		},
+		{
+			name:           "no tags",
+			expectedTagged: true,
+		},
+		{
+			name: "invalidated after load",
+			tags: []string{"toy:1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						tagCacheKeyScript,
+						[]string{"cache_tags:toy:1", "cache_tag_invalidations:toy:1"},
+						"key",
+						time.Hour.Milliseconds(),
+						gomock.Any(),
+					).
+					Return(redis.NewCmdResult(int64(0), nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			tags: []string{"toy:1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						tagCacheKeyScript,
+						[]string{"cache_tags:toy:1", "cache_tag_invalidations:toy:1"},
+						"key",
+						time.Hour.Milliseconds(),
+						gomock.Any(),
+					).
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			tagged, err := repo.Tag(context.Background(), "key", tc.tags, time.Hour, time.Now())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedTagged, tagged)
+		})
+	}
+}
+
+func TestRedisCacheTagsRepository_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisCacheTagsRepository(redisClient)
+
+	tagKeys := []string{"cache_tags:master:1", "cache_tags:masters:list"}
+	markKeys := []string{
+		"cache_tags:master:1",
+		"cache_tags:masters:list",
+		"cache_tag_invalidations:master:1",
+		"cache_tag_invalidations:masters:list",
+	}
+
+	taggedKeys := make([]any, 0, cacheTagsDeleteBatchSize+1)
+	for i := 0; i <= cacheTagsDeleteBatchSize; i++ {
+		taggedKeys = append(taggedKeys, fmt.Sprintf("masters:%d", i))
+	}
+
+	testCases := []struct {
+		name          string
+		tags          []string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			tags: []string{"master:1", "masters:list"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				gomock.InOrder(
+					redisClient.
+						EXPECT().
+						Eval(
+							gomock.Any(),
+							markCacheTagsInvalidatedScript,
+							markKeys,
+							cacheTagInvalidationTTL.Milliseconds(),
+						).
+						Return(redis.NewCmdResult([]any{"master:1", "masters:list:1"}, nil)).
+						Times(1),
+					redisClient.
+						EXPECT().
+						Del(gomock.Any(), "master:1", "masters:list:1").
+						Return(redis.NewIntResult(2, nil)).
+						Times(1),
+					redisClient.
+						EXPECT().
+						Eval(
+							gomock.Any(),
+							invalidateCacheTagsScript,
+							tagKeys,
+							cacheInvalidationsChannel,
+							"master:1",
+							"masters:list",
+						).
+						Return(redis.NewCmdResult(int64(1), nil)).
+						Times(1),
+				)
+			},
+		},
+		{
+			name: "tagged keys are deleted by batches",
+			tags: []string{"master:1", "masters:list"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						markCacheTagsInvalidatedScript,
+						markKeys,
+						cacheTagInvalidationTTL.Milliseconds(),
+					).
+					Return(redis.NewCmdResult(taggedKeys, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), taggedKeys[:cacheTagsDeleteBatchSize]...).
+					Return(redis.NewIntResult(cacheTagsDeleteBatchSize, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), taggedKeys[cacheTagsDeleteBatchSize:]...).
+					Return(redis.NewIntResult(1, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						invalidateCacheTagsScript,
+						tagKeys,
+						cacheInvalidationsChannel,
+						"master:1",
+						"masters:list",
+					).
+					Return(redis.NewCmdResult(int64(1), nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "no tags",
+		},
+		{
+			name: "mark error",
+			tags: []string{"master:1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						markCacheTagsInvalidatedScript,
+						[]string{"cache_tags:master:1", "cache_tag_invalidations:master:1"},
+						cacheTagInvalidationTTL.Milliseconds(),
+					).
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "delete error",
+			tags: []string{"master:1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						markCacheTagsInvalidatedScript,
+						[]string{"cache_tags:master:1", "cache_tag_invalidations:master:1"},
+						cacheTagInvalidationTTL.Milliseconds(),
+					).
+					Return(redis.NewCmdResult([]any{"master:1"}, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), "master:1").
+					Return(redis.NewIntResult(0, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "publish error",
+			tags: []string{"master:1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						markCacheTagsInvalidatedScript,
+						[]string{"cache_tags:master:1", "cache_tag_invalidations:master:1"},
+						cacheTagInvalidationTTL.Milliseconds(),
+					).
+					Return(redis.NewCmdResult([]any{}, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						invalidateCacheTagsScript,
+						[]string{"cache_tags:master:1"},
+						cacheInvalidationsChannel,
+						"master:1",
+					).
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.Invalidate(context.Background(), tc.tags...)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedisCacheTagsRepository_StaleLoad(t *testing.T) {
+	embeddedRedis := miniredis.RunT(t)
+	repo := NewRedisCacheTagsRepository(redis.NewClient(&redis.Options{Addr: embeddedRedis.Addr()}))
+	ctx := context.Background()
+
+	invalidatedAt := time.Now()
+	embeddedRedis.SetTime(invalidatedAt)
+
+	// Value, loaded before invalidation, is tagged and deleted by invalidation:
+	tagged, err := repo.Tag(ctx, "toys:1", []string{"toy:1", "toys:list"}, time.Hour, time.Now())
+	require.NoError(t, err)
+	require.True(t, tagged)
+	require.NoError(t, embeddedRedis.Set("toys:1", "value"))
+
+	require.NoError(t, repo.Invalidate(ctx, "toy:1"))
+	require.False(t, embeddedRedis.Exists("toys:1"))
+	require.False(t, embeddedRedis.Exists("cache_tags:toy:1"))
+
+	// The same load could not tag value again, because it had started before invalidation:
+	tagged, err = repo.Tag(ctx, "toys:1", []string{"toy:1", "toys:list"}, time.Hour, time.Now())
+	require.NoError(t, err)
+	require.False(t, tagged)
+	require.False(t, embeddedRedis.Exists("cache_tags:toy:1"))
+
+	// Load, which has started after invalidation, is tagged:
+	embeddedRedis.SetTime(invalidatedAt.Add(time.Second))
+
+	tagged, err = repo.Tag(ctx, "toys:1", []string{"toy:1"}, time.Hour, time.Now())
+	require.NoError(t, err)
+	require.True(t, tagged)
+
+	members, err := embeddedRedis.Members("cache_tags:toy:1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"toys:1"}, members)
+}
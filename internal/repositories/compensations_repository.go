@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const compensationsKey = "failed_compensations"
+
+type RedisCompensationsRepository struct {
+	client interfaces.RedisClient
+}
+
+func NewRedisCompensationsRepository(client interfaces.RedisClient) *RedisCompensationsRepository {
+	return &RedisCompensationsRepository{client: client}
+}
+
+func (repo *RedisCompensationsRepository) SaveCompensation(
+	ctx context.Context,
+	compensation entities.Compensation,
+) error {
+	data, err := json.Marshal(compensation)
+	if err != nil {
+		return err
+	}
+
+	return repo.client.LPush(ctx, compensationsKey, data).Err()
+}
+
+func (repo *RedisCompensationsRepository) PopCompensation(
+	ctx context.Context,
+) (*entities.Compensation, error) {
+	data, err := repo.client.RPop(ctx, compensationsKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var compensation entities.Compensation
+	if err = json.Unmarshal([]byte(data), &compensation); err != nil {
+		return nil, err
+	}
+
+	return &compensation, nil
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+func TestRedisCompensationsRepository_SaveCompensation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisCompensationsRepository(redisClient)
+
+	compensation := entities.Compensation{
+		Saga:      "AddToy",
+		Step:      "UploadFiles",
+		Kind:      entities.CompensationKindDeleteFiles,
+		FileKeys:  []string{"key"},
+		Attempts:  1,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(compensation)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					LPush(gomock.Any(), compensationsKey, data).
+					Return(redis.NewIntResult(1, nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					LPush(gomock.Any(), compensationsKey, data).
+					Return(redis.NewIntResult(0, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.SaveCompensation(context.Background(), compensation)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedisCompensationsRepository_PopCompensation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisCompensationsRepository(redisClient)
+
+	compensation := &entities.Compensation{
+		Saga:      "AddToy",
+		Step:      "UploadFiles",
+		Kind:      entities.CompensationKindDeleteFiles,
+		FileKeys:  []string{"key"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(compensation)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		expected      *entities.Compensation
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					RPop(gomock.Any(), compensationsKey).
+					Return(redis.NewStringResult(string(data), nil)).
+					Times(1)
+			},
+			expected: compensation,
+		},
+		{
+			name: "empty list",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					RPop(gomock.Any(), compensationsKey).
+					Return(redis.NewStringResult("", redis.Nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "invalid data",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					RPop(gomock.Any(), compensationsKey).
+					Return(redis.NewStringResult("invalid", nil)).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					RPop(gomock.Any(), compensationsKey).
+					Return(redis.NewStringResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			actual, err := repo.PopCompensation(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -14,13 +14,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 
 	appconfig "github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
 )
 
 type S3FileStorageRepository struct {
-	client   interfaces.S3Client
-	logger   logging.Logger
-	s3config appconfig.S3Config
+	client    interfaces.S3Client
+	presigner interfaces.S3Presigner
+	logger    logging.Logger
+	s3config  appconfig.S3Config
 }
 
 func NewS3FileStorageRepository(
@@ -49,17 +52,30 @@ func NewS3FileStorageRepository(
 	// Create an Amazon S3 service client
 	client := s3.NewFromConfig(cfg)
 
+	return NewS3FileStorageRepositoryFromClients(client, s3.NewPresignClient(client), s3config, logger), nil
+}
+
+// NewS3FileStorageRepositoryFromClients creates repository with already configured S3 clients,
+// for example with in-memory ones for tests.
+func NewS3FileStorageRepositoryFromClients(
+	client interfaces.S3Client,
+	presigner interfaces.S3Presigner,
+	s3config appconfig.S3Config,
+	logger logging.Logger,
+) *S3FileStorageRepository {
 	return &S3FileStorageRepository{
-		client:   client,
-		logger:   logger,
-		s3config: s3config,
-	}, nil
+		client:    client,
+		presigner: presigner,
+		logger:    logger,
+		s3config:  s3config,
+	}
 }
 
 func (repo *S3FileStorageRepository) Upload(
 	ctx context.Context,
 	key string,
 	file []byte,
+	visibility entities.FileVisibility,
 ) (string, error) {
 	_, err := repo.client.PutObject(
 		ctx,
@@ -67,7 +83,7 @@ func (repo *S3FileStorageRepository) Upload(
 			Bucket: aws.String(repo.s3config.Bucket),
 			Key:    aws.String(key),
 			Body:   bytes.NewReader(file),
-			ACL:    types.ObjectCannedACL(repo.s3config.ACL),
+			ACL:    repo.acl(visibility),
 		},
 	)
 	if err != nil {
@@ -108,7 +124,7 @@ func (repo *S3FileStorageRepository) Delete(ctx context.Context, key string) err
 	return nil
 }
 
-// DeleteMany deletes multiple files from S3.
+// DeleteMany deletes multiple files from S3 and returns DeleteFileError for each key, which was not deleted.
 // No empty keys should be provided in purpose not to receive error!
 // https://stackoverflow.com/questions/54093951/aws-s3-userkeymustbespecified-error-when-deleting-multiple-objects
 func (repo *S3FileStorageRepository) DeleteMany(ctx context.Context, keys []string) []error {
@@ -126,34 +142,119 @@ func (repo *S3FileStorageRepository) DeleteMany(ctx context.Context, keys []stri
 			},
 		},
 	)
+	if err != nil {
+		out := make([]error, 0, len(keys))
+		for _, key := range keys {
+			out = append(out, &customerrors.DeleteFileError{Message: key, BaseErr: err})
+		}
+
+		return out
+	}
 
 	var out []error
 
-	switch {
-	case err != nil:
-		out = append(out, err)
-	case len(delOut.Errors) > 0:
-		out = make([]error, 0, len(delOut.Errors))
-		for _, err := range delOut.Errors {
-			out = append(
-				out,
-				fmt.Errorf("%v-%v:%v-%v", err.VersionId, err.Code, err.Key, err.Message),
-			)
+	for _, deleteErr := range delOut.Errors {
+		out = append(
+			out,
+			&customerrors.DeleteFileError{
+				Message: aws.ToString(deleteErr.Key),
+				BaseErr: fmt.Errorf(
+					"%s-%s:%s",
+					aws.ToString(deleteErr.VersionId),
+					aws.ToString(deleteErr.Code),
+					aws.ToString(deleteErr.Message),
+				),
+			},
+		)
+	}
+
+	for _, delObj := range delOut.Deleted {
+		if err = s3.NewObjectNotExistsWaiter(repo.client).Wait(
+			ctx,
+			&s3.HeadObjectInput{
+				Bucket: aws.String(repo.s3config.Bucket),
+				Key:    delObj.Key,
+			},
+			repo.s3config.Timeout,
+		); err != nil {
+			out = append(out, &customerrors.DeleteFileError{Message: aws.ToString(delObj.Key), BaseErr: err})
 		}
-	default:
-		for _, delObj := range delOut.Deleted {
-			if err = s3.NewObjectNotExistsWaiter(repo.client).Wait(
-				ctx,
-				&s3.HeadObjectInput{
-					Bucket: aws.String(repo.s3config.Bucket),
-					Key:    delObj.Key,
+	}
+
+	return out
+}
+
+// List returns all files, which are stored in bucket.
+func (repo *S3FileStorageRepository) List(ctx context.Context) ([]entities.StoredFile, error) {
+	paginator := s3.NewListObjectsV2Paginator(
+		repo.client,
+		&s3.ListObjectsV2Input{
+			Bucket: aws.String(repo.s3config.Bucket),
+		},
+	)
+
+	var files []entities.StoredFile
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			files = append(
+				files,
+				entities.StoredFile{
+					Key:          aws.ToString(object.Key),
+					Size:         aws.ToInt64(object.Size),
+					LastModified: aws.ToTime(object.LastModified),
 				},
-				repo.s3config.Timeout,
-			); err != nil {
-				out = append(out, err)
-			}
+			)
 		}
 	}
 
-	return out
+	return files, nil
+}
+
+// GetPresignedURL returns URL for getting file, which expires after configured time.
+func (repo *S3FileStorageRepository) GetPresignedURL(ctx context.Context, key string) (string, error) {
+	request, err := repo.presigner.PresignGetObject(
+		ctx,
+		&s3.GetObjectInput{
+			Bucket: aws.String(repo.s3config.Bucket),
+			Key:    aws.String(key),
+		},
+		s3.WithPresignExpires(repo.s3config.PresignExpiry),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+// SetVisibility changes ACL of already uploaded file.
+func (repo *S3FileStorageRepository) SetVisibility(
+	ctx context.Context,
+	key string,
+	visibility entities.FileVisibility,
+) error {
+	_, err := repo.client.PutObjectAcl(
+		ctx,
+		&s3.PutObjectAclInput{
+			Bucket: aws.String(repo.s3config.Bucket),
+			Key:    aws.String(key),
+			ACL:    repo.acl(visibility),
+		},
+	)
+
+	return err
+}
+
+func (repo *S3FileStorageRepository) acl(visibility entities.FileVisibility) types.ObjectCannedACL {
+	if visibility == entities.FileVisibilityPrivate {
+		return types.ObjectCannedACL(repo.s3config.PrivateACL)
+	}
+
+	return types.ObjectCannedACL(repo.s3config.ACL)
 }
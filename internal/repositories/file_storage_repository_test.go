@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,8 @@ import (
 	"github.com/DKhorkov/libs/pointers"
 
 	appconfig "github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
 )
 
@@ -71,10 +74,11 @@ func TestS3FileStorageRepository_Upload(t *testing.T) {
 	s3Client := mockclients.NewMockS3Client(ctrl)
 
 	s3Config := appconfig.S3Config{
-		Bucket:  "test-bucket",
-		Region:  "us-east-1",
-		ACL:     "public-read",
-		Timeout: 5 * time.Second,
+		Bucket:     "test-bucket",
+		Region:     "us-east-1",
+		ACL:        "public-read",
+		PrivateACL: "private",
+		Timeout:    5 * time.Second,
 	}
 
 	repo := &S3FileStorageRepository{
@@ -87,14 +91,16 @@ func TestS3FileStorageRepository_Upload(t *testing.T) {
 		name          string
 		key           string
 		file          []byte
+		visibility    entities.FileVisibility
 		setupMocks    func(s3Client *mockclients.MockS3Client)
 		expectedURL   string
 		errorExpected bool
 	}{
 		{
-			name: "success",
-			key:  "test-key",
-			file: []byte("test content"),
+			name:       "success",
+			key:        "test-key",
+			file:       []byte("test content"),
+			visibility: entities.FileVisibilityPublic,
 			setupMocks: func(s3Client *mockclients.MockS3Client) {
 				s3Client.
 					EXPECT().
@@ -115,9 +121,34 @@ func TestS3FileStorageRepository_Upload(t *testing.T) {
 			errorExpected: false,
 		},
 		{
-			name: "upload error",
-			key:  "test-key",
-			file: []byte("test content"),
+			name:       "success private",
+			key:        "test-key",
+			file:       []byte("test content"),
+			visibility: entities.FileVisibilityPrivate,
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					PutObject(
+						gomock.Any(),
+						&s3.PutObjectInput{
+							Bucket: aws.String("test-bucket"),
+							Key:    aws.String("test-key"),
+							Body:   bytes.NewReader([]byte("test content")),
+							ACL:    "private",
+						},
+						gomock.Any(),
+					).
+					Return(&s3.PutObjectOutput{}, nil).
+					Times(1)
+			},
+			expectedURL:   "https://test-bucket.s3.us-east-1.amazonaws.com/test-key",
+			errorExpected: false,
+		},
+		{
+			name:       "upload error",
+			key:        "test-key",
+			file:       []byte("test content"),
+			visibility: entities.FileVisibilityPublic,
 			setupMocks: func(s3Client *mockclients.MockS3Client) {
 				s3Client.
 					EXPECT().
@@ -145,7 +176,7 @@ func TestS3FileStorageRepository_Upload(t *testing.T) {
 				tc.setupMocks(s3Client)
 			}
 
-			url, err := repo.Upload(context.Background(), tc.key, tc.file)
+			url, err := repo.Upload(context.Background(), tc.key, tc.file, tc.visibility)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.Empty(t, url)
@@ -410,6 +441,20 @@ func TestS3FileStorageRepository_DeleteMany(t *testing.T) {
 						},
 					}, nil).
 					Times(1)
+
+				// Deleted keys of partially failed batch are still awaited:
+				s3Client.
+					EXPECT().
+					HeadObject(
+						gomock.Any(),
+						&s3.HeadObjectInput{
+							Bucket: aws.String("test-bucket"),
+							Key:    pointers.New("key1"),
+						},
+						gomock.Any(),
+					).
+					Return(nil, &types.NotFound{}).
+					Times(1)
 			},
 			expectedErrors: 1,
 		},
@@ -464,6 +509,282 @@ func TestS3FileStorageRepository_DeleteMany(t *testing.T) {
 
 			errs := repo.DeleteMany(context.Background(), tc.keys)
 			require.Equal(t, tc.expectedErrors, len(errs))
+
+			for _, err := range errs {
+				var deleteFileError *customerrors.DeleteFileError
+				require.ErrorAs(t, err, &deleteFileError)
+			}
+		})
+	}
+}
+
+func TestS3FileStorageRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	s3Client := mockclients.NewMockS3Client(ctrl)
+
+	s3Config := appconfig.S3Config{
+		Bucket:  "test-bucket",
+		Region:  "us-east-1",
+		Timeout: 5 * time.Second,
+	}
+
+	repo := &S3FileStorageRepository{
+		client:   s3Client,
+		logger:   logger,
+		s3config: s3Config,
+	}
+
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(s3Client *mockclients.MockS3Client)
+		expected      []entities.StoredFile
+		errorExpected bool
+	}{
+		{
+			name: "success with multiple pages",
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					ListObjectsV2(
+						gomock.Any(),
+						&s3.ListObjectsV2Input{Bucket: aws.String("test-bucket")},
+						gomock.Any(),
+					).
+					Return(
+						&s3.ListObjectsV2Output{
+							Contents: []types.Object{
+								{Key: aws.String("key1"), Size: aws.Int64(10), LastModified: &now},
+							},
+							IsTruncated:           aws.Bool(true),
+							NextContinuationToken: aws.String("token"),
+						},
+						nil,
+					).
+					Times(1)
+
+				s3Client.
+					EXPECT().
+					ListObjectsV2(
+						gomock.Any(),
+						&s3.ListObjectsV2Input{
+							Bucket:            aws.String("test-bucket"),
+							ContinuationToken: aws.String("token"),
+						},
+						gomock.Any(),
+					).
+					Return(
+						&s3.ListObjectsV2Output{
+							Contents: []types.Object{
+								{Key: aws.String("key2"), Size: aws.Int64(20), LastModified: &now},
+							},
+							IsTruncated: aws.Bool(false),
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expected: []entities.StoredFile{
+				{Key: "key1", Size: 10, LastModified: now},
+				{Key: "key2", Size: 20, LastModified: now},
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					ListObjectsV2(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("list failed")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(s3Client)
+			}
+
+			files, err := repo.List(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+func TestS3FileStorageRepository_GetPresignedURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	presigner := mockclients.NewMockS3Presigner(ctrl)
+
+	repo := &S3FileStorageRepository{
+		presigner: presigner,
+		s3config: appconfig.S3Config{
+			Bucket:        "test-bucket",
+			PresignExpiry: time.Minute,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(presigner *mockclients.MockS3Presigner)
+		expected      string
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(presigner *mockclients.MockS3Presigner) {
+				presigner.
+					EXPECT().
+					PresignGetObject(
+						gomock.Any(),
+						&s3.GetObjectInput{
+							Bucket: aws.String("test-bucket"),
+							Key:    aws.String("test-key"),
+						},
+						gomock.Any(),
+					).
+					DoAndReturn(
+						func(
+							_ context.Context,
+							_ *s3.GetObjectInput,
+							optFns ...func(*s3.PresignOptions),
+						) (*v4.PresignedHTTPRequest, error) {
+							options := &s3.PresignOptions{}
+							for _, optFn := range optFns {
+								optFn(options)
+							}
+
+							require.Equal(t, time.Minute, options.Expires)
+
+							return &v4.PresignedHTTPRequest{URL: "https://presigned-url"}, nil
+						},
+					).
+					Times(1)
+			},
+			expected: "https://presigned-url",
+		},
+		{
+			name: "error",
+			setupMocks: func(presigner *mockclients.MockS3Presigner) {
+				presigner.
+					EXPECT().
+					PresignGetObject(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("presign failed")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(presigner)
+			}
+
+			url, err := repo.GetPresignedURL(context.Background(), "test-key")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, url)
+		})
+	}
+}
+
+func TestS3FileStorageRepository_SetVisibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s3Client := mockclients.NewMockS3Client(ctrl)
+
+	repo := &S3FileStorageRepository{
+		client: s3Client,
+		s3config: appconfig.S3Config{
+			Bucket:     "test-bucket",
+			ACL:        "public-read",
+			PrivateACL: "private",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		visibility    entities.FileVisibility
+		setupMocks    func(s3Client *mockclients.MockS3Client)
+		errorExpected bool
+	}{
+		{
+			name:       "public",
+			visibility: entities.FileVisibilityPublic,
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					PutObjectAcl(
+						gomock.Any(),
+						&s3.PutObjectAclInput{
+							Bucket: aws.String("test-bucket"),
+							Key:    aws.String("test-key"),
+							ACL:    types.ObjectCannedACL("public-read"),
+						},
+					).
+					Return(&s3.PutObjectAclOutput{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:       "private",
+			visibility: entities.FileVisibilityPrivate,
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					PutObjectAcl(
+						gomock.Any(),
+						&s3.PutObjectAclInput{
+							Bucket: aws.String("test-bucket"),
+							Key:    aws.String("test-key"),
+							ACL:    types.ObjectCannedACL("private"),
+						},
+					).
+					Return(&s3.PutObjectAclOutput{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:       "error",
+			visibility: entities.FileVisibilityPublic,
+			setupMocks: func(s3Client *mockclients.MockS3Client) {
+				s3Client.
+					EXPECT().
+					PutObjectAcl(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("acl failed")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(s3Client)
+			}
+
+			err := repo.SetVisibility(context.Background(), "test-key", tc.visibility)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
 		})
 	}
 }
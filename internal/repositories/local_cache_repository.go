@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+type localCacheEntry struct {
+	value []byte
+	tags  []string
+}
+
+// LRUCacheRepository keeps bounded number of recently used values in process memory. Short TTL
+// limits staleness of values, which invalidation broadcast was missed for.
+type LRUCacheRepository struct {
+	entries *expirable.LRU[string, localCacheEntry]
+}
+
+func NewLRUCacheRepository(size int, ttl time.Duration) *LRUCacheRepository {
+	return &LRUCacheRepository{
+		entries: expirable.NewLRU[string, localCacheEntry](size, nil, ttl),
+	}
+}
+
+func (repo *LRUCacheRepository) Get(key string) ([]byte, bool) {
+	entry, ok := repo.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (repo *LRUCacheRepository) Set(key string, value []byte, tags []string) {
+	repo.entries.Add(key, localCacheEntry{value: value, tags: tags})
+}
+
+// Invalidate scans all entries, which is acceptable, because local cache size is bounded and
+// invalidations are much rarer than reads.
+func (repo *LRUCacheRepository) Invalidate(tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	for _, key := range repo.entries.Keys() {
+		entry, ok := repo.entries.Peek(key)
+		if !ok {
+			continue
+		}
+
+		if slices.ContainsFunc(entry.tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			repo.entries.Remove(key)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCacheRepository_GetSet(t *testing.T) {
+	repo := NewLRUCacheRepository(2, time.Minute)
+
+	_, ok := repo.Get("key1")
+	require.False(t, ok)
+
+	repo.Set("key1", []byte("value1"), nil)
+	repo.Set("key2", []byte("value2"), nil)
+
+	value, ok := repo.Get("key1")
+	require.True(t, ok)
+	require.Equal(t, []byte("value1"), value)
+
+	// Least recently used key2 is evicted:
+	repo.Set("key3", []byte("value3"), nil)
+
+	_, ok = repo.Get("key2")
+	require.False(t, ok)
+
+	_, ok = repo.Get("key1")
+	require.True(t, ok)
+
+	_, ok = repo.Get("key3")
+	require.True(t, ok)
+}
+
+func TestLRUCacheRepository_Expiration(t *testing.T) {
+	repo := NewLRUCacheRepository(10, time.Millisecond*10)
+	repo.Set("key", []byte("value"), nil)
+
+	require.Eventually(
+		t,
+		func() bool {
+			_, ok := repo.Get("key")
+
+			return !ok
+		},
+		time.Second,
+		time.Millisecond*5,
+	)
+}
+
+func TestLRUCacheRepository_Invalidate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tags         []string
+		expectedKeys []string
+	}{
+		{
+			name:         "single tag",
+			tags:         []string{"toy:1"},
+			expectedKeys: []string{"get_categories", "get_toy_by_id:2"},
+		},
+		{
+			name:         "multiple tags",
+			tags:         []string{"toy:1", "categories:all"},
+			expectedKeys: []string{"get_toy_by_id:2"},
+		},
+		{
+			name:         "unknown tag",
+			tags:         []string{"toy:3"},
+			expectedKeys: []string{"get_categories", "get_toy_by_id:1", "get_toy_by_id:2"},
+		},
+		{
+			name:         "no tags",
+			expectedKeys: []string{"get_categories", "get_toy_by_id:1", "get_toy_by_id:2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewLRUCacheRepository(10, time.Minute)
+			repo.Set("get_categories", []byte("[]"), []string{"categories:all"})
+			repo.Set("get_toy_by_id:1", []byte("{}"), []string{"toy:1", "toys:list"})
+			repo.Set("get_toy_by_id:2", []byte("{}"), []string{"toy:2", "toys:list"})
+
+			repo.Invalidate(tc.tags...)
+
+			var keys []string
+			for _, key := range []string{"get_categories", "get_toy_by_id:1", "get_toy_by_id:2"} {
+				if _, ok := repo.Get(key); ok {
+					keys = append(keys, key)
+				}
+			}
+
+			require.Equal(t, tc.expectedKeys, keys)
+		})
+	}
+}
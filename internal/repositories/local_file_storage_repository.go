@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+)
+
+// LocalFileStorageRepository stores files on local disk instead of S3. It is used by fake mode of server,
+// so all files are served by static files server without any access control.
+type LocalFileStorageRepository struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalFileStorageRepository(dir, baseURL string) (*LocalFileStorageRepository, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalFileStorageRepository{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (repo *LocalFileStorageRepository) Upload(
+	_ context.Context,
+	key string,
+	file []byte,
+	_ entities.FileVisibility,
+) (string, error) {
+	path, err := repo.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+
+	if err = os.WriteFile(path, file, 0o600); err != nil {
+		return "", err
+	}
+
+	return repo.url(key), nil
+}
+
+// Delete does not fail for missing file, as S3 does not.
+func (repo *LocalFileStorageRepository) Delete(_ context.Context, key string) error {
+	path, err := repo.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *LocalFileStorageRepository) DeleteMany(ctx context.Context, keys []string) []error {
+	var out []error
+
+	for _, key := range keys {
+		if err := repo.Delete(ctx, key); err != nil {
+			out = append(out, &customerrors.DeleteFileError{Message: key, BaseErr: err})
+		}
+	}
+
+	return out
+}
+
+// List returns all stored files with keys, which use "/" separator, as S3 keys do.
+func (repo *LocalFileStorageRepository) List(_ context.Context) ([]entities.StoredFile, error) {
+	var files []entities.StoredFile
+
+	err := filepath.WalkDir(
+		repo.dir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			key, err := filepath.Rel(repo.dir, path)
+			if err != nil {
+				return err
+			}
+
+			files = append(
+				files,
+				entities.StoredFile{
+					Key:          filepath.ToSlash(key),
+					Size:         info.Size(),
+					LastModified: info.ModTime(),
+				},
+			)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// GetPresignedURL returns ordinary link, because local files are not protected.
+func (repo *LocalFileStorageRepository) GetPresignedURL(_ context.Context, key string) (string, error) {
+	if _, err := repo.stat(key); err != nil {
+		return "", err
+	}
+
+	return repo.url(key), nil
+}
+
+// SetVisibility only checks, that file exists, because local files are not protected.
+func (repo *LocalFileStorageRepository) SetVisibility(
+	_ context.Context,
+	key string,
+	_ entities.FileVisibility,
+) error {
+	_, err := repo.stat(key)
+
+	return err
+}
+
+func (repo *LocalFileStorageRepository) stat(key string) (fs.FileInfo, error) {
+	path, err := repo.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Stat(path)
+}
+
+// path returns location of file on disk. Keys, which point outside of storage directory, are rejected.
+func (repo *LocalFileStorageRepository) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid file key %q", key)
+	}
+
+	return filepath.Join(repo.dir, filepath.FromSlash(key)), nil
+}
+
+func (repo *LocalFileStorageRepository) url(key string) string {
+	return repo.baseURL + "/" + key
+}
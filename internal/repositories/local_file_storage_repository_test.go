@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+)
+
+func TestLocalFileStorageRepository(t *testing.T) {
+	repo, err := NewLocalFileStorageRepository(t.TempDir(), "http://localhost:8090/")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	link, err := repo.Upload(ctx, "toys/1/file.png", []byte("content"), entities.FileVisibilityPrivate)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8090/toys/1/file.png", link)
+
+	presignedURL, err := repo.GetPresignedURL(ctx, "toys/1/file.png")
+	require.NoError(t, err)
+	require.Equal(t, link, presignedURL)
+
+	files, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "toys/1/file.png", files[0].Key)
+	require.Equal(t, int64(len("content")), files[0].Size)
+
+	require.NoError(t, repo.SetVisibility(ctx, "toys/1/file.png", entities.FileVisibilityPublic))
+	require.Empty(t, repo.DeleteMany(ctx, []string{"toys/1/file.png", "missing.png"}))
+
+	files, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	require.Error(t, repo.SetVisibility(ctx, "toys/1/file.png", entities.FileVisibilityPublic))
+
+	_, err = repo.Upload(ctx, "../outside.png", []byte("content"), entities.FileVisibilityPublic)
+	require.Error(t, err)
+}
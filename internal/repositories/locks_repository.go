@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	lockTokenLength = 16
+
+	// Deletes lock only if it is still owned by the caller:
+	releaseLockScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+)
+
+type RedisLocksRepository struct {
+	client interfaces.RedisClient
+}
+
+func NewRedisLocksRepository(client interfaces.RedisClient) *RedisLocksRepository {
+	return &RedisLocksRepository{client: client}
+}
+
+func (repo *RedisLocksRepository) Acquire(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (string, bool, error) {
+	tokenBytes := make([]byte, lockTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", false, err
+	}
+
+	token := hex.EncodeToString(tokenBytes)
+
+	acquired, err := repo.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return "", false, err
+	}
+
+	if !acquired {
+		return "", false, nil
+	}
+
+	return token, true, nil
+}
+
+func (repo *RedisLocksRepository) Release(ctx context.Context, key, token string) error {
+	return repo.client.Eval(ctx, releaseLockScript, []string{key}, token).Err()
+}
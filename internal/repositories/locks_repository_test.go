@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+func TestRedisLocksRepository_Acquire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisLocksRepository(redisClient)
+
+	testCases := []struct {
+		name          string
+		key           string
+		ttl           time.Duration
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		acquired      bool
+		errorExpected bool
+	}{
+		{
+			name: "acquired",
+			key:  "lock",
+			ttl:  time.Minute,
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					SetNX(gomock.Any(), "lock", gomock.Any(), time.Minute).
+					Return(redis.NewBoolResult(true, nil)).
+					Times(1)
+			},
+			acquired: true,
+		},
+		{
+			name: "already locked",
+			key:  "lock",
+			ttl:  time.Minute,
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					SetNX(gomock.Any(), "lock", gomock.Any(), time.Minute).
+					Return(redis.NewBoolResult(false, nil)).
+					Times(1)
+			},
+			acquired: false,
+		},
+		{
+			name: "error",
+			key:  "lock",
+			ttl:  time.Minute,
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					SetNX(gomock.Any(), "lock", gomock.Any(), time.Minute).
+					Return(redis.NewBoolResult(false, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			token, acquired, err := repo.Acquire(context.Background(), tc.key, tc.ttl)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.acquired, acquired)
+			require.Equal(t, tc.acquired, token != "")
+		})
+	}
+}
+
+func TestRedisLocksRepository_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisLocksRepository(redisClient)
+
+	testCases := []struct {
+		name          string
+		key           string
+		token         string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name:  "success",
+			key:   "lock",
+			token: "token",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), releaseLockScript, []string{"lock"}, "token").
+					Return(redis.NewCmdResult(int64(1), nil)).
+					Times(1)
+			},
+		},
+		{
+			name:  "error",
+			key:   "lock",
+			token: "token",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), releaseLockScript, []string{"lock"}, "token").
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.Release(context.Background(), tc.key, tc.token)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -35,7 +35,7 @@ func (repo *NotificationsRepository) GetUserEmailCommunications(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	emailCommunications := make([]entities.Email, len(response.GetEmails()))
@@ -54,7 +54,7 @@ func (repo *NotificationsRepository) CountUserEmailCommunications(ctx context.Co
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetCount(), nil
@@ -30,7 +30,7 @@ func (repo *SsoRepository) RegisterUser(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetUserID(), nil
@@ -44,7 +44,7 @@ func (repo *SsoRepository) GetUserByID(ctx context.Context, id uint64) (*entitie
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processUserResponse(response), nil
@@ -61,7 +61,7 @@ func (repo *SsoRepository) GetUserByEmail(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processUserResponse(response), nil
@@ -81,7 +81,7 @@ func (repo *SsoRepository) GetUsers(ctx context.Context, pagination *entities.Pa
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	users := make([]entities.User, len(response.GetUsers()))
@@ -104,7 +104,7 @@ func (repo *SsoRepository) LoginUser(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return &entities.TokensDTO{
@@ -121,7 +121,7 @@ func (repo *SsoRepository) LogoutUser(ctx context.Context, accessToken string) e
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) VerifyUserEmail(ctx context.Context, verifyEmailToken string) error {
@@ -132,7 +132,7 @@ func (repo *SsoRepository) VerifyUserEmail(ctx context.Context, verifyEmailToken
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) ForgetPassword(ctx context.Context, forgetPasswordToken, newPassword string) error {
@@ -144,7 +144,7 @@ func (repo *SsoRepository) ForgetPassword(ctx context.Context, forgetPasswordTok
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) ChangePassword(
@@ -162,7 +162,7 @@ func (repo *SsoRepository) ChangePassword(
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) SendVerifyEmailMessage(ctx context.Context, email string) error {
@@ -173,7 +173,7 @@ func (repo *SsoRepository) SendVerifyEmailMessage(ctx context.Context, email str
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) SendForgetPasswordMessage(ctx context.Context, email string) error {
@@ -184,7 +184,7 @@ func (repo *SsoRepository) SendForgetPasswordMessage(ctx context.Context, email
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) GetMe(ctx context.Context, accessToken string) (*entities.User, error) {
@@ -195,7 +195,7 @@ func (repo *SsoRepository) GetMe(ctx context.Context, accessToken string) (*enti
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processUserResponse(response), nil
@@ -212,7 +212,7 @@ func (repo *SsoRepository) RefreshTokens(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return &entities.TokensDTO{
@@ -236,7 +236,7 @@ func (repo *SsoRepository) UpdateUserProfile(
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *SsoRepository) processUserResponse(userResponse *sso.GetUserOut) *entities.User {
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+)
+
+// translateError converts gRPC status of downstream service into domain error, keeping original error as base one.
+// Errors without gRPC status are returned as is. Codes, which have no domain meaning, are translated into
+// InternalError without status message, so gRPC code and internals of downstream service are not shown to client.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	message := st.Message()
+
+	switch st.Code() {
+	case codes.NotFound:
+		return &customerrors.NotFoundError{Message: message, BaseErr: err}
+	case codes.AlreadyExists:
+		return &customerrors.AlreadyExistsError{Message: message, BaseErr: err}
+	case codes.InvalidArgument:
+		return &customerrors.InvalidArgumentError{Message: withFieldViolations(message, st), BaseErr: err}
+	case codes.Unauthenticated:
+		return &customerrors.UnauthenticatedError{Message: message, BaseErr: err}
+	case codes.Unavailable:
+		return &customerrors.UnavailableError{Message: message, BaseErr: err}
+	case codes.PermissionDenied:
+		return &customerrors.AccessDeniedError{Message: message, BaseErr: err}
+	case codes.FailedPrecondition:
+		return &customerrors.FailedPreconditionError{Message: message, BaseErr: err}
+	case codes.DeadlineExceeded:
+		return &customerrors.DeadlineExceededError{BaseErr: err}
+	default:
+		return &customerrors.InternalError{BaseErr: err}
+	}
+}
+
+// withFieldViolations appends violations of BadRequest details to message, so client knows, which fields are invalid.
+func withFieldViolations(message string, st *status.Status) string {
+	var violations []string
+
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range badRequest.GetFieldViolations() {
+			violations = append(
+				violations,
+				fmt.Sprintf("%s: %s", violation.GetField(), violation.GetDescription()),
+			)
+		}
+	}
+
+	if len(violations) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf("%s (%s)", message, strings.Join(violations, "; "))
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DKhorkov/hmtm-toys/api/protobuf/generated/go/toys"
+
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+func TestTranslateError(t *testing.T) {
+	invalidArgumentStatus, err := status.
+		New(codes.InvalidArgument, "invalid toy").
+		WithDetails(
+			&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "name", Description: "must not be empty"},
+					{Field: "price", Description: "must be positive"},
+				},
+			},
+		)
+	require.NoError(t, err)
+
+	plainErr := errors.New("plain error")
+	upstreamErr := &customerrors.UpstreamUnavailableError{Message: "toys"}
+
+	testCases := []struct {
+		name            string
+		err             error
+		expected        error
+		expectedMessage string
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:            "not found",
+			err:             status.Error(codes.NotFound, "toy not found"),
+			expected:        &customerrors.NotFoundError{},
+			expectedMessage: "toy not found",
+		},
+		{
+			name:            "already exists",
+			err:             status.Error(codes.AlreadyExists, "user already exists"),
+			expected:        &customerrors.AlreadyExistsError{},
+			expectedMessage: "user already exists",
+		},
+		{
+			name:            "invalid argument",
+			err:             status.Error(codes.InvalidArgument, "invalid email"),
+			expected:        &customerrors.InvalidArgumentError{},
+			expectedMessage: "invalid email",
+		},
+		{
+			name:            "invalid argument with field violations",
+			err:             invalidArgumentStatus.Err(),
+			expected:        &customerrors.InvalidArgumentError{},
+			expectedMessage: "invalid toy (name: must not be empty; price: must be positive)",
+		},
+		{
+			name:            "unauthenticated",
+			err:             status.Error(codes.Unauthenticated, "token expired"),
+			expected:        &customerrors.UnauthenticatedError{},
+			expectedMessage: "token expired",
+		},
+		{
+			name:            "unavailable",
+			err:             status.Error(codes.Unavailable, "connection refused"),
+			expected:        &customerrors.UnavailableError{},
+			expectedMessage: "connection refused",
+		},
+		{
+			name:            "upstream is unavailable",
+			err:             upstreamErr,
+			expected:        &customerrors.UnavailableError{},
+			expectedMessage: "upstream toys is unavailable",
+		},
+		{
+			name:            "permission denied",
+			err:             status.Error(codes.PermissionDenied, "master can not respond to own ticket"),
+			expected:        &customerrors.AccessDeniedError{},
+			expectedMessage: "master can not respond to own ticket",
+		},
+		{
+			name:            "failed precondition",
+			err:             status.Error(codes.FailedPrecondition, "new password must differ from old one"),
+			expected:        &customerrors.FailedPreconditionError{},
+			expectedMessage: "new password must differ from old one",
+		},
+		{
+			name:            "deadline exceeded",
+			err:             status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			expected:        &customerrors.DeadlineExceededError{},
+			expectedMessage: "service did not respond in time",
+		},
+		{
+			name:            "internal",
+			err:             status.Error(codes.Internal, "pq: relation \"toys\" does not exist"),
+			expected:        &customerrors.InternalError{},
+			expectedMessage: "internal error",
+		},
+		{
+			name:            "code without domain meaning",
+			err:             status.Error(codes.Unimplemented, "unknown method GetToy"),
+			expected:        &customerrors.InternalError{},
+			expectedMessage: "internal error",
+		},
+		{
+			name:            "without gRPC status",
+			err:             plainErr,
+			expected:        plainErr,
+			expectedMessage: plainErr.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := translateError(tc.err)
+			if tc.expected == nil {
+				require.NoError(t, actual)
+
+				return
+			}
+
+			require.IsType(t, tc.expected, actual)
+			require.EqualError(t, actual, tc.expectedMessage)
+			require.ErrorIs(t, actual, tc.err)
+		})
+	}
+}
+
+func TestToysRepository_TranslatesErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	toysClient := mockclients.NewMockToysClient(ctrl)
+	repo := NewToysRepository(toysClient)
+
+	toysClient.
+		EXPECT().
+		GetToy(gomock.Any(), &toys.GetToyIn{ID: 1}).
+		Return(nil, status.Error(codes.NotFound, "toy with id=1 not found")).
+		Times(1)
+
+	toy, err := repo.GetToyByID(context.Background(), 1)
+	require.Nil(t, toy)
+
+	var notFoundErr *customerrors.NotFoundError
+	require.ErrorAs(t, err, &notFoundErr)
+	require.Equal(t, "toy with id=1 not found", notFoundErr.Message)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	storageUsageKeyPrefix = "storage_usage:"
+
+	// Hash with owner and size of every accounted file in format "<userID>:<size>":
+	storageFilesKey = "storage_usage_files"
+
+	// Accounts file size only if User's usage with new file does not exceed limit. Returns 0, if limit is exceeded,
+	// 1 for new file and 2 for overwritten file with the same key, which size is not counted twice:
+	reserveStorageScript = `
+local used = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = 0
+local entry = redis.call("HGET", KEYS[2], ARGV[1])
+if entry then
+	local _, size = string.match(entry, "(%d+):(%d+)")
+	previous = tonumber(size)
+end
+local size = tonumber(ARGV[2])
+if used - previous + size > tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("INCRBY", KEYS[1], size - previous)
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[4] .. ":" .. ARGV[2])
+if entry then
+	return 2
+end
+return 1
+`
+
+	// Removes files from accounting and decreases usage of their owners:
+	releaseStorageScript = `
+for _, key in ipairs(ARGV) do
+	local entry = redis.call("HGET", KEYS[1], key)
+	if entry then
+		local owner, size = string.match(entry, "(%d+):(%d+)")
+		redis.call("DECRBY", "` + storageUsageKeyPrefix + `" .. owner, size)
+		redis.call("HDEL", KEYS[1], key)
+	end
+end
+return 1
+`
+)
+
+type RedisStorageUsageRepository struct {
+	client interfaces.RedisClient
+}
+
+func NewRedisStorageUsageRepository(client interfaces.RedisClient) *RedisStorageUsageRepository {
+	return &RedisStorageUsageRepository{client: client}
+}
+
+func (repo *RedisStorageUsageRepository) Reserve(
+	ctx context.Context,
+	userID uint64,
+	key string,
+	size int64,
+	limit int64,
+) (bool, bool, error) {
+	result, err := repo.client.Eval(
+		ctx,
+		reserveStorageScript,
+		[]string{storageUsageKey(userID), storageFilesKey},
+		key,
+		size,
+		limit,
+		userID,
+	).Int()
+	if err != nil {
+		return false, false, err
+	}
+
+	return result != 0, result == 2, nil
+}
+
+func (repo *RedisStorageUsageRepository) Release(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+
+	return repo.client.Eval(ctx, releaseStorageScript, []string{storageFilesKey}, args...).Err()
+}
+
+func (repo *RedisStorageUsageRepository) GetUsedBytes(ctx context.Context, userID uint64) (int64, error) {
+	used, err := repo.client.Get(ctx, storageUsageKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return used, err
+}
+
+func storageUsageKey(userID uint64) string {
+	return storageUsageKeyPrefix + strconv.FormatUint(userID, 10)
+}
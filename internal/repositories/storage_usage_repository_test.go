@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+func TestRedisStorageUsageRepository_Reserve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisStorageUsageRepository(redisClient)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		reserved      bool
+		overwritten   bool
+		errorExpected bool
+	}{
+		{
+			name: "reserved",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						reserveStorageScript,
+						[]string{"storage_usage:1", storageFilesKey},
+						"key",
+						int64(10),
+						int64(100),
+						uint64(1),
+					).
+					Return(redis.NewCmdResult(int64(1), nil)).
+					Times(1)
+			},
+			reserved: true,
+		},
+		{
+			name: "overwritten",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), reserveStorageScript, gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(int64(2), nil)).
+					Times(1)
+			},
+			reserved:    true,
+			overwritten: true,
+		},
+		{
+			name: "quota exceeded",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), reserveStorageScript, gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(int64(0), nil)).
+					Times(1)
+			},
+			reserved: false,
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), reserveStorageScript, gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			reserved, overwritten, err := repo.Reserve(context.Background(), 1, "key", 10, 100)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.reserved, reserved)
+			require.Equal(t, tc.overwritten, overwritten)
+		})
+	}
+}
+
+func TestRedisStorageUsageRepository_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisStorageUsageRepository(redisClient)
+
+	testCases := []struct {
+		name          string
+		keys          []string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			keys: []string{"key1", "key2"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), releaseStorageScript, []string{storageFilesKey}, "key1", "key2").
+					Return(redis.NewCmdResult(int64(1), nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "no keys",
+		},
+		{
+			name: "error",
+			keys: []string{"key1"},
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), releaseStorageScript, []string{storageFilesKey}, "key1").
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.Release(context.Background(), tc.keys)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedisStorageUsageRepository_GetUsedBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisStorageUsageRepository(redisClient)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		expected      int64
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "storage_usage:1").
+					Return(redis.NewStringResult("42", nil)).
+					Times(1)
+			},
+			expected: 42,
+		},
+		{
+			name: "no usage",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "storage_usage:1").
+					Return(redis.NewStringResult("", redis.Nil)).
+					Times(1)
+			},
+			expected: 0,
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "storage_usage:1").
+					Return(redis.NewStringResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			used, err := repo.GetUsedBytes(context.Background(), 1)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, used)
+		})
+	}
+}
@@ -35,7 +35,7 @@ func (repo *TicketsRepository) CreateTicket(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetTicketID(), nil
@@ -52,7 +52,7 @@ func (repo *TicketsRepository) GetTicketByID(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processTicketResponse(response), nil
@@ -88,7 +88,7 @@ func (repo *TicketsRepository) GetTickets(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	allTickets := make([]entities.RawTicket, len(response.GetTickets()))
@@ -130,7 +130,7 @@ func (repo *TicketsRepository) GetUserTickets(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	userTickets := make([]entities.RawTicket, len(response.GetTickets()))
@@ -160,7 +160,7 @@ func (repo *TicketsRepository) CountTickets(ctx context.Context, filters *entiti
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -189,7 +189,7 @@ func (repo *TicketsRepository) CountUserTickets(
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -209,7 +209,7 @@ func (repo *TicketsRepository) RespondToTicket(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetRespondID(), nil
@@ -226,7 +226,7 @@ func (repo *TicketsRepository) GetRespondByID(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processRespondResponse(response), nil
@@ -243,7 +243,7 @@ func (repo *TicketsRepository) GetTicketResponds(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	ticketResponds := make([]entities.Respond, len(response.GetResponds()))
@@ -265,7 +265,7 @@ func (repo *TicketsRepository) GetUserResponds(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	userResponds := make([]entities.Respond, len(response.GetResponds()))
@@ -289,7 +289,7 @@ func (repo *TicketsRepository) UpdateRespond(
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *TicketsRepository) DeleteRespond(ctx context.Context, id uint64) error {
@@ -300,7 +300,7 @@ func (repo *TicketsRepository) DeleteRespond(ctx context.Context, id uint64) err
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *TicketsRepository) UpdateTicket(
@@ -321,7 +321,7 @@ func (repo *TicketsRepository) UpdateTicket(
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *TicketsRepository) DeleteTicket(ctx context.Context, id uint64) error {
@@ -332,7 +332,7 @@ func (repo *TicketsRepository) DeleteTicket(ctx context.Context, id uint64) erro
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *TicketsRepository) processRespondResponse(
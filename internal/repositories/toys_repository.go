@@ -36,7 +36,7 @@ func (repo *ToysRepository) AddToy(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetToyID(), nil
@@ -72,7 +72,7 @@ func (repo *ToysRepository) GetToys(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	allToys := make([]entities.Toy, len(response.GetToys()))
@@ -102,7 +102,7 @@ func (repo *ToysRepository) CountToys(ctx context.Context, filters *entities.Toy
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -131,7 +131,7 @@ func (repo *ToysRepository) CountMasterToys(
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -160,7 +160,7 @@ func (repo *ToysRepository) CountUserToys(
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -197,7 +197,7 @@ func (repo *ToysRepository) GetMasterToys(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	masterToys := make([]entities.Toy, len(response.GetToys()))
@@ -239,7 +239,7 @@ func (repo *ToysRepository) GetUserToys(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	userToys := make([]entities.Toy, len(response.GetToys()))
@@ -258,7 +258,7 @@ func (repo *ToysRepository) GetToyByID(ctx context.Context, id uint64) (*entitie
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processToyResponse(response), nil
@@ -289,7 +289,7 @@ func (repo *ToysRepository) GetMasters(
 		in,
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	masters := make([]entities.Master, len(response.GetMasters()))
@@ -314,7 +314,7 @@ func (repo *ToysRepository) CountMasters(ctx context.Context, filters *entities.
 		in,
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.Count, nil
@@ -331,7 +331,7 @@ func (repo *ToysRepository) GetMasterByID(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processMasterResponse(response), nil
@@ -349,7 +349,7 @@ func (repo *ToysRepository) RegisterMaster(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
 	}
 
 	return response.GetMasterID(), nil
@@ -361,7 +361,7 @@ func (repo *ToysRepository) GetAllCategories(ctx context.Context) ([]entities.Ca
 		&emptypb.Empty{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	categories := make([]entities.Category, len(response.GetCategories()))
@@ -383,7 +383,7 @@ func (repo *ToysRepository) GetCategoryByID(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processCategoryResponse(response), nil
@@ -395,7 +395,7 @@ func (repo *ToysRepository) GetAllTags(ctx context.Context) ([]entities.Tag, err
 		&emptypb.Empty{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	tags := make([]entities.Tag, len(response.GetTags()))
@@ -414,7 +414,7 @@ func (repo *ToysRepository) GetTagByID(ctx context.Context, id uint32) (*entitie
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processTagResponse(response), nil
@@ -433,7 +433,7 @@ func (repo *ToysRepository) CreateTags(
 
 	response, err := repo.client.CreateTags(ctx, &toys.CreateTagsIn{Tags: tagsRequest})
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	tagIDs := make([]uint32, len(response.GetTags()))
@@ -459,7 +459,7 @@ func (repo *ToysRepository) UpdateToy(ctx context.Context, toyData entities.Upda
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *ToysRepository) DeleteToy(ctx context.Context, id uint64) error {
@@ -470,7 +470,7 @@ func (repo *ToysRepository) DeleteToy(ctx context.Context, id uint64) error {
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *ToysRepository) GetMasterByUserID(
@@ -484,7 +484,7 @@ func (repo *ToysRepository) GetMasterByUserID(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return repo.processMasterResponse(response), nil
@@ -502,7 +502,7 @@ func (repo *ToysRepository) UpdateMaster(
 		},
 	)
 
-	return err
+	return translateError(err)
 }
 
 func (repo *ToysRepository) processTagResponse(tagResponse *toys.GetTagOut) *entities.Tag {
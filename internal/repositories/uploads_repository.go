@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+const (
+	uploadsKeyPrefix    = "uploads:"
+	uploadDataKeySuffix = ":data"
+	uploadIDLength      = 16
+
+	appendChunkUploadNotFound = -1
+	appendChunkOffsetMismatch = -2
+
+	// Appends chunk only if upload exists and offset equals to length of already received data.
+	// Data key expires together with upload meta:
+	appendUploadChunkScript = `
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	return -1
+end
+if redis.call("STRLEN", KEYS[2]) ~= tonumber(ARGV[1]) then
+	return -2
+end
+local length = redis.call("APPEND", KEYS[2], ARGV[2])
+redis.call("PEXPIRE", KEYS[2], ttl)
+return length
+`
+)
+
+type RedisUploadsRepository struct {
+	client interfaces.RedisClient
+	ttl    time.Duration
+}
+
+func NewRedisUploadsRepository(client interfaces.RedisClient, ttl time.Duration) *RedisUploadsRepository {
+	return &RedisUploadsRepository{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (repo *RedisUploadsRepository) CreateUpload(
+	ctx context.Context,
+	upload entities.ResumableUpload,
+) (string, error) {
+	idBytes := make([]byte, uploadIDLength)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+
+	upload.ID = hex.EncodeToString(idBytes)
+	upload.Offset = 0
+
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return "", err
+	}
+
+	if err = repo.client.Set(ctx, uploadKey(upload.ID), data, repo.ttl).Err(); err != nil {
+		return "", err
+	}
+
+	return upload.ID, nil
+}
+
+func (repo *RedisUploadsRepository) GetUpload(ctx context.Context, id string) (*entities.ResumableUpload, error) {
+	data, err := repo.client.Get(ctx, uploadKey(id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, &customerrors.UploadNotFoundError{Message: id}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var upload entities.ResumableUpload
+	if err = json.Unmarshal([]byte(data), &upload); err != nil {
+		return nil, err
+	}
+
+	// Received data is removed after upload completion:
+	if upload.Link != "" {
+		upload.Offset = upload.Length
+
+		return &upload, nil
+	}
+
+	upload.Offset, err = repo.client.StrLen(ctx, uploadDataKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+func (repo *RedisUploadsRepository) AppendChunk(
+	ctx context.Context,
+	id string,
+	offset int64,
+	chunk []byte,
+) (int64, error) {
+	length, err := repo.client.Eval(
+		ctx,
+		appendUploadChunkScript,
+		[]string{uploadKey(id), uploadDataKey(id)},
+		offset,
+		chunk,
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	switch length {
+	case appendChunkUploadNotFound:
+		return 0, &customerrors.UploadNotFoundError{Message: id}
+	case appendChunkOffsetMismatch:
+		return 0, &customerrors.UploadOffsetMismatchError{
+			Message: fmt.Sprintf("offset=%d does not match received data of upload with id=%s", offset, id),
+		}
+	default:
+		return length, nil
+	}
+}
+
+func (repo *RedisUploadsRepository) GetUploadData(ctx context.Context, id string) ([]byte, error) {
+	data, err := repo.client.Get(ctx, uploadDataKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return []byte{}, nil
+	}
+
+	return data, err
+}
+
+func (repo *RedisUploadsRepository) CompleteUpload(ctx context.Context, id, link string) error {
+	upload, err := repo.GetUpload(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	upload.Link = link
+	upload.Offset = 0
+
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+
+	// Completed upload lives till the end of initial TTL for being used by mutations:
+	if err = repo.client.Set(ctx, uploadKey(id), data, redis.KeepTTL).Err(); err != nil {
+		return err
+	}
+
+	return repo.client.Del(ctx, uploadDataKey(id)).Err()
+}
+
+func (repo *RedisUploadsRepository) DeleteUpload(ctx context.Context, id string) error {
+	return repo.client.Del(ctx, uploadKey(id), uploadDataKey(id)).Err()
+}
+
+func uploadKey(id string) string {
+	return uploadsKeyPrefix + id
+}
+
+func uploadDataKey(id string) string {
+	return uploadsKeyPrefix + id + uploadDataKeySuffix
+}
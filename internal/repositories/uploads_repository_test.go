@@ -0,0 +1,486 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockclients "github.com/DKhorkov/hmtm-bff/mocks/clients"
+)
+
+const testUploadData = `{"id":"id","userId":1,"filename":"file.jpg","length":10,"offset":0}`
+
+func TestRedisUploadsRepository_CreateUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), time.Hour).
+					Return(redis.NewStatusResult("OK", nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), time.Hour).
+					Return(redis.NewStatusResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			id, err := repo.CreateUpload(context.Background(), entities.ResumableUpload{UserID: 1, Length: 10})
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Empty(t, id)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, id, uploadIDLength*2)
+			}
+		})
+	}
+}
+
+func TestRedisUploadsRepository_GetUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		expected      *entities.ResumableUpload
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name: "in progress",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult(testUploadData, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					StrLen(gomock.Any(), "uploads:id:data").
+					Return(redis.NewIntResult(4, nil)).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{
+				ID:       "id",
+				UserID:   1,
+				Filename: "file.jpg",
+				Length:   10,
+				Offset:   4,
+			},
+		},
+		{
+			name: "completed",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(
+						redis.NewStringResult(
+							`{"id":"id","userId":1,"filename":"file.jpg","length":10,"link":"link"}`,
+							nil,
+						),
+					).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{
+				ID:       "id",
+				UserID:   1,
+				Filename: "file.jpg",
+				Length:   10,
+				Offset:   10,
+				Link:     "link",
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult("", redis.Nil)).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotFoundError{},
+		},
+		{
+			name: "get error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "strlen error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult(testUploadData, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					StrLen(gomock.Any(), "uploads:id:data").
+					Return(redis.NewIntResult(0, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			upload, err := repo.GetUpload(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, upload)
+		})
+	}
+}
+
+func TestRedisUploadsRepository_AppendChunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		expected      int64
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(
+						gomock.Any(),
+						appendUploadChunkScript,
+						[]string{"uploads:id", "uploads:id:data"},
+						int64(4),
+						[]byte("chunk"),
+					).
+					Return(redis.NewCmdResult(int64(9), nil)).
+					Times(1)
+			},
+			expected: 9,
+		},
+		{
+			name: "not found",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), appendUploadChunkScript, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(int64(appendChunkUploadNotFound), nil)).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotFoundError{},
+		},
+		{
+			name: "offset mismatch",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), appendUploadChunkScript, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(int64(appendChunkOffsetMismatch), nil)).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadOffsetMismatchError{},
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Eval(gomock.Any(), appendUploadChunkScript, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(nil, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			offset, err := repo.AppendChunk(context.Background(), "id", 4, []byte("chunk"))
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, offset)
+		})
+	}
+}
+
+func TestRedisUploadsRepository_GetUploadData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		expected      []byte
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id:data").
+					Return(redis.NewStringResult("data", nil)).
+					Times(1)
+			},
+			expected: []byte("data"),
+		},
+		{
+			name: "no data",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id:data").
+					Return(redis.NewStringResult("", redis.Nil)).
+					Times(1)
+			},
+			expected: []byte{},
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id:data").
+					Return(redis.NewStringResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			data, err := repo.GetUploadData(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, data)
+			}
+		})
+	}
+}
+
+func TestRedisUploadsRepository_CompleteUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult(testUploadData, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					StrLen(gomock.Any(), "uploads:id:data").
+					Return(redis.NewIntResult(10, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Set(
+						gomock.Any(),
+						"uploads:id",
+						[]byte(`{"id":"id","userId":1,"filename":"file.jpg","length":10,"offset":0,"link":"link"}`),
+						time.Duration(redis.KeepTTL),
+					).
+					Return(redis.NewStatusResult("OK", nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), "uploads:id:data").
+					Return(redis.NewIntResult(1, nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult("", redis.Nil)).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "set error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Get(gomock.Any(), "uploads:id").
+					Return(redis.NewStringResult(testUploadData, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					StrLen(gomock.Any(), "uploads:id:data").
+					Return(redis.NewIntResult(10, nil)).
+					Times(1)
+
+				redisClient.
+					EXPECT().
+					Set(gomock.Any(), "uploads:id", gomock.Any(), time.Duration(redis.KeepTTL)).
+					Return(redis.NewStatusResult("", errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.CompleteUpload(context.Background(), "id", "link")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedisUploadsRepository_DeleteUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redisClient := mockclients.NewMockRedisClient(ctrl)
+	repo := NewRedisUploadsRepository(redisClient, time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(redisClient *mockclients.MockRedisClient)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), "uploads:id", "uploads:id:data").
+					Return(redis.NewIntResult(2, nil)).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(redisClient *mockclients.MockRedisClient) {
+				redisClient.
+					EXPECT().
+					Del(gomock.Any(), "uploads:id", "uploads:id:data").
+					Return(redis.NewIntResult(0, errors.New("test error"))).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(redisClient)
+			}
+
+			err := repo.DeleteUpload(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package requestcache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DKhorkov/libs/contextlib"
+)
+
+const contextKey = "requestCache"
+
+// Cache keeps values, which are resolved once per request, for example authenticated user, which is needed
+// by resolvers of several fields. Cache is created by middleware and is discarded with request.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+func New() *Cache {
+	return &Cache{
+		entries: make(map[string]*entry),
+	}
+}
+
+func WithCache(ctx context.Context, cache *Cache) context.Context {
+	return contextlib.WithValue(ctx, contextKey, cache)
+}
+
+// Get returns value by key, which is resolved by first caller within request. Concurrent callers wait for
+// the first one and receive the same value or error. Value is resolved on each call, if context does not
+// belong to request.
+func Get[T any](ctx context.Context, key string, resolve func() (T, error)) (T, error) {
+	cache, err := contextlib.ValueFromContext[*Cache](ctx, contextKey)
+	if err != nil {
+		return resolve()
+	}
+
+	cache.mu.Lock()
+	cached, ok := cache.entries[key]
+	if !ok {
+		cached = &entry{}
+		cache.entries[key] = cached
+	}
+	cache.mu.Unlock()
+
+	cached.once.Do(func() {
+		cached.value, cached.err = resolve()
+	})
+
+	value, _ := cached.value.(T)
+
+	return value, cached.err
+}
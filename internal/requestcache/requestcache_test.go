@@ -0,0 +1,68 @@
+package requestcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	ctx := WithCache(context.Background(), New())
+
+	var calls atomic.Int32
+	resolve := func() (int, error) {
+		calls.Add(1)
+
+		return 1, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := Get(ctx, "key", resolve)
+			require.NoError(t, err)
+			require.Equal(t, 1, value)
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+
+	// Errors are also resolved once:
+	resolveErr := errors.New("resolve failed")
+	for range 2 {
+		_, err := Get(ctx, "failed", func() (int, error) {
+			calls.Add(1)
+
+			return 0, resolveErr
+		})
+		require.ErrorIs(t, err, resolveErr)
+	}
+
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestGet_MissingInContext(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int
+	for range 2 {
+		value, err := Get(ctx, "key", func() (string, error) {
+			calls++
+
+			return "value", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "value", value)
+	}
+
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,84 @@
+package requestmeta
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DKhorkov/libs/contextlib"
+)
+
+const contextKey = "requestMetadata"
+
+// Metadata describes incoming request. It is created by HTTP middleware and is filled by later layers,
+// when operation name and authenticated user become known. Metadata is forwarded to downstream services.
+type Metadata struct {
+	mu            sync.RWMutex
+	requestID     string
+	clientIP      string
+	operationName string
+	userID        uint64
+}
+
+func New(requestID, clientIP string) *Metadata {
+	return &Metadata{
+		requestID: requestID,
+		clientIP:  clientIP,
+	}
+}
+
+func WithMetadata(ctx context.Context, metadata *Metadata) context.Context {
+	return contextlib.WithValue(ctx, contextKey, metadata)
+}
+
+// FromContext returns metadata of request. Nil is returned, if context does not belong to request.
+func FromContext(ctx context.Context) *Metadata {
+	metadata, err := contextlib.ValueFromContext[*Metadata](ctx, contextKey)
+	if err != nil {
+		return nil
+	}
+
+	return metadata
+}
+
+// SetOperationName remembers name of GraphQL operation of request, if context belongs to request.
+func SetOperationName(ctx context.Context, operationName string) {
+	if metadata := FromContext(ctx); metadata != nil {
+		metadata.mu.Lock()
+		defer metadata.mu.Unlock()
+
+		metadata.operationName = operationName
+	}
+}
+
+// SetUserID remembers authenticated user of request, if context belongs to request.
+func SetUserID(ctx context.Context, userID uint64) {
+	if metadata := FromContext(ctx); metadata != nil {
+		metadata.mu.Lock()
+		defer metadata.mu.Unlock()
+
+		metadata.userID = userID
+	}
+}
+
+func (m *Metadata) RequestID() string {
+	return m.requestID
+}
+
+func (m *Metadata) ClientIP() string {
+	return m.clientIP
+}
+
+func (m *Metadata) OperationName() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.operationName
+}
+
+// UserID returns ID of authenticated user or zero, if user is not authenticated.
+func (m *Metadata) UserID() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.userID
+}
@@ -0,0 +1,32 @@
+package requestmeta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	ctx := WithMetadata(context.Background(), New("request-id", "10.0.0.1"))
+
+	SetOperationName(ctx, "toys")
+	SetUserID(ctx, 1)
+
+	metadata := FromContext(ctx)
+	require.NotNil(t, metadata)
+	require.Equal(t, "request-id", metadata.RequestID())
+	require.Equal(t, "10.0.0.1", metadata.ClientIP())
+	require.Equal(t, "toys", metadata.OperationName())
+	require.Equal(t, uint64(1), metadata.UserID())
+}
+
+func TestMetadata_MissingInContext(t *testing.T) {
+	ctx := context.Background()
+
+	// Setters do nothing without metadata:
+	SetOperationName(ctx, "toys")
+	SetUserID(ctx, 1)
+
+	require.Nil(t, FromContext(ctx))
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+type CompensationsService struct {
+	compensationsRepository interfaces.CompensationsRepository
+	fileStorageService      interfaces.FileStorageService
+	logger                  logging.Logger
+}
+
+func NewCompensationsService(
+	compensationsRepository interfaces.CompensationsRepository,
+	fileStorageService interfaces.FileStorageService,
+	logger logging.Logger,
+) *CompensationsService {
+	return &CompensationsService{
+		compensationsRepository: compensationsRepository,
+		fileStorageService:      fileStorageService,
+		logger:                  logger,
+	}
+}
+
+func (service *CompensationsService) SaveCompensation(
+	ctx context.Context,
+	compensation entities.Compensation,
+) error {
+	err := service.compensationsRepository.SaveCompensation(ctx, compensation)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to save Compensation=%+v", compensation),
+			err,
+		)
+	}
+
+	return err
+}
+
+func (service *CompensationsService) PopCompensation(ctx context.Context) (*entities.Compensation, error) {
+	compensation, err := service.compensationsRepository.PopCompensation(ctx)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to pop Compensation",
+			err,
+		)
+	}
+
+	return compensation, err
+}
+
+func (service *CompensationsService) ExecuteCompensation(
+	ctx context.Context,
+	compensation entities.Compensation,
+) error {
+	switch compensation.Kind {
+	case entities.CompensationKindDeleteFiles:
+		if len(compensation.FileKeys) == 0 {
+			return nil
+		}
+
+		// Errors are logged by FileStorageService:
+		if deleteErrors := service.fileStorageService.DeleteMany(ctx, compensation.FileKeys); len(deleteErrors) > 0 {
+			return &customerrors.DeleteFileError{
+				Message: fmt.Sprintf("%v", compensation.FileKeys),
+				BaseErr: fmt.Errorf("%v", deleteErrors),
+			}
+		}
+
+		return nil
+	default:
+		return &customerrors.UnknownCompensationKindError{Message: compensation.Kind}
+	}
+}
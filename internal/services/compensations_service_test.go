@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
+)
+
+func TestCompensationsService_SaveCompensation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	compensationsRepository := mockrepositories.NewMockCompensationsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewCompensationsService(compensationsRepository, nil, logger)
+
+	compensation := entities.Compensation{Kind: entities.CompensationKindDeleteFiles}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(compensationsRepository *mockrepositories.MockCompensationsRepository, logger *mocklogging.MockLogger)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(compensationsRepository *mockrepositories.MockCompensationsRepository, _ *mocklogging.MockLogger) {
+				compensationsRepository.
+					EXPECT().
+					SaveCompensation(gomock.Any(), compensation).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(compensationsRepository *mockrepositories.MockCompensationsRepository, logger *mocklogging.MockLogger) {
+				compensationsRepository.
+					EXPECT().
+					SaveCompensation(gomock.Any(), compensation).
+					Return(errors.New("save failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(compensationsRepository, logger)
+			}
+
+			err := service.SaveCompensation(context.Background(), compensation)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompensationsService_PopCompensation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	compensationsRepository := mockrepositories.NewMockCompensationsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewCompensationsService(compensationsRepository, nil, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(compensationsRepository *mockrepositories.MockCompensationsRepository, logger *mocklogging.MockLogger)
+		expected      *entities.Compensation
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(compensationsRepository *mockrepositories.MockCompensationsRepository, _ *mocklogging.MockLogger) {
+				compensationsRepository.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(&entities.Compensation{Step: "step"}, nil).
+					Times(1)
+			},
+			expected: &entities.Compensation{Step: "step"},
+		},
+		{
+			name: "error",
+			setupMocks: func(compensationsRepository *mockrepositories.MockCompensationsRepository, logger *mocklogging.MockLogger) {
+				compensationsRepository.
+					EXPECT().
+					PopCompensation(gomock.Any()).
+					Return(nil, errors.New("pop failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(compensationsRepository, logger)
+			}
+
+			actual, err := service.PopCompensation(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestCompensationsService_ExecuteCompensation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewCompensationsService(nil, fileStorageService, logger)
+
+	testCases := []struct {
+		name          string
+		compensation  entities.Compensation
+		setupMocks    func(fileStorageService *mockservices.MockFileStorageService)
+		expectedError error
+	}{
+		{
+			name: "delete files success",
+			compensation: entities.Compensation{
+				Kind:     entities.CompensationKindDeleteFiles,
+				FileKeys: []string{"key1", "key2"},
+			},
+			setupMocks: func(fileStorageService *mockservices.MockFileStorageService) {
+				fileStorageService.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"key1", "key2"}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "delete files without keys",
+			compensation: entities.Compensation{
+				Kind: entities.CompensationKindDeleteFiles,
+			},
+		},
+		{
+			name: "delete files error",
+			compensation: entities.Compensation{
+				Kind:     entities.CompensationKindDeleteFiles,
+				FileKeys: []string{"key1"},
+			},
+			setupMocks: func(fileStorageService *mockservices.MockFileStorageService) {
+				fileStorageService.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"key1"}).
+					Return([]error{errors.New("delete failed")}).
+					Times(1)
+			},
+			expectedError: &customerrors.DeleteFileError{},
+		},
+		{
+			name: "unknown kind",
+			compensation: entities.Compensation{
+				Kind: "unknown",
+			},
+			expectedError: &customerrors.UnknownCompensationKindError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageService)
+			}
+
+			err := service.ExecuteCompensation(context.Background(), tc.compensation)
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,26 +2,31 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DKhorkov/libs/logging"
 
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
 	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
 )
 
 type FileStorageService struct {
-	fileStorageRepository interfaces.FileStorageRepository
-	logger                logging.Logger
+	fileStorageRepository  interfaces.FileStorageRepository
+	storageUsageRepository interfaces.StorageUsageRepository
+	logger                 logging.Logger
 }
 
 func NewFileStorageService(
 	fileStorageRepository interfaces.FileStorageRepository,
+	storageUsageRepository interfaces.StorageUsageRepository,
 	logger logging.Logger,
 ) *FileStorageService {
 	return &FileStorageService{
-		fileStorageRepository: fileStorageRepository,
-		logger:                logger,
+		fileStorageRepository:  fileStorageRepository,
+		storageUsageRepository: storageUsageRepository,
+		logger:                 logger,
 	}
 }
 
@@ -29,8 +34,9 @@ func (service *FileStorageService) Upload(
 	ctx context.Context,
 	key string,
 	data []byte,
+	visibility entities.FileVisibility,
 ) (string, error) {
-	url, err := service.fileStorageRepository.Upload(ctx, key, data)
+	url, err := service.fileStorageRepository.Upload(ctx, key, data, visibility)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
@@ -45,6 +51,63 @@ func (service *FileStorageService) Upload(
 	return url, nil
 }
 
+func (service *FileStorageService) UploadUserFile(
+	ctx context.Context,
+	userID uint64,
+	limit int64,
+	key string,
+	data []byte,
+	visibility entities.FileVisibility,
+) (string, bool, error) {
+	reserved, overwritten, err := service.storageUsageRepository.Reserve(ctx, userID, key, int64(len(data)), limit)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf(
+				"Error occurred while trying to reserve storage for File with key=%s of User with ID=%d",
+				key,
+				userID,
+			),
+			err,
+		)
+
+		return "", false, &customerrors.UploadFileError{Message: key, BaseErr: err}
+	}
+
+	if !reserved {
+		return "", false, &customerrors.StorageQuotaExceededError{
+			Message: fmt.Sprintf("File with key=%s does not fit into limit of %d bytes", key, limit),
+		}
+	}
+
+	url, err := service.Upload(ctx, key, data, visibility)
+	if err != nil {
+		// Overwritten file is still stored, so that its accounting is kept:
+		if !overwritten {
+			service.release(ctx, []string{key})
+		}
+
+		return "", false, err
+	}
+
+	return url, overwritten, nil
+}
+
+func (service *FileStorageService) GetUsedBytes(ctx context.Context, userID uint64) (int64, error) {
+	used, err := service.storageUsageRepository.GetUsedBytes(ctx, userID)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to get used storage for User with ID=%d", userID),
+			err,
+		)
+	}
+
+	return used, err
+}
+
 func (service *FileStorageService) Delete(ctx context.Context, key string) error {
 	err := service.fileStorageRepository.Delete(ctx, key)
 	if err != nil {
@@ -54,9 +117,13 @@ func (service *FileStorageService) Delete(ctx context.Context, key string) error
 			"Error occurred while trying to delete File with key="+key,
 			err,
 		)
+
+		return err
 	}
 
-	return err
+	service.release(ctx, []string{key})
+
+	return nil
 }
 
 func (service *FileStorageService) DeleteMany(ctx context.Context, keys []string) []error {
@@ -68,7 +135,103 @@ func (service *FileStorageService) DeleteMany(ctx context.Context, keys []string
 			fmt.Sprintf("Errors occurred while trying to delete Files with keys=%s", keys),
 			fmt.Errorf("%v", deleteErrors),
 		)
+
+		// Storage usage of deleted files is released, while usage of not deleted files is released
+		// after successful retry of deletion:
+		service.release(ctx, deletedKeys(keys, deleteErrors))
+
+		return deleteErrors
+	}
+
+	service.release(ctx, keys)
+
+	return nil
+}
+
+func (service *FileStorageService) List(ctx context.Context) ([]entities.StoredFile, error) {
+	files, err := service.fileStorageRepository.List(ctx)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to list Files",
+			err,
+		)
+	}
+
+	return files, err
+}
+
+func (service *FileStorageService) GetPresignedURL(ctx context.Context, key string) (string, error) {
+	url, err := service.fileStorageRepository.GetPresignedURL(ctx, key)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to get presigned URL for File with key="+key,
+			err,
+		)
+	}
+
+	return url, err
+}
+
+func (service *FileStorageService) SetVisibility(
+	ctx context.Context,
+	key string,
+	visibility entities.FileVisibility,
+) error {
+	err := service.fileStorageRepository.SetVisibility(ctx, key, visibility)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to set visibility=%s for File with key=%s", visibility, key),
+			err,
+		)
+	}
+
+	return err
+}
+
+// release removes deleted files from storage usage. Failed release does not fail deletion,
+// because file is already removed from storage.
+func (service *FileStorageService) release(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := service.storageUsageRepository.Release(ctx, keys); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to release storage for Files with keys=%s", keys),
+			err,
+		)
+	}
+}
+
+// deletedKeys returns keys, which have no DeleteFileError. No key is treated as deleted, if any error
+// does not point to its key.
+func deletedKeys(keys []string, deleteErrors []error) []string {
+	failed := make(map[string]struct{}, len(deleteErrors))
+
+	for _, err := range deleteErrors {
+		var deleteFileError *customerrors.DeleteFileError
+		if !errors.As(err, &deleteFileError) {
+			return nil
+		}
+
+		failed[deleteFileError.Message] = struct{}{}
+	}
+
+	deleted := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if _, ok := failed[key]; !ok {
+			deleted = append(deleted, key)
+		}
 	}
 
-	return deleteErrors
+	return deleted
 }
@@ -10,6 +10,7 @@ import (
 
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
 	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
 )
@@ -17,8 +18,9 @@ import (
 func TestFileStorageService_Upload(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	service := NewFileStorageService(fileStorageRepository, logger)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
 
 	testCases := []struct {
 		name          string
@@ -35,7 +37,7 @@ func TestFileStorageService_Upload(t *testing.T) {
 			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
 				fileStorageRepository.
 					EXPECT().
-					Upload(gomock.Any(), "test-key", []byte("test-data")).
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPrivate).
 					Return("http://storage/test-key", nil).
 					Times(1)
 			},
@@ -49,7 +51,7 @@ func TestFileStorageService_Upload(t *testing.T) {
 			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
 				fileStorageRepository.
 					EXPECT().
-					Upload(gomock.Any(), "test-key", []byte("test-data")).
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPrivate).
 					Return("", errors.New("upload failed")).
 					Times(1)
 
@@ -69,7 +71,7 @@ func TestFileStorageService_Upload(t *testing.T) {
 				tc.setupMocks(fileStorageRepository, logger)
 			}
 
-			url, err := service.Upload(context.Background(), tc.key, tc.data)
+			url, err := service.Upload(context.Background(), tc.key, tc.data, entities.FileVisibilityPrivate)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.IsType(t, &customerrors.UploadFileError{}, err)
@@ -85,31 +87,77 @@ func TestFileStorageService_Upload(t *testing.T) {
 func TestFileStorageService_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	service := NewFileStorageService(fileStorageRepository, logger)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
 
 	testCases := []struct {
-		name          string
-		key           string
-		setupMocks    func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger)
+		name       string
+		key        string
+		setupMocks func(
+			fileStorageRepository *mockrepositories.MockFileStorageRepository,
+			storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+			logger *mocklogging.MockLogger,
+		)
 		errorExpected bool
 	}{
 		{
 			name: "success",
 			key:  "test-key",
-			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
 				fileStorageRepository.
 					EXPECT().
 					Delete(gomock.Any(), "test-key").
 					Return(nil).
 					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), []string{"test-key"}).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name: "release error does not fail deletion",
+			key:  "test-key",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				fileStorageRepository.
+					EXPECT().
+					Delete(gomock.Any(), "test-key").
+					Return(nil).
+					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), []string{"test-key"}).
+					Return(errors.New("release failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 			errorExpected: false,
 		},
 		{
 			name: "error",
 			key:  "test-key",
-			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
 				fileStorageRepository.
 					EXPECT().
 					Delete(gomock.Any(), "test-key").
@@ -128,7 +176,7 @@ func TestFileStorageService_Delete(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.setupMocks != nil {
-				tc.setupMocks(fileStorageRepository, logger)
+				tc.setupMocks(fileStorageRepository, storageUsageRepository, logger)
 			}
 
 			err := service.Delete(context.Background(), tc.key)
@@ -145,31 +193,50 @@ func TestFileStorageService_Delete(t *testing.T) {
 func TestFileStorageService_DeleteMany(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	service := NewFileStorageService(fileStorageRepository, logger)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
 
 	testCases := []struct {
-		name           string
-		keys           []string
-		setupMocks     func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger)
+		name       string
+		keys       []string
+		setupMocks func(
+			fileStorageRepository *mockrepositories.MockFileStorageRepository,
+			storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+			logger *mocklogging.MockLogger,
+		)
 		expectedErrors []error
 	}{
 		{
 			name: "success",
 			keys: []string{"key1", "key2"},
-			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
 				fileStorageRepository.
 					EXPECT().
 					DeleteMany(gomock.Any(), []string{"key1", "key2"}).
 					Return(nil).
 					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), []string{"key1", "key2"}).
+					Return(nil).
+					Times(1)
 			},
 			expectedErrors: nil,
 		},
 		{
 			name: "partial errors",
 			keys: []string{"key1", "key2"},
-			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
 				deleteErrors := []error{nil, errors.New("delete key2 failed")}
 				fileStorageRepository.
 					EXPECT().
@@ -184,12 +251,39 @@ func TestFileStorageService_DeleteMany(t *testing.T) {
 			},
 			expectedErrors: []error{nil, errors.New("delete key2 failed")},
 		},
+		{
+			name: "deleted keys are released on partial failure",
+			keys: []string{"key1", "key2"},
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				fileStorageRepository.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"key1", "key2"}).
+					Return([]error{&customerrors.DeleteFileError{Message: "key2"}}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), []string{"key1"}).
+					Return(nil).
+					Times(1)
+			},
+			expectedErrors: []error{&customerrors.DeleteFileError{Message: "key2"}},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.setupMocks != nil {
-				tc.setupMocks(fileStorageRepository, logger)
+				tc.setupMocks(fileStorageRepository, storageUsageRepository, logger)
 			}
 
 			deleteErrors := service.DeleteMany(context.Background(), tc.keys)
@@ -208,3 +302,432 @@ func TestFileStorageService_DeleteMany(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStorageService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger)
+		expected      []entities.StoredFile
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					List(gomock.Any()).
+					Return([]entities.StoredFile{{Key: "key1"}}, nil).
+					Times(1)
+			},
+			expected: []entities.StoredFile{{Key: "key1"}},
+		},
+		{
+			name: "error",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					List(gomock.Any()).
+					Return(nil, errors.New("list failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageRepository, logger)
+			}
+
+			files, err := service.List(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+func TestFileStorageService_GetPresignedURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger)
+		expected      string
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					GetPresignedURL(gomock.Any(), "test-key").
+					Return("https://presigned-url", nil).
+					Times(1)
+			},
+			expected: "https://presigned-url",
+		},
+		{
+			name: "error",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					GetPresignedURL(gomock.Any(), "test-key").
+					Return("", errors.New("presign failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageRepository, logger)
+			}
+
+			url, err := service.GetPresignedURL(context.Background(), "test-key")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, url)
+		})
+	}
+}
+
+func TestFileStorageService_SetVisibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					SetVisibility(gomock.Any(), "test-key", entities.FileVisibilityPublic).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(fileStorageRepository *mockrepositories.MockFileStorageRepository, logger *mocklogging.MockLogger) {
+				fileStorageRepository.
+					EXPECT().
+					SetVisibility(gomock.Any(), "test-key", entities.FileVisibilityPublic).
+					Return(errors.New("acl failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageRepository, logger)
+			}
+
+			err := service.SetVisibility(context.Background(), "test-key", entities.FileVisibilityPublic)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFileStorageService_UploadUserFile(t *testing.T) {
+	const (
+		userID = uint64(1)
+		limit  = int64(100)
+	)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			fileStorageRepository *mockrepositories.MockFileStorageRepository,
+			storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+			logger *mocklogging.MockLogger,
+		)
+		expectedURL         string
+		expectedOverwritten bool
+		expectedError       error
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(true, false, nil).
+					Times(1)
+
+				fileStorageRepository.
+					EXPECT().
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPublic).
+					Return("http://storage/test-key", nil).
+					Times(1)
+			},
+			expectedURL: "http://storage/test-key",
+		},
+		{
+			name: "overwritten file",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(true, true, nil).
+					Times(1)
+
+				fileStorageRepository.
+					EXPECT().
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPublic).
+					Return("http://storage/test-key", nil).
+					Times(1)
+			},
+			expectedURL:         "http://storage/test-key",
+			expectedOverwritten: true,
+		},
+		{
+			name: "quota exceeded",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(false, false, nil).
+					Times(1)
+			},
+			expectedError: &customerrors.StorageQuotaExceededError{},
+		},
+		{
+			name: "reserve error",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(false, false, errors.New("reserve failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedError: &customerrors.UploadFileError{},
+		},
+		{
+			name: "upload error releases reserved storage",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(true, false, nil).
+					Times(1)
+
+				fileStorageRepository.
+					EXPECT().
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPublic).
+					Return("", errors.New("upload failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), []string{"test-key"}).
+					Return(nil).
+					Times(1)
+			},
+			expectedError: &customerrors.UploadFileError{},
+		},
+		{
+			name: "upload error keeps storage of overwritten file",
+			setupMocks: func(
+				fileStorageRepository *mockrepositories.MockFileStorageRepository,
+				storageUsageRepository *mockrepositories.MockStorageUsageRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				storageUsageRepository.
+					EXPECT().
+					Reserve(gomock.Any(), userID, "test-key", int64(len("test-data")), limit).
+					Return(true, true, nil).
+					Times(1)
+
+				fileStorageRepository.
+					EXPECT().
+					Upload(gomock.Any(), "test-key", []byte("test-data"), entities.FileVisibilityPublic).
+					Return("", errors.New("upload failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				storageUsageRepository.
+					EXPECT().
+					Release(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedError: &customerrors.UploadFileError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+			storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+			service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageRepository, storageUsageRepository, logger)
+			}
+
+			url, overwritten, err := service.UploadUserFile(
+				context.Background(),
+				userID,
+				limit,
+				"test-key",
+				[]byte("test-data"),
+				entities.FileVisibilityPublic,
+			)
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedURL, url)
+			require.Equal(t, tc.expectedOverwritten, overwritten)
+		})
+	}
+}
+
+func TestFileStorageService_GetUsedBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fileStorageRepository := mockrepositories.NewMockFileStorageRepository(ctrl)
+	storageUsageRepository := mockrepositories.NewMockStorageUsageRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewFileStorageService(fileStorageRepository, storageUsageRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(storageUsageRepository *mockrepositories.MockStorageUsageRepository, logger *mocklogging.MockLogger)
+		expected      int64
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(storageUsageRepository *mockrepositories.MockStorageUsageRepository, logger *mocklogging.MockLogger) {
+				storageUsageRepository.
+					EXPECT().
+					GetUsedBytes(gomock.Any(), uint64(1)).
+					Return(int64(42), nil).
+					Times(1)
+			},
+			expected: 42,
+		},
+		{
+			name: "error",
+			setupMocks: func(storageUsageRepository *mockrepositories.MockStorageUsageRepository, logger *mocklogging.MockLogger) {
+				storageUsageRepository.
+					EXPECT().
+					GetUsedBytes(gomock.Any(), uint64(1)).
+					Return(int64(0), errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(storageUsageRepository, logger)
+			}
+
+			used, err := service.GetUsedBytes(context.Background(), 1)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, used)
+		})
+	}
+}
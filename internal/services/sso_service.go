@@ -8,6 +8,7 @@ import (
 
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
 )
 
 type SsoService struct {
@@ -216,9 +217,14 @@ func (service *SsoService) GetMe(ctx context.Context, accessToken string) (*enti
 			"Error occurred while trying to get User with AccessToken="+accessToken,
 			err,
 		)
+
+		return nil, err
 	}
 
-	return user, err
+	// Authenticated User is forwarded to downstream services with next calls of request:
+	requestmeta.SetUserID(ctx, user.ID)
+
+	return user, nil
 }
 
 func (service *SsoService) RefreshTokens(
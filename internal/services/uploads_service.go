@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+type UploadsService struct {
+	uploadsRepository interfaces.UploadsRepository
+	logger            logging.Logger
+}
+
+func NewUploadsService(
+	uploadsRepository interfaces.UploadsRepository,
+	logger logging.Logger,
+) *UploadsService {
+	return &UploadsService{
+		uploadsRepository: uploadsRepository,
+		logger:            logger,
+	}
+}
+
+func (service *UploadsService) CreateUpload(ctx context.Context, upload entities.ResumableUpload) (string, error) {
+	id, err := service.uploadsRepository.CreateUpload(ctx, upload)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to create Upload=%+v", upload),
+			err,
+		)
+	}
+
+	return id, err
+}
+
+func (service *UploadsService) GetUpload(ctx context.Context, id string) (*entities.ResumableUpload, error) {
+	upload, err := service.uploadsRepository.GetUpload(ctx, id)
+	if err != nil && !isUploadClientError(err) {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to get Upload with ID="+id,
+			err,
+		)
+	}
+
+	return upload, err
+}
+
+func (service *UploadsService) AppendChunk(
+	ctx context.Context,
+	id string,
+	offset int64,
+	chunk []byte,
+) (int64, error) {
+	newOffset, err := service.uploadsRepository.AppendChunk(ctx, id, offset, chunk)
+	if err != nil && !isUploadClientError(err) {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to append chunk with offset=%d to Upload with ID=%s", offset, id),
+			err,
+		)
+	}
+
+	return newOffset, err
+}
+
+func (service *UploadsService) GetUploadData(ctx context.Context, id string) ([]byte, error) {
+	data, err := service.uploadsRepository.GetUploadData(ctx, id)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to get data of Upload with ID="+id,
+			err,
+		)
+	}
+
+	return data, err
+}
+
+func (service *UploadsService) CompleteUpload(ctx context.Context, id, link string) error {
+	err := service.uploadsRepository.CompleteUpload(ctx, id, link)
+	if err != nil && !isUploadClientError(err) {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to complete Upload with ID="+id,
+			err,
+		)
+	}
+
+	return err
+}
+
+func (service *UploadsService) DeleteUpload(ctx context.Context, id string) error {
+	err := service.uploadsRepository.DeleteUpload(ctx, id)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to delete Upload with ID="+id,
+			err,
+		)
+	}
+
+	return err
+}
+
+// isUploadClientError checks, whether error is caused by client's request and should not be logged.
+func isUploadClientError(err error) bool {
+	var (
+		notFoundErr       *customerrors.UploadNotFoundError
+		offsetMismatchErr *customerrors.UploadOffsetMismatchError
+	)
+
+	return errors.As(err, &notFoundErr) || errors.As(err, &offsetMismatchErr)
+}
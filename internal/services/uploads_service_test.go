@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+)
+
+func TestUploadsService_CreateUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	upload := entities.ResumableUpload{UserID: 1, Filename: "file.jpg", Length: 10}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		expected      string
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					CreateUpload(gomock.Any(), upload).
+					Return("id", nil).
+					Times(1)
+			},
+			expected: "id",
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					CreateUpload(gomock.Any(), upload).
+					Return("", errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			id, err := service.CreateUpload(context.Background(), upload)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestUploadsService_GetUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		expected      *entities.ResumableUpload
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id"}, nil).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{ID: "id"},
+		},
+		{
+			name: "not found is not logged",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(nil, &customerrors.UploadNotFoundError{Message: "id"}).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			upload, err := service.GetUpload(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, upload)
+		})
+	}
+}
+
+func TestUploadsService_AppendChunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		expected      int64
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(0), []byte("chunk")).
+					Return(int64(5), nil).
+					Times(1)
+			},
+			expected: 5,
+		},
+		{
+			name: "offset mismatch is not logged",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(0), []byte("chunk")).
+					Return(int64(0), &customerrors.UploadOffsetMismatchError{}).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(0), []byte("chunk")).
+					Return(int64(0), errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			offset, err := service.AppendChunk(context.Background(), "id", 0, []byte("chunk"))
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, offset)
+		})
+	}
+}
+
+func TestUploadsService_GetUploadData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		expected      []byte
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					GetUploadData(gomock.Any(), "id").
+					Return([]byte("data"), nil).
+					Times(1)
+			},
+			expected: []byte("data"),
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					GetUploadData(gomock.Any(), "id").
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			data, err := service.GetUploadData(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, data)
+		})
+	}
+}
+
+func TestUploadsService_CompleteUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					CompleteUpload(gomock.Any(), "id", "link").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					CompleteUpload(gomock.Any(), "id", "link").
+					Return(errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			err := service.CompleteUpload(context.Background(), "id", "link")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUploadsService_DeleteUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uploadsRepository := mockrepositories.NewMockUploadsRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUploadsService(uploadsRepository, logger)
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger)
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					DeleteUpload(gomock.Any(), "id").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(uploadsRepository *mockrepositories.MockUploadsRepository, logger *mocklogging.MockLogger) {
+				uploadsRepository.
+					EXPECT().
+					DeleteUpload(gomock.Any(), "id").
+					Return(errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(uploadsRepository, logger)
+			}
+
+			err := service.DeleteUpload(context.Background(), "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Names of cache compressors, which could be used in config:
+const (
+	noCacheCompression     = "none"
+	zstdCacheCompression   = "zstd"
+	snappyCacheCompression = "snappy"
+)
+
+// cacheCompressor compresses encoded values of cache entries. Its ID is stored in entry, so that entries
+// are decompressed correctly after compression algorithm is changed in config.
+type cacheCompressor interface {
+	ID() byte
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+var (
+	// Encoder and decoder are safe for concurrent use of EncodeAll and DecodeAll:
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+
+	// cacheCompressors is used to find compressor of entry by ID:
+	cacheCompressors = map[byte]cacheCompressor{
+		zstdCacheCompressor{}.ID():   zstdCacheCompressor{},
+		snappyCacheCompressor{}.ID(): snappyCacheCompressor{},
+	}
+)
+
+// newCacheCompressor returns compressor by its name from config. Nil is returned, if compression is disabled.
+func newCacheCompressor(compression string) (cacheCompressor, error) {
+	switch compression {
+	case "", noCacheCompression:
+		return nil, nil
+	case zstdCacheCompression:
+		return zstdCacheCompressor{}, nil
+	case snappyCacheCompression:
+		return snappyCacheCompressor{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache compression %s", compression)
+	}
+}
+
+type zstdCacheCompressor struct{}
+
+func (zstdCacheCompressor) ID() byte {
+	return 1
+}
+
+func (zstdCacheCompressor) Compress(data []byte) ([]byte, error) {
+	return zstdEncoder.EncodeAll(data, nil), nil
+}
+
+func (zstdCacheCompressor) Decompress(data []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(data, nil)
+}
+
+type snappyCacheCompressor struct{}
+
+func (snappyCacheCompressor) ID() byte {
+	return 2
+}
+
+func (snappyCacheCompressor) Compress(data []byte) ([]byte, error) {
+	return snappy.Encode(nil, data), nil
+}
+
+func (snappyCacheCompressor) Decompress(data []byte) ([]byte, error) {
+	return snappy.Decode(nil, data)
+}
@@ -2,269 +2,134 @@ package usecases
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/DKhorkov/libs/cache"
 	"github.com/DKhorkov/libs/logging"
-	"github.com/rxwycdh/rxhash"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/DKhorkov/hmtm-bff/internal/circuitbreaker"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
 )
 
 const (
-	// Prefixes:
-	getUserByIDPrefix       = "get_user_by_id"
-	getUserByEmailPrefix    = "get_user_by_email"
-	getUsersPrefix          = "users"
-	getToysPrefix           = "toys"
-	countToysPrefix         = "toys_count"
-	countMastersPrefix      = "masters_count"
-	getMasterToysPrefix     = "master_toys"
-	countMasterToysPrefix   = "master_toys_count"
-	getToyByIDPrefix        = "get_toy_by_id"
-	getMastersPrefix        = "get_masters"
-	getMasterByIDPrefix     = "get_master_by_id"
-	getMasterByUserIDPrefix = "get_master_by_user_id"
-	getCategoriesPrefix     = "get_categories"
-	getCategoryByIDPrefix   = "get_category_by_id"
-	getTagsPrefix           = "get_tags"
-	getTagByIDPrefix        = "get_tag_by_id"
-	getTicketByIDPrefix     = "get_ticket_by_id"
-	getTicketsPrefix        = "get_tickets"
-	getUserTicketsPrefix    = "get_user_tickets"
-	countTicketsPrefix      = "tickets_count"
-	countUserTicketsPrefix  = "user_tickets_count"
-
-	// TTls:
-	getUserByIDTTL       = time.Hour * 24
-	getUserByEmailTTL    = time.Hour * 24
-	getUsersTTL          = time.Minute * 5
-	getToysTTL           = time.Minute * 5
-	countToysTTL         = time.Minute * 5
-	countMastersTTL      = time.Minute * 5
-	getMasterToysTTL     = time.Hour * 6
-	countMasterToysTTL   = time.Hour * 6
-	getToyByIDTTL        = time.Hour * 24
-	getMastersTTL        = time.Hour * 6
-	getMasterByIDTTL     = time.Hour * 24
-	getMasterByUserIDTTL = time.Hour * 24
-	getCategoriesTTL     = time.Hour * 24
-	getCategoryByIDTTL   = time.Hour * 24
-	getTagsTTL           = time.Hour * 24
-	getTagByIDTTL        = time.Hour * 24
-	getTicketByIDTTL     = time.Hour * 24
-	getTicketsTTL        = time.Minute * 5
-	getUserTicketsTTL    = time.Minute * 5
-	countTicketsTTL      = time.Minute * 5
-	countUserTicketsTTL  = time.Hour * 6
+	// Tags, which are shared by all cached lists of entities:
+	usersListTag   = "users:list"
+	toysListTag    = "toys:list"
+	mastersListTag = "masters:list"
+	ticketsListTag = "tickets:list"
+	categoriesTag  = "categories:all"
+	tagsTag        = "tags:all"
+
+	// Tags of remembered NotFound results, which are invalidated by creation of entities:
+	usersNotFoundTag   = "users:not_found"
+	toysNotFoundTag    = "toys:not_found"
+	mastersNotFoundTag = "masters:not_found"
+	ticketsNotFoundTag = "tickets:not_found"
 )
 
 func NewCacheDecorator(
 	useCases interfaces.UseCases,
 	cacheProvider cache.Provider,
+	localCacheRepository interfaces.LocalCacheRepository,
+	cacheTagsRepository interfaces.CacheTagsRepository,
+	locksRepository interfaces.LocksRepository,
+	cacheConfig config.CacheConfig,
 	logger logging.Logger,
-) *CacheDecorator {
-	return &CacheDecorator{
-		UseCases:      useCases,
-		logger:        logger,
-		cacheProvider: cacheProvider,
-	}
-}
-
-type CacheDecorator struct {
-	interfaces.UseCases
-	cacheProvider cache.Provider
-	logger        logging.Logger
-}
-
-func (c *CacheDecorator) GetUserByID(ctx context.Context, id uint64) (*entities.User, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetUserByID(ctx, id)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getUserByIDPrefix, id)
-	userToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var user entities.User
-		if err = json.Unmarshal([]byte(userToDecode), &user); err == nil {
-			return &user, nil
-		}
+	registerer prometheus.Registerer,
+) (*CacheDecorator, error) {
+	if err := validateCachePolicies(cacheConfig); err != nil {
+		return nil, err
 	}
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached User with id=%d", id),
-		err,
-	)
-
-	user, err := c.UseCases.GetUserByID(ctx, id)
+	serializer, err := newCacheSerializer(cacheConfig.Encoding.Codec)
 	if err != nil {
 		return nil, err
 	}
 
-	encodedUser, err := json.Marshal(user)
+	compressor, err := newCacheCompressor(cacheConfig.Encoding.Compression)
 	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache User with id=%d", id),
-			err,
-		)
-
-		return user, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedUser, getUserByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache User with id=%d", id),
-			err,
-		)
-	}
-
-	return user, nil
-}
-
-func (c *CacheDecorator) GetUserByEmail(
-	ctx context.Context,
-	email string,
-) (*entities.User, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetUserByEmail(ctx, email)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%s", getUserByEmailPrefix, email)
-	userToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var user entities.User
-		if err = json.Unmarshal([]byte(userToDecode), &user); err == nil {
-			return &user, nil
-		}
+		return nil, err
 	}
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached User with email=%s", email),
-		err,
-	)
-
-	user, err := c.UseCases.GetUserByEmail(ctx, email)
+	metrics, err := newCacheMetrics(registerer)
 	if err != nil {
 		return nil, err
 	}
 
-	encodedUser, err := json.Marshal(user)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache User with email=%s", email),
-			err,
-		)
-
-		return user, nil
-	}
+	circuitBreaker := circuitbreaker.New(
+		cacheConfig.CircuitBreaker,
+		func(from, to circuitbreaker.State) {
+			metrics.circuitBreakerState.Set(float64(to))
+			logging.LogInfo(logger, fmt.Sprintf("Cache circuit breaker changed state from %s to %s", from, to))
+		},
+	)
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedUser, getUserByEmailTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache User with email=%s", email),
-			err,
+	var accessTokens *expirable.LRU[string, uint64]
+	if cacheConfig.AccessTokens.TTL > 0 {
+		accessTokens = expirable.NewLRU[string, uint64](
+			cacheConfig.AccessTokens.Size,
+			nil,
+			cacheConfig.AccessTokens.TTL,
 		)
 	}
 
-	return user, nil
+	return &CacheDecorator{
+		UseCases:             useCases,
+		logger:               logger,
+		cacheProvider:        cacheProvider,
+		localCacheRepository: localCacheRepository,
+		cacheTagsRepository:  cacheTagsRepository,
+		locksRepository:      locksRepository,
+		config:               cacheConfig,
+		serializer:           serializer,
+		compressor:           compressor,
+		circuitBreaker:       circuitBreaker,
+		metrics:              metrics,
+		accessTokens:         accessTokens,
+	}, nil
 }
 
-func (c *CacheDecorator) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetUsers(ctx, pagination)
-	}
-
-	paginationHash, err := rxhash.HashStruct(pagination)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Users",
-			err,
-		)
-
-		return c.UseCases.GetUsers(ctx, pagination)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%s", getUsersPrefix, paginationHash)
-	usersToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var users []entities.User
-		if err = json.Unmarshal([]byte(usersToDecode), &users); err == nil {
-			return users, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Users",
-		err,
-	)
-
-	users, err := c.UseCases.GetUsers(ctx, pagination)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedUsers, err := json.Marshal(users)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Users",
-			err,
-		)
+// CacheDecorator caches results of UseCases. Each cached value is attached to dependency tags,
+// which are invalidated by mutations of related entities. Values, which are read by almost every
+// request, are additionally cached in process memory in front of Redis. Redis is not called, while
+// circuit breaker is open after consecutive failures. Values are stored in versioned binary entries,
+// which are compressed above configured size.
+type CacheDecorator struct {
+	interfaces.UseCases
+	cacheProvider        cache.Provider
+	localCacheRepository interfaces.LocalCacheRepository
+	cacheTagsRepository  interfaces.CacheTagsRepository
+	locksRepository      interfaces.LocksRepository
+	config               config.CacheConfig
+	serializer           cacheSerializer
+	compressor           cacheCompressor // Is nil, if compression is disabled
+	circuitBreaker       *circuitbreaker.CircuitBreaker
+	metrics              *cacheMetrics
+	loads                singleflight.Group
+	logger               logging.Logger
+
+	// Owners of recently used access tokens by hashes of tokens. Is nil, if cache of tokens is disabled:
+	accessTokens *expirable.LRU[string, uint64]
+}
 
-		return users, nil
-	}
+func (c *CacheDecorator) GetUserByID(ctx context.Context, id uint64) (*entities.User, error) {
+	return cached(ctx, c, getUserByIDPolicy, id)
+}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedUsers, getUsersTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Users",
-			err,
-		)
-	}
+func (c *CacheDecorator) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return cached(ctx, c, getUserByEmailPolicy, email)
+}
 
-	return users, nil
+func (c *CacheDecorator) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
+	return cached(ctx, c, getUsersPolicy, pagination)
 }
 
 func (c *CacheDecorator) GetToys(
@@ -272,1513 +137,529 @@ func (c *CacheDecorator) GetToys(
 	pagination *entities.Pagination,
 	filters *entities.ToysFilters,
 ) ([]entities.Toy, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetToys(ctx, pagination, filters)
-	}
-
-	paginationHash, err := rxhash.HashStruct(pagination)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Toys",
-			err,
-		)
-
-		return c.UseCases.GetToys(ctx, pagination, filters)
-	}
-
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Toys",
-			err,
-		)
+	return cached(ctx, c, getToysPolicy, listArgs[*entities.ToysFilters]{pagination: pagination, filters: filters})
+}
 
-		return c.UseCases.GetToys(ctx, pagination, filters)
-	}
+func (c *CacheDecorator) CountToys(ctx context.Context, filters *entities.ToysFilters) (uint64, error) {
+	return cached(ctx, c, countToysPolicy, filters)
+}
 
-	cacheKey := fmt.Sprintf(
-		"%s:%s_%s",
-		getToysPrefix,
-		paginationHash,
-		filtersHash,
+func (c *CacheDecorator) CountMasterToys(
+	ctx context.Context,
+	masterID uint64,
+	filters *entities.ToysFilters,
+) (uint64, error) {
+	return cached(
+		ctx,
+		c,
+		countMasterToysPolicy,
+		listArgs[*entities.ToysFilters]{ownerID: masterID, filters: filters},
 	)
+}
 
-	toysToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var toys []entities.Toy
-		if err = json.Unmarshal([]byte(toysToDecode), &toys); err == nil {
-			return toys, nil
-		}
-	}
-
-	logging.LogErrorContext(
+func (c *CacheDecorator) GetMasterToys(
+	ctx context.Context,
+	masterID uint64,
+	pagination *entities.Pagination,
+	filters *entities.ToysFilters,
+) ([]entities.Toy, error) {
+	return cached(
 		ctx,
-		c.logger,
-		"Failed to get cached Toys",
-		err,
+		c,
+		getMasterToysPolicy,
+		listArgs[*entities.ToysFilters]{ownerID: masterID, pagination: pagination, filters: filters},
 	)
+}
 
-	toys, err := c.UseCases.GetToys(ctx, pagination, filters)
-	if err != nil {
-		return nil, err
-	}
+func (c *CacheDecorator) GetToyByID(ctx context.Context, id uint64) (*entities.Toy, error) {
+	return cached(ctx, c, getToyByIDPolicy, id)
+}
 
-	encodedToys, err := json.Marshal(toys)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Toys",
-			err,
-		)
+func (c *CacheDecorator) GetMasters(
+	ctx context.Context,
+	pagination *entities.Pagination,
+	filters *entities.MastersFilters,
+) ([]entities.Master, error) {
+	return cached(
+		ctx,
+		c,
+		getMastersPolicy,
+		listArgs[*entities.MastersFilters]{pagination: pagination, filters: filters},
+	)
+}
 
-		return toys, nil
-	}
+func (c *CacheDecorator) CountMasters(ctx context.Context, filters *entities.MastersFilters) (uint64, error) {
+	return cached(ctx, c, countMastersPolicy, filters)
+}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedToys, getToysTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Toys",
-			err,
-		)
-	}
+func (c *CacheDecorator) GetMasterByID(ctx context.Context, id uint64) (*entities.Master, error) {
+	return cached(ctx, c, getMasterByIDPolicy, id)
+}
 
-	return toys, nil
+func (c *CacheDecorator) GetMasterByUserID(ctx context.Context, userID uint64) (*entities.Master, error) {
+	return cached(ctx, c, getMasterByUserIDPolicy, userID)
 }
 
-func (c *CacheDecorator) CountToys(ctx context.Context, filters *entities.ToysFilters) (uint64, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
+func (c *CacheDecorator) GetAllCategories(ctx context.Context) ([]entities.Category, error) {
+	return cached(ctx, c, getCategoriesPolicy, struct{}{})
+}
 
-		return c.UseCases.CountToys(ctx, filters)
-	}
+func (c *CacheDecorator) GetCategoryByID(ctx context.Context, id uint32) (*entities.Category, error) {
+	return cached(ctx, c, getCategoryByIDPolicy, id)
+}
 
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Toys counter",
-			err,
-		)
+func (c *CacheDecorator) GetAllTags(ctx context.Context) ([]entities.Tag, error) {
+	return cached(ctx, c, getTagsPolicy, struct{}{})
+}
 
-		return c.UseCases.CountToys(ctx, filters)
-	}
+func (c *CacheDecorator) GetTagByID(ctx context.Context, id uint32) (*entities.Tag, error) {
+	return cached(ctx, c, getTagByIDPolicy, id)
+}
 
-	cacheKey := fmt.Sprintf("%s:%s", countToysPrefix, filtersHash)
-	strCounter, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		if counter, err := strconv.ParseUint(strCounter, 10, 64); err == nil {
-			return counter, nil
-		}
-	}
+func (c *CacheDecorator) GetTicketByID(ctx context.Context, id uint64) (*entities.Ticket, error) {
+	return cached(ctx, c, getTicketByIDPolicy, id)
+}
 
-	logging.LogErrorContext(
+func (c *CacheDecorator) GetTickets(
+	ctx context.Context,
+	pagination *entities.Pagination,
+	filters *entities.TicketsFilters,
+) ([]entities.Ticket, error) {
+	return cached(
 		ctx,
-		c.logger,
-		"Failed to get cached Toys counter",
-		err,
+		c,
+		getTicketsPolicy,
+		listArgs[*entities.TicketsFilters]{pagination: pagination, filters: filters},
 	)
+}
 
-	counter, err := c.UseCases.CountToys(ctx, filters)
-	if err != nil {
-		return 0, err
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, counter, countToysTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Toys counter",
-			err,
-		)
-	}
+func (c *CacheDecorator) GetUserTickets(
+	ctx context.Context,
+	userID uint64,
+	pagination *entities.Pagination,
+	filters *entities.TicketsFilters,
+) ([]entities.Ticket, error) {
+	return cached(
+		ctx,
+		c,
+		getUserTicketsPolicy,
+		listArgs[*entities.TicketsFilters]{ownerID: userID, pagination: pagination, filters: filters},
+	)
+}
 
-	return counter, nil
+func (c *CacheDecorator) CountTickets(ctx context.Context, filters *entities.TicketsFilters) (uint64, error) {
+	return cached(ctx, c, countTicketsPolicy, filters)
 }
 
-func (c *CacheDecorator) CountMasterToys(
+func (c *CacheDecorator) CountUserTickets(
 	ctx context.Context,
-	masterID uint64,
-	filters *entities.ToysFilters,
+	userID uint64,
+	filters *entities.TicketsFilters,
 ) (uint64, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
+	return cached(
+		ctx,
+		c,
+		countUserTicketsPolicy,
+		listArgs[*entities.TicketsFilters]{ownerID: userID, filters: filters},
+	)
+}
 
-		return c.UseCases.CountMasterToys(ctx, masterID, filters)
+func (c *CacheDecorator) RegisterUser(ctx context.Context, userData entities.RegisterUserDTO) (uint64, error) {
+	userID, err := c.UseCases.RegisterUser(ctx, userData)
+	if err != nil {
+		return 0, err
 	}
 
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to get cached Toys counter for Master with id=%d", masterID),
-			err,
-		)
-
-		return c.UseCases.CountMasterToys(ctx, masterID, filters)
-	}
-
-	cacheKey := fmt.Sprintf(
-		"%s:%d_%s",
-		countMasterToysPrefix,
-		masterID,
-		filtersHash,
-	)
-
-	strCounter, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		if counter, err := strconv.ParseUint(strCounter, 10, 64); err == nil {
-			return counter, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Toys counter for Master with id=%d", masterID),
-		err,
-	)
-
-	counter, err := c.UseCases.CountMasterToys(ctx, masterID, filters)
-	if err != nil {
-		return 0, err
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, counter, countMasterToysTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Toys counter for Master with id=%d", masterID),
-			err,
-		)
-	}
-
-	return counter, nil
-}
-
-func (c *CacheDecorator) GetMasterToys(
-	ctx context.Context,
-	masterID uint64,
-	pagination *entities.Pagination,
-	filters *entities.ToysFilters,
-) ([]entities.Toy, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetMasterToys(ctx, masterID, pagination, filters)
-	}
-
-	paginationHash, err := rxhash.HashStruct(pagination)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to get cached Toys for Master with id=%d", masterID),
-			err,
-		)
-
-		return c.UseCases.GetMasterToys(ctx, masterID, pagination, filters)
-	}
-
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to get cached Toys for Master with id=%d", masterID),
-			err,
-		)
-
-		return c.UseCases.GetMasterToys(ctx, masterID, pagination, filters)
-	}
-
-	cacheKey := fmt.Sprintf(
-		"%s:%d_%s_%s",
-		getMasterToysPrefix,
-		masterID,
-		paginationHash,
-		filtersHash,
-	)
-
-	toysToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var toys []entities.Toy
-		if err = json.Unmarshal([]byte(toysToDecode), &toys); err == nil {
-			return toys, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Toys for Master with id=%d", masterID),
-		err,
-	)
-
-	toys, err := c.UseCases.GetMasterToys(ctx, masterID, pagination, filters)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedToys, err := json.Marshal(toys)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Toys for Master with id=%d", masterID),
-			err,
-		)
-
-		return toys, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedToys, getMasterToysTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Toys for Master with id=%d", masterID),
-			err,
-		)
-	}
-
-	return toys, nil
-}
-
-func (c *CacheDecorator) GetToyByID(ctx context.Context, id uint64) (*entities.Toy, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetToyByID(ctx, id)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPrefix, id)
-	toyToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var toy entities.Toy
-		if err = json.Unmarshal([]byte(toyToDecode), &toy); err == nil {
-			return &toy, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Toy with id=%d", id),
-		err,
-	)
-
-	toy, err := c.UseCases.GetToyByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedToy, err := json.Marshal(toy)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Toy with id=%d", id),
-			err,
-		)
-
-		return toy, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedToy, getToyByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Toy with id=%d", id),
-			err,
-		)
-	}
+	c.invalidate(ctx, userTag(userID), usersListTag, usersNotFoundTag)
 
-	return toy, nil
+	return userID, nil
 }
 
-func (c *CacheDecorator) GetMasters(
-	ctx context.Context,
-	pagination *entities.Pagination,
-	filters *entities.MastersFilters,
-) ([]entities.Master, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetMasters(ctx, pagination, filters)
-	}
-
-	paginationHash, err := rxhash.HashStruct(pagination)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Masters",
-			err,
-		)
-
-		return c.UseCases.GetMasters(ctx, pagination, filters)
-	}
-
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Masters",
-			err,
-		)
-
-		return c.UseCases.GetMasters(ctx, pagination, filters)
-	}
-
-	cacheKey := fmt.Sprintf(
-		"%s:%s_%s",
-		getMastersPrefix,
-		paginationHash,
-		filtersHash,
-	)
-
-	mastersToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var masters []entities.Master
-		if err = json.Unmarshal([]byte(mastersToDecode), &masters); err == nil {
-			return masters, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Masters",
-		err,
-	)
-
-	masters, err := c.UseCases.GetMasters(ctx, pagination, filters)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedMasters, err := json.Marshal(masters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Masters",
-			err,
-		)
-
-		return masters, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedMasters, getMastersTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Masters",
-			err,
-		)
-	}
-
-	return masters, nil
-}
-
-func (c *CacheDecorator) CountMasters(ctx context.Context, filters *entities.MastersFilters) (uint64, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.CountMasters(ctx, filters)
-	}
-
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to get cached Masters counter",
-			err,
-		)
-
-		return c.UseCases.CountMasters(ctx, filters)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%s", countMastersPrefix, filtersHash)
-	strCounter, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		if counter, err := strconv.ParseUint(strCounter, 10, 64); err == nil {
-			return counter, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Masters counter",
-		err,
-	)
-
-	counter, err := c.UseCases.CountMasters(ctx, filters)
-	if err != nil {
-		return 0, err
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, counter, countMastersTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Masters counter",
-			err,
-		)
-	}
-
-	return counter, nil
-}
-
-func (c *CacheDecorator) GetMasterByID(ctx context.Context, id uint64) (*entities.Master, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetMasterByID(ctx, id)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getMasterByIDPrefix, id)
-	masterToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var master entities.Master
-		if err = json.Unmarshal([]byte(masterToDecode), &master); err == nil {
-			return &master, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Master with id=%d", id),
-		err,
-	)
-
-	master, err := c.UseCases.GetMasterByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedMaster, err := json.Marshal(master)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Master with id=%d", id),
-			err,
-		)
-
-		return master, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedMaster, getMasterByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Master with id=%d", id),
-			err,
-		)
-	}
-
-	return master, nil
-}
-
-func (c *CacheDecorator) GetMasterByUserID(ctx context.Context, userID uint64) (*entities.Master, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetMasterByUserID(ctx, userID)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getMasterByUserIDPrefix, userID)
-	masterToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var master entities.Master
-		if err = json.Unmarshal([]byte(masterToDecode), &master); err == nil {
-			return &master, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Master with userID=%d", userID),
-		err,
-	)
-
-	master, err := c.UseCases.GetMasterByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedMaster, err := json.Marshal(master)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Master with userID=%d", userID),
-			err,
-		)
-
-		return master, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedMaster, getMasterByUserIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Master with userID=%d", userID),
-			err,
-		)
-	}
-
-	return master, nil
-}
-
-func (c *CacheDecorator) GetAllCategories(ctx context.Context) ([]entities.Category, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetAllCategories(ctx)
-	}
-
-	cacheKey := getCategoriesPrefix
-	categoriesToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var categories []entities.Category
-		if err = json.Unmarshal([]byte(categoriesToDecode), &categories); err == nil {
-			return categories, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Categories",
-		err,
-	)
-
-	categories, err := c.UseCases.GetAllCategories(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedCategories, err := json.Marshal(categories)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Categories",
-			err,
-		)
-
-		return categories, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedCategories, getCategoriesTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Categories",
-			err,
-		)
-	}
-
-	return categories, nil
-}
-
-func (c *CacheDecorator) GetCategoryByID(ctx context.Context, id uint32) (*entities.Category, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetCategoryByID(ctx, id)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPrefix, id)
-	categoryToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var category entities.Category
-		if err = json.Unmarshal([]byte(categoryToDecode), &category); err == nil {
-			return &category, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Category with id=%d", id),
-		err,
-	)
-
-	category, err := c.UseCases.GetCategoryByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedCategory, err := json.Marshal(category)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Category with id=%d", id),
-			err,
-		)
-
-		return category, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedCategory, getCategoryByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Category with id=%d", id),
-			err,
-		)
-	}
-
-	return category, nil
-}
-
-func (c *CacheDecorator) GetAllTags(ctx context.Context) ([]entities.Tag, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetAllTags(ctx)
-	}
-
-	cacheKey := getTagsPrefix
-	tagsToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var tags []entities.Tag
-		if err = json.Unmarshal([]byte(tagsToDecode), &tags); err == nil {
-			return tags, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Tags",
-		err,
-	)
-
-	tags, err := c.UseCases.GetAllTags(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	encodedTags, err := json.Marshal(tags)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Tags",
-			err,
-		)
-
-		return tags, nil
-	}
-
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedTags, getTagsTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Tags",
-			err,
-		)
-	}
-
-	return tags, nil
-}
-
-func (c *CacheDecorator) GetTagByID(ctx context.Context, id uint32) (*entities.Tag, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetTagByID(ctx, id)
-	}
-
-	cacheKey := fmt.Sprintf("%s:%d", getTagByIDPrefix, id)
-	tagToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var tag entities.Tag
-		if err = json.Unmarshal([]byte(tagToDecode), &tag); err == nil {
-			return &tag, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Tag with id=%d", id),
-		err,
-	)
-
-	tag, err := c.UseCases.GetTagByID(ctx, id)
-	if err != nil {
-		return nil, err
+func (c *CacheDecorator) UpdateUserProfile(
+	ctx context.Context,
+	userToDecodeProfileData entities.RawUpdateUserProfileDTO,
+) error {
+	if err := c.UseCases.UpdateUserProfile(ctx, userToDecodeProfileData); err != nil {
+		return err
 	}
 
-	encodedTag, err := json.Marshal(tag)
+	user, err := c.UseCases.GetMe(ctx, userToDecodeProfileData.AccessToken)
 	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Tag with id=%d", id),
-			err,
-		)
+		logging.LogErrorContext(ctx, c.logger, "Failed to get current User to invalidate cache", err)
 
-		return tag, nil
+		return nil
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedTag, getTagByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Tag with id=%d", id),
-			err,
-		)
-	}
+	c.invalidate(ctx, userTag(user.ID), usersListTag)
 
-	return tag, nil
+	return nil
 }
 
-func (c *CacheDecorator) GetTicketByID(ctx context.Context, id uint64) (*entities.Ticket, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetTicketByID(ctx, id)
+// LogoutUser forgets owner of access token, so that cached data of User is not available by revoked token.
+// Other instances of BFF remember owner until TTL of access tokens cache passes.
+func (c *CacheDecorator) LogoutUser(ctx context.Context, accessToken string) error {
+	if c.accessTokens != nil {
+		c.accessTokens.Remove(accessTokenHash(accessToken))
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d", getTicketByIDPrefix, id)
-	ticketToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var ticket entities.Ticket
-		if err = json.Unmarshal([]byte(ticketToDecode), &ticket); err == nil {
-			return &ticket, nil
-		}
-	}
-
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Ticket with id=%d", id),
-		err,
-	)
+	return c.UseCases.LogoutUser(ctx, accessToken)
+}
 
-	ticket, err := c.UseCases.GetTicketByID(ctx, id)
+func (c *CacheDecorator) AddToy(ctx context.Context, rawToyData entities.RawAddToyDTO) (uint64, error) {
+	toyID, err := c.UseCases.AddToy(ctx, rawToyData)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	encodedTicket, err := json.Marshal(ticket)
+	tags := []string{toysListTag, tagsTag, toysNotFoundTag}
+
+	toy, err := c.UseCases.GetToyByID(ctx, toyID)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			fmt.Sprintf("Failed to cache Ticket with id=%d", id),
+			fmt.Sprintf("Failed to get Toy with ID=%d to invalidate cache", toyID),
 			err,
 		)
-
-		return ticket, nil
+	} else {
+		tags = append(tags, masterTag(toy.MasterID))
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedTicket, getTicketByIDTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Ticket with id=%d", id),
-			err,
-		)
-	}
+	c.invalidate(ctx, tags...)
 
-	return ticket, nil
+	return toyID, nil
 }
 
-func (c *CacheDecorator) GetTickets(
+func (c *CacheDecorator) UpdateToy(
 	ctx context.Context,
-	pagination *entities.Pagination,
-	filters *entities.TicketsFilters,
-) ([]entities.Ticket, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetTickets(ctx, pagination, filters)
+	rawToyData entities.RawUpdateToyDTO,
+) error {
+	if err := c.UseCases.UpdateToy(ctx, rawToyData); err != nil {
+		return err
 	}
 
-	paginationHash, err := rxhash.HashStruct(pagination)
+	tags := []string{toyTag(rawToyData.ID), toysListTag, tagsTag}
+
+	toy, err := c.UseCases.GetToyByID(ctx, rawToyData.ID)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			"Failed to get cached Tickets",
+			fmt.Sprintf("Failed to get Toy with ID=%d to invalidate cache", rawToyData.ID),
 			err,
 		)
-
-		return c.UseCases.GetTickets(ctx, pagination, filters)
+	} else {
+		tags = append(tags, masterTag(toy.MasterID))
 	}
 
-	filtersHash, err := rxhash.HashStruct(filters)
+	c.invalidate(ctx, tags...)
+
+	return nil
+}
+
+func (c *CacheDecorator) DeleteToy(ctx context.Context, accessToken string, id uint64) error {
+	tags := []string{toyTag(id), toysListTag}
+
+	// Toy is received before deletion, because Master's cache could not be found out afterward:
+	toy, err := c.UseCases.GetToyByID(ctx, id)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			"Failed to get cached Tickets",
+			fmt.Sprintf("Failed to get Toy with ID=%d to invalidate cache", id),
 			err,
 		)
-
-		return c.UseCases.GetTickets(ctx, pagination, filters)
+	} else {
+		tags = append(tags, masterTag(toy.MasterID))
 	}
 
-	cacheKey := fmt.Sprintf(
-		"%s:%s_%s",
-		getTicketsPrefix,
-		paginationHash,
-		filtersHash,
-	)
-
-	ticketsToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var tickets []entities.Ticket
-		if err = json.Unmarshal([]byte(ticketsToDecode), &tickets); err == nil {
-			return tickets, nil
-		}
+	if err = c.UseCases.DeleteToy(ctx, accessToken, id); err != nil {
+		return err
 	}
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Tickets",
-		err,
-	)
+	c.invalidate(ctx, tags...)
 
-	tickets, err := c.UseCases.GetTickets(ctx, pagination, filters)
-	if err != nil {
-		return nil, err
-	}
+	return nil
+}
 
-	encodedTickets, err := json.Marshal(tickets)
+func (c *CacheDecorator) RegisterMaster(
+	ctx context.Context,
+	rawMasterData entities.RawRegisterMasterDTO,
+) (uint64, error) {
+	masterID, err := c.UseCases.RegisterMaster(ctx, rawMasterData)
 	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Tickets",
-			err,
-		)
-
-		return tickets, nil
+		return 0, err
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedTickets, getTicketsTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Tickets",
-			err,
-		)
-	}
+	c.invalidate(ctx, masterTag(masterID), mastersListTag, mastersNotFoundTag)
 
-	return tickets, nil
+	return masterID, nil
 }
 
-func (c *CacheDecorator) GetUserTickets(
+func (c *CacheDecorator) UpdateMaster(
 	ctx context.Context,
-	userID uint64,
-	pagination *entities.Pagination,
-	filters *entities.TicketsFilters,
-) ([]entities.Ticket, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.GetUserTickets(ctx, userID, pagination, filters)
+	rawMasterData entities.RawUpdateMasterDTO,
+) error {
+	if err := c.UseCases.UpdateMaster(ctx, rawMasterData); err != nil {
+		return err
 	}
 
-	paginationHash, err := rxhash.HashStruct(pagination)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to get cached Tickets for User with id=%d", userID),
-			err,
-		)
+	c.invalidate(ctx, masterTag(rawMasterData.ID), mastersListTag)
+
+	return nil
+}
 
-		return c.UseCases.GetUserTickets(ctx, userID, pagination, filters)
+func (c *CacheDecorator) CreateTicket(
+	ctx context.Context,
+	rawTicketData entities.RawCreateTicketDTO,
+) (uint64, error) {
+	ticketID, err := c.UseCases.CreateTicket(ctx, rawTicketData)
+	if err != nil {
+		return 0, err
 	}
 
-	filtersHash, err := rxhash.HashStruct(filters)
+	tags := []string{ticketsListTag, tagsTag, ticketsNotFoundTag}
+
+	ticket, err := c.UseCases.GetTicketByID(ctx, ticketID)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			fmt.Sprintf("Failed to get cached Tickets for User with id=%d", userID),
+			fmt.Sprintf("Failed to get Ticket with ID=%d to invalidate cache", ticketID),
 			err,
 		)
-
-		return c.UseCases.GetUserTickets(ctx, userID, pagination, filters)
+	} else {
+		tags = append(tags, userTicketsTag(ticket.UserID))
 	}
 
-	cacheKey := fmt.Sprintf(
-		"%s:%d_%s_%s",
-		getUserTicketsPrefix,
-		userID,
-		paginationHash,
-		filtersHash,
-	)
-
-	ticketsToDecode, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		var tickets []entities.Ticket
-		if err = json.Unmarshal([]byte(ticketsToDecode), &tickets); err == nil {
-			return tickets, nil
-		}
-	}
+	c.invalidate(ctx, tags...)
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Tickets for User with id=%d", userID),
-		err,
-	)
+	return ticketID, nil
+}
 
-	tickets, err := c.UseCases.GetUserTickets(ctx, userID, pagination, filters)
-	if err != nil {
-		return nil, err
+func (c *CacheDecorator) UpdateTicket(
+	ctx context.Context,
+	rawTicketData entities.RawUpdateTicketDTO,
+) error {
+	if err := c.UseCases.UpdateTicket(ctx, rawTicketData); err != nil {
+		return err
 	}
 
-	encodedTickets, err := json.Marshal(tickets)
+	tags := []string{ticketTag(rawTicketData.ID), ticketsListTag, tagsTag}
+
+	ticket, err := c.UseCases.GetTicketByID(ctx, rawTicketData.ID)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			fmt.Sprintf("Failed to cache Tickets for User with id=%d", userID),
+			fmt.Sprintf("Failed to get Ticket with ID=%d to invalidate cache", rawTicketData.ID),
 			err,
 		)
-
-		return tickets, nil
+	} else {
+		tags = append(tags, userTicketsTag(ticket.UserID))
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, encodedTickets, getUserTicketsTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Tickets for User with id=%d", userID),
-			err,
-		)
-	}
+	c.invalidate(ctx, tags...)
 
-	return tickets, nil
+	return nil
 }
 
-func (c *CacheDecorator) CountTickets(ctx context.Context, filters *entities.TicketsFilters) (uint64, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.CountTickets(ctx, filters)
-	}
+func (c *CacheDecorator) DeleteTicket(ctx context.Context, accessToken string, id uint64) error {
+	tags := []string{ticketTag(id), ticketsListTag}
 
-	filtersHash, err := rxhash.HashStruct(filters)
+	// Ticket is received before deletion, because owner's cache could not be found out afterward:
+	ticket, err := c.UseCases.GetTicketByID(ctx, id)
 	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			"Failed to get cached Tickets counter",
+			fmt.Sprintf("Failed to get Ticket with ID=%d to invalidate cache", id),
 			err,
 		)
-
-		return c.UseCases.CountTickets(ctx, filters)
+	} else {
+		tags = append(tags, userTicketsTag(ticket.UserID))
 	}
 
-	cacheKey := fmt.Sprintf("%s:%s", countTicketsPrefix, filtersHash)
-	strCounter, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		if counter, err := strconv.ParseUint(strCounter, 10, 64); err == nil {
-			return counter, nil
-		}
+	if err = c.UseCases.DeleteTicket(ctx, accessToken, id); err != nil {
+		return err
 	}
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		"Failed to get cached Tickets counter",
-		err,
-	)
+	c.invalidate(ctx, tags...)
+
+	return nil
+}
 
-	counter, err := c.UseCases.CountTickets(ctx, filters)
+func (c *CacheDecorator) RespondToTicket(
+	ctx context.Context,
+	rawRespondData entities.RawRespondToTicketDTO,
+) (uint64, error) {
+	respondID, err := c.UseCases.RespondToTicket(ctx, rawRespondData)
 	if err != nil {
 		return 0, err
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, counter, countTicketsTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Failed to cache Tickets counter",
-			err,
-		)
+	tags := []string{ticketTag(rawRespondData.TicketID)}
+
+	user, err := c.UseCases.GetMe(ctx, rawRespondData.AccessToken)
+	if err != nil {
+		logging.LogErrorContext(ctx, c.logger, "Failed to get current User to invalidate cache", err)
+	} else {
+		tags = append(tags, userRespondsTag(user.ID))
 	}
 
-	return counter, nil
+	c.invalidate(ctx, tags...)
+
+	return respondID, nil
 }
 
-func (c *CacheDecorator) CountUserTickets(
+func (c *CacheDecorator) GetRespondByID(
 	ctx context.Context,
-	userID uint64,
-	filters *entities.TicketsFilters,
-) (uint64, error) {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
+	id uint64,
+	accessToken string,
+) (*entities.Respond, error) {
+	return cachedForUser(ctx, c, getRespondByIDPolicy, accessToken, id)
+}
 
-		return c.UseCases.CountUserTickets(ctx, userID, filters)
-	}
+func (c *CacheDecorator) GetTicketResponds(
+	ctx context.Context,
+	ticketID uint64,
+	accessToken string,
+) ([]entities.Respond, error) {
+	return cachedForUser(ctx, c, getTicketRespondsPolicy, accessToken, ticketID)
+}
 
-	filtersHash, err := rxhash.HashStruct(filters)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to get cached Tickets counter for User with id=%d", userID),
-			err,
-		)
+func (c *CacheDecorator) GetMyResponds(ctx context.Context, accessToken string) ([]entities.Respond, error) {
+	return cachedForUser(ctx, c, getMyRespondsPolicy, accessToken, struct{}{})
+}
 
-		return c.UseCases.CountUserTickets(ctx, userID, filters)
+func (c *CacheDecorator) UpdateRespond(ctx context.Context, rawRespondData entities.RawUpdateRespondDTO) error {
+	if err := c.UseCases.UpdateRespond(ctx, rawRespondData); err != nil {
+		return err
 	}
 
-	cacheKey := fmt.Sprintf(
-		"%s:%d_%s",
-		countUserTicketsPrefix,
-		userID,
-		filtersHash,
-	)
-
-	strCounter, err := c.cacheProvider.Get(ctx, cacheKey)
-	if err == nil {
-		if counter, err := strconv.ParseUint(strCounter, 10, 64); err == nil {
-			return counter, nil
-		}
-	}
+	c.invalidate(ctx, respondTag(rawRespondData.ID))
 
-	logging.LogErrorContext(
-		ctx,
-		c.logger,
-		fmt.Sprintf("Failed to get cached Tickets counter for User with id=%d", userID),
-		err,
-	)
+	return nil
+}
 
-	counter, err := c.UseCases.CountUserTickets(ctx, userID, filters)
-	if err != nil {
-		return 0, err
+func (c *CacheDecorator) DeleteRespond(ctx context.Context, accessToken string, id uint64) error {
+	if err := c.UseCases.DeleteRespond(ctx, accessToken, id); err != nil {
+		return err
 	}
 
-	if err = c.cacheProvider.Set(ctx, cacheKey, counter, countUserTicketsTTL); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			fmt.Sprintf("Failed to cache Tickets counter for User with id=%d", userID),
-			err,
-		)
-	}
+	c.invalidate(ctx, respondTag(id))
 
-	return counter, nil
+	return nil
 }
 
-func (c *CacheDecorator) UpdateUserProfile(
+func (c *CacheDecorator) GetMyEmailCommunications(
 	ctx context.Context,
-	userToDecodeProfileData entities.RawUpdateUserProfileDTO,
-) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.UpdateUserProfile(ctx, userToDecodeProfileData)
-	}
+	accessToken string,
+	pagination *entities.Pagination,
+) ([]entities.Email, error) {
+	return cachedForUser(ctx, c, getMyEmailCommunicationsPolicy, accessToken, pagination)
+}
 
-	err := c.UseCases.UpdateUserProfile(ctx, userToDecodeProfileData)
-	if err == nil {
-		var user *entities.User
-		user, err = c.UseCases.GetMe(ctx, userToDecodeProfileData.AccessToken)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get User with AccessToken=%s to delete cache", userToDecodeProfileData.AccessToken),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getUserByIDPrefix, user.ID),
-			fmt.Sprintf("%s:%s", getUserByEmailPrefix, user.Email),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
-	}
+func (c *CacheDecorator) CountMyEmailCommunications(ctx context.Context, accessToken string) (uint64, error) {
+	return cachedForUser(ctx, c, countMyEmailCommunicationsPolicy, accessToken, struct{}{})
+}
 
-	return err
+// Ready reports state of cache circuit breaker. Cache never fails readiness, because requests are
+// served without cache, while circuit is open.
+func (c *CacheDecorator) Ready(context.Context) (string, bool) {
+	return c.circuitBreaker.State().String(), true
 }
 
-func (c *CacheDecorator) UpdateToy(
+// set caches value and attaches it to provided tags. Value is not cached, if it could not be tagged,
+// because otherwise it would not be invalidated by mutations. Value, which was loaded at loadedAt before
+// invalidation of any of its tags, is stale, so it is not cached and false is returned.
+func (c *CacheDecorator) set(
 	ctx context.Context,
-	rawToyData entities.RawUpdateToyDTO,
-) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.UpdateToy(ctx, rawToyData)
+	key string,
+	value any,
+	ttl time.Duration,
+	loadedAt time.Time,
+	tags ...string,
+) (bool, error) {
+	tagged, err := c.cacheTagsRepository.Tag(ctx, key, tags, ttl, loadedAt)
+	if err == nil && tagged {
+		err = c.cacheProvider.Set(ctx, key, value, ttl)
 	}
 
-	err := c.UseCases.UpdateToy(ctx, rawToyData)
-	if err == nil {
-		var toy *entities.Toy
-		toy, err = c.UseCases.GetToyByID(ctx, rawToyData.ID)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get Toy with ID=%d to delete cache", rawToyData.ID),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getToyByIDPrefix, toy.ID),
-			fmt.Sprintf("%s:%d*", getMasterToysPrefix, toy.MasterID),
-			fmt.Sprintf("%s:%d*", countMasterToysPrefix, toy.MasterID),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
-	}
+	c.observe(err)
 
-	return err
+	return tagged && err == nil, err
 }
 
-func (c *CacheDecorator) DeleteToy(ctx context.Context, accessToken string, id uint64) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
+// invalidate deletes all cached values, attached to provided tags. Error is only logged, because
+// mutation has already been processed. Local cache tiers of other instances are purged after
+// receiving invalidation broadcast.
+func (c *CacheDecorator) invalidate(ctx context.Context, tags ...string) {
+	if c.config.Local.Enabled {
+		c.localCacheRepository.Invalidate(tags...)
+	}
+
+	if !c.circuitBreaker.Allow() {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			"Cache provider error",
-			err,
+			fmt.Sprintf("Failed to invalidate cache by tags %v", tags),
+			errCacheCircuitOpen,
 		)
 
-		return c.UseCases.DeleteToy(ctx, accessToken, id)
+		return
 	}
 
-	err := c.UseCases.DeleteToy(ctx, accessToken, id)
-	if err == nil {
-		var toy *entities.Toy
-		toy, err = c.UseCases.GetToyByID(ctx, id)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get Toy with ID=%d to delete cache", id),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getToyByIDPrefix, toy.ID),
-			fmt.Sprintf("%s:%d*", getMasterToysPrefix, toy.MasterID),
-			fmt.Sprintf("%s:%d*", countMasterToysPrefix, toy.MasterID),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
-	}
-
-	return err
-}
+	err := c.cacheTagsRepository.Invalidate(ctx, tags...)
+	c.observe(err)
 
-func (c *CacheDecorator) UpdateMaster(
-	ctx context.Context,
-	rawMasterData entities.RawUpdateMasterDTO,
-) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
+	if err != nil {
 		logging.LogErrorContext(
 			ctx,
 			c.logger,
-			"Cache provider error",
+			fmt.Sprintf("Failed to invalidate cache by tags %v", tags),
 			err,
 		)
-
-		return c.UseCases.UpdateMaster(ctx, rawMasterData)
 	}
+}
 
-	err := c.UseCases.UpdateMaster(ctx, rawMasterData)
-	if err == nil {
-		var master *entities.Master
-		master, err = c.UseCases.GetMasterByID(ctx, rawMasterData.ID)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get Master with ID=%d to delete cache", rawMasterData.ID),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getMasterByIDPrefix, master.ID),
-			fmt.Sprintf("%s:%d", getMasterByUserIDPrefix, master.UserID),
-			fmt.Sprintf("%s:%d*", getMasterToysPrefix, master.ID),
-			fmt.Sprintf("%s:%d*", countMasterToysPrefix, master.ID),
-			fmt.Sprintf("%s*", getMastersPrefix),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
+// observe reports result of Redis call to circuit breaker. Missing key is not a failure. Call, which was
+// canceled or ran out of budget of operation, tells nothing about health of Redis.
+func (c *CacheDecorator) observe(err error) {
+	switch {
+	case err == nil || errors.Is(err, redis.Nil):
+		c.circuitBreaker.Success()
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		c.circuitBreaker.Cancel()
+	default:
+		c.circuitBreaker.Failure()
 	}
-
-	return err
 }
 
-func (c *CacheDecorator) UpdateTicket(
-	ctx context.Context,
-	rawTicketData entities.RawUpdateTicketDTO,
-) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
-
-		return c.UseCases.UpdateTicket(ctx, rawTicketData)
-	}
+func userTag(id uint64) string {
+	return fmt.Sprintf("user:%d", id)
+}
 
-	err := c.UseCases.UpdateTicket(ctx, rawTicketData)
-	if err == nil {
-		var ticket *entities.Ticket
-		ticket, err = c.UseCases.GetTicketByID(ctx, rawTicketData.ID)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get Ticket with ID=%d to delete cache", rawTicketData.ID),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getTicketByIDPrefix, ticket.ID),
-			fmt.Sprintf("%s:%d", getUserTicketsPrefix, ticket.UserID),
-			fmt.Sprintf("%s:%d*", countUserTicketsPrefix, ticket.UserID),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
-	}
+func toyTag(id uint64) string {
+	return fmt.Sprintf("toy:%d", id)
+}
 
-	return err
+func masterTag(id uint64) string {
+	return fmt.Sprintf("master:%d", id)
 }
 
-func (c *CacheDecorator) DeleteTicket(ctx context.Context, accessToken string, id uint64) error {
-	if _, err := c.cacheProvider.Ping(ctx); err != nil {
-		logging.LogErrorContext(
-			ctx,
-			c.logger,
-			"Cache provider error",
-			err,
-		)
+func ticketTag(id uint64) string {
+	return fmt.Sprintf("ticket:%d", id)
+}
 
-		return c.UseCases.DeleteTicket(ctx, accessToken, id)
-	}
+func userTicketsTag(userID uint64) string {
+	return fmt.Sprintf("user_tickets:%d", userID)
+}
 
-	err := c.UseCases.DeleteTicket(ctx, accessToken, id)
-	if err == nil {
-		var ticket *entities.Ticket
-		ticket, err = c.UseCases.GetTicketByID(ctx, id)
-		if err != nil {
-			logging.LogErrorContext(
-				ctx,
-				c.logger,
-				fmt.Sprintf("Failed to get Ticket with ID=%d to delete cache", id),
-				err,
-			)
-
-			return nil
-		}
-
-		patterns := []string{
-			fmt.Sprintf("%s:%d", getTicketByIDPrefix, ticket.ID),
-			fmt.Sprintf("%s:%d", getUserTicketsPrefix, ticket.UserID),
-			fmt.Sprintf("%s:%d*", countUserTicketsPrefix, ticket.UserID),
-		}
-
-		for _, pattern := range patterns {
-			if cacheErr := c.cacheProvider.DelByPattern(ctx, pattern, nil); cacheErr != nil {
-				logging.LogErrorContext(
-					ctx,
-					c.logger,
-					fmt.Sprintf("Failed to delete cache by pattern %s", pattern),
-					err,
-				)
-			}
-		}
-	}
+func respondTag(id uint64) string {
+	return fmt.Sprintf("respond:%d", id)
+}
 
-	return err
+func userRespondsTag(userID uint64) string {
+	return fmt.Sprintf("user_responds:%d", userID)
 }
@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DKhorkov/libs/pointers"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/DKhorkov/hmtm-bff/internal/config"
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
 	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
 	mockcache "github.com/DKhorkov/libs/cache/mocks"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
@@ -21,11 +27,23 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	userID := uint64(1)
 	user := &entities.User{ID: userID, DisplayName: "Test User"}
-	cacheKey := fmt.Sprintf("%s:%d", getUserByIDPrefix, userID)
+	cacheKey := fmt.Sprintf("%s:%d", getUserByIDPolicy.name, userID)
 
 	testCases := []struct {
 		name          string
@@ -41,16 +59,10 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 			cacheValue:   `{"id":1,"DisplayName":"Cached User"}`,
 			expectedUser: &entities.User{ID: 1, DisplayName: "Cached User"},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"DisplayName":"Cached User"}`, nil).
+					Return(cachedValue(`{"id":1,"DisplayName":"Cached User"}`), nil).
 					Times(1)
 			},
 		},
@@ -59,12 +71,6 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 			userID:       userID,
 			expectedUser: user,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -82,22 +88,28 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{userTag(userID)}, getUserByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByIDPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:         "cache ping error, success from db",
-			userID:       userID,
-			expectedUser: user,
+			name:          "db error",
+			userID:        userID,
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
 				cacheMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
 					Times(1)
 
 				loggerMock.
@@ -108,21 +120,15 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 				useCasesMock.
 					EXPECT().
 					GetUserByID(gomock.Any(), userID).
-					Return(user, nil).
+					Return(nil, errors.New("db error")).
 					Times(1)
 			},
 		},
 		{
-			name:          "db error",
-			userID:        userID,
-			expectedError: errors.New("db error"),
+			name:         "set cache error",
+			userID:       userID,
+			expectedUser: user,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -132,26 +138,32 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
+					Times(2) // One for cache miss, one for set cache error
 
 				useCasesMock.
 					EXPECT().
 					GetUserByID(gomock.Any(), userID).
-					Return(nil, errors.New("db error")).
+					Return(user, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{userTag(userID)}, getUserByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByIDPolicy.ttl).
+					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
 		{
-			name:         "set cache error",
+			name:         "tag cache error, value is not cached",
 			userID:       userID,
 			expectedUser: user,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -161,7 +173,7 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(2) // One for cache miss, one for set cache error
+					Times(2) // One for cache miss, one for tag cache error
 
 				useCasesMock.
 					EXPECT().
@@ -169,10 +181,24 @@ func TestCacheDecorator_GetUserByID(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByIDTTL).
-					Return(errors.New("set cache error")).
+					Tag(gomock.Any(), cacheKey, []string{userTag(userID)}, getUserByIDPolicy.ttl, gomock.Any()).
+					Return(false, errors.New("tag cache error")).
+					Times(1)
+			},
+		},
+		{
+			name:         "cache circuit open, success from db",
+			userID:       userID,
+			expectedUser: user,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetUserByID(gomock.Any(), userID).
+					Return(user, nil).
 					Times(1)
 			},
 		},
@@ -203,11 +229,23 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	email := "test@example.com"
 	user := &entities.User{ID: 1, Email: email, DisplayName: "Test User"}
-	cacheKey := fmt.Sprintf("%s:%s", getUserByEmailPrefix, email)
+	cacheKey := fmt.Sprintf("%s:%s", getUserByEmailPolicy.name, email)
 
 	testCases := []struct {
 		name          string
@@ -223,16 +261,10 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 			cacheValue:   `{"id":1,"email":"test@example.com","displayName":"Cached User"}`,
 			expectedUser: &entities.User{ID: 1, Email: email, DisplayName: "Cached User"},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"email":"test@example.com","displayName":"Cached User"}`, nil).
+					Return(cachedValue(`{"id":1,"email":"test@example.com","displayName":"Cached User"}`), nil).
 					Times(1)
 			},
 		},
@@ -241,11 +273,6 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 			email:        email,
 			expectedUser: user,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -260,31 +287,16 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 					GetUserByEmail(gomock.Any(), email).
 					Return(user, nil).
 					Times(1)
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByEmailTTL).
-					Return(nil).
+					Tag(gomock.Any(), cacheKey, []string{userTag(user.ID)}, getUserByEmailPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
-			},
-		},
-		{
-			name:         "cache ping error, success from db",
-			email:        email,
-			expectedUser: user,
-			setupMocks: func() {
+
 				cacheMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-				loggerMock.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-				useCasesMock.
-					EXPECT().
-					GetUserByEmail(gomock.Any(), email).
-					Return(user, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByEmailPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -293,11 +305,6 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 			email:         email,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -319,11 +326,6 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 			email:        email,
 			expectedUser: user,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -338,13 +340,33 @@ func TestCacheDecorator_GetUserByEmail(t *testing.T) {
 					GetUserByEmail(gomock.Any(), email).
 					Return(user, nil).
 					Times(1)
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{userTag(user.ID)}, getUserByEmailPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByEmailTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getUserByEmailPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:         "cache circuit open, success from db",
+			email:        email,
+			expectedUser: user,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), email).
+					Return(user, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -370,7 +392,19 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
 	users := []entities.User{{ID: 1, DisplayName: "Test User"}}
@@ -389,15 +423,10 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 			cacheValue:    `[{"id":1,"displayName":"Cached User"}]`,
 			expectedUsers: []entities.User{{ID: 1, DisplayName: "Cached User"}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1,"displayName":"Cached User"}]`, nil
+							return cachedValue(`[{"id":1,"displayName":"Cached User"}]`), nil
 						},
 					),
 				)
@@ -408,12 +437,6 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 			pagination:    pagination,
 			expectedUsers: users,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -432,31 +455,16 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 					Return(users, nil).
 					Times(1)
 
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUsersTTL).
-					Return(nil).
+					Tag(gomock.Any(), gomock.Any(), []string{usersListTag}, getUsersPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
-			},
-		},
-		{
-			name:          "cache ping error, success from db",
-			pagination:    pagination,
-			expectedUsers: users,
-			setupMocks: func() {
+
 				cacheMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-				loggerMock.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-				useCasesMock.
-					EXPECT().
-					GetUsers(gomock.Any(), pagination).
-					Return(users, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUsersPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -465,12 +473,6 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 			pagination:    pagination,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -496,12 +498,6 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 			pagination:    pagination,
 			expectedUsers: users,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -521,13 +517,33 @@ func TestCacheDecorator_GetUsers(t *testing.T) {
 					Return(users, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{usersListTag}, getUsersPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUsersTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUsersPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			pagination:    pagination,
+			expectedUsers: users,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetUsers(gomock.Any(), pagination).
+					Return(users, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -554,7 +570,19 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
 	filters := &entities.ToysFilters{Search: pointers.New("toy")}
@@ -576,16 +604,10 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 			cacheValue:   `[{"id":1,"name":"Cached Toy"}]`,
 			expectedToys: []entities.Toy{{ID: 1, Name: "Cached Toy"}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1,"name":"Cached Toy"}]`, nil
+							return cachedValue(`[{"id":1,"name":"Cached Toy"}]`), nil
 						},
 					),
 				)
@@ -597,12 +619,6 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 			filters:      filters,
 			expectedToys: toys,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -622,34 +638,16 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 					Return(toys, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getToysTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:         "cache ping error, success from db",
-			pagination:   pagination,
-			filters:      filters,
-			expectedToys: toys,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{toysListTag}, getToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetToys(gomock.Any(), pagination, filters).
-					Return(toys, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getToysPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -659,12 +657,6 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -691,12 +683,6 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 			filters:      filters,
 			expectedToys: toys,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -716,13 +702,34 @@ func TestCacheDecorator_GetToys(t *testing.T) {
 					Return(toys, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{toysListTag}, getToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getToysPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:         "cache circuit open, success from db",
+			pagination:   pagination,
+			filters:      filters,
+			expectedToys: toys,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetToys(gomock.Any(), pagination, filters).
+					Return(toys, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -749,7 +756,19 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	filters := &entities.ToysFilters{Search: pointers.New("toy")}
 	count := uint64(100)
@@ -768,16 +787,10 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 			cacheValue:    "100",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return "100", nil
+							return cachedValue("100"), nil
 						},
 					),
 				)
@@ -788,12 +801,6 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -813,33 +820,16 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:          "cache ping error, success from db",
-			filters:       filters,
-			expectedCount: count,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{toysListTag}, countToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					CountToys(gomock.Any(), filters).
-					Return(count, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -848,12 +838,6 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -879,12 +863,6 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -904,9 +882,15 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{toysListTag}, countToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
@@ -917,12 +901,6 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 			cacheValue:    "invalid",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -942,13 +920,33 @@ func TestCacheDecorator_CountToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{toysListTag}, countToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countToysPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			filters:       filters,
+			expectedCount: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					CountToys(gomock.Any(), filters).
+					Return(count, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -975,7 +973,19 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	masterID := uint64(1)
 	filters := &entities.ToysFilters{Search: pointers.New("toy")}
@@ -997,16 +1007,10 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 			cacheValue:    "50",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return "50", nil
+							return cachedValue("50"), nil
 						},
 					),
 				)
@@ -1018,12 +1022,6 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1043,34 +1041,16 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:          "cache ping error, success from db",
-			masterID:      masterID,
-			filters:       filters,
-			expectedCount: count,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{masterTag(masterID)}, countMasterToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					CountMasterToys(gomock.Any(), masterID, filters).
-					Return(count, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -1080,12 +1060,6 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1112,12 +1086,6 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1137,9 +1105,15 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{masterTag(masterID)}, countMasterToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
@@ -1151,12 +1125,6 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 			cacheValue:    "invalid",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1176,13 +1144,34 @@ func TestCacheDecorator_CountMasterToys(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{masterTag(masterID)}, countMasterToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countMasterToysPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			masterID:      masterID,
+			filters:       filters,
+			expectedCount: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					CountMasterToys(gomock.Any(), masterID, filters).
+					Return(count, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1209,7 +1198,19 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	masterID := uint64(1)
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
@@ -1234,16 +1235,10 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 			cacheValue:   `[{"id":1}]`,
 			expectedToys: []entities.Toy{{ID: 1}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1}]`, nil
+							return cachedValue(`[{"id":1}]`), nil
 						},
 					),
 				)
@@ -1256,12 +1251,6 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 			filters:      filters,
 			expectedToys: toys,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1281,35 +1270,16 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 					Return(toys, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMasterToysTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:         "cache ping error, success from db",
-			masterID:     masterID,
-			pagination:   pagination,
-			filters:      filters,
-			expectedToys: toys,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{masterTag(masterID)}, getMasterToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetMasterToys(gomock.Any(), masterID, pagination, filters).
-					Return(toys, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMasterToysPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -1320,12 +1290,6 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1353,12 +1317,6 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 			filters:      filters,
 			expectedToys: toys,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1378,13 +1336,35 @@ func TestCacheDecorator_GetMasterToys(t *testing.T) {
 					Return(toys, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{masterTag(masterID)}, getMasterToysPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMasterToysTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMasterToysPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:         "cache circuit open, success from db",
+			masterID:     masterID,
+			pagination:   pagination,
+			filters:      filters,
+			expectedToys: toys,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMasterToys(gomock.Any(), masterID, pagination, filters).
+					Return(toys, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1411,11 +1391,23 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	toyID := uint64(1)
 	toy := &entities.Toy{ID: toyID}
-	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPrefix, toyID)
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
 
 	testCases := []struct {
 		name          string
@@ -1431,16 +1423,10 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 			cacheValue:  `{"id":1}`,
 			expectedToy: &entities.Toy{ID: 1},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1}`, nil).
+					Return(cachedValue(`{"id":1}`), nil).
 					Times(1)
 			},
 		},
@@ -1449,12 +1435,6 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 			toyID:       toyID,
 			expectedToy: toy,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -1472,33 +1452,16 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 					Return(toy, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:        "cache ping error, success from db",
-			toyID:       toyID,
-			expectedToy: toy,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetToyByID(gomock.Any(), toyID).
-					Return(toy, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -1507,12 +1470,6 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 			toyID:         toyID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -1536,12 +1493,6 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 			toyID:       toyID,
 			expectedToy: toy,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -1559,13 +1510,33 @@ func TestCacheDecorator_GetToyByID(t *testing.T) {
 					Return(toy, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:        "cache circuit open, success from db",
+			toyID:       toyID,
+			expectedToy: toy,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1592,7 +1563,19 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
 	filters := &entities.MastersFilters{Search: pointers.New("test"), CreatedAtOrderByAsc: pointers.New(true)}
@@ -1614,16 +1597,10 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 			cacheValue:      `[{"id":1,"userId":1}]`,
 			expectedMasters: masters,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1}]`, nil
+							return cachedValue(`[{"id":1}]`), nil
 						},
 					),
 				)
@@ -1635,12 +1612,6 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 			filters:         filters,
 			expectedMasters: masters,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1660,34 +1631,16 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 					Return(masters, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMastersTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:            "cache ping error, success from db",
-			pagination:      pagination,
-			filters:         filters,
-			expectedMasters: masters,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{mastersListTag}, getMastersPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetMasters(gomock.Any(), pagination, filters).
-					Return(masters, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMastersPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -1697,12 +1650,6 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1729,12 +1676,6 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 			filters:         filters,
 			expectedMasters: masters,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1754,13 +1695,34 @@ func TestCacheDecorator_GetMasters(t *testing.T) {
 					Return(masters, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{mastersListTag}, getMastersPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMastersTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getMastersPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:            "cache circuit open, success from db",
+			pagination:      pagination,
+			filters:         filters,
+			expectedMasters: masters,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMasters(gomock.Any(), pagination, filters).
+					Return(masters, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1787,7 +1749,19 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	filters := &entities.MastersFilters{Search: pointers.New("ticket")}
 	count := uint64(100)
@@ -1806,16 +1780,10 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 			cacheValue:    "100",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return "100", nil
+							return cachedValue("100"), nil
 						},
 					),
 				)
@@ -1826,12 +1794,6 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1851,47 +1813,24 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{mastersListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:          "cache ping error, success from db",
+			name:          "db error",
 			filters:       filters,
-			expectedCount: count,
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-
-				useCasesMock.
-					EXPECT().
-					CountMasters(gomock.Any(), filters).
-					Return(count, nil).
-					Times(1)
-			},
-		},
-		{
-			name:          "db error",
-			filters:       filters,
-			expectedError: errors.New("db error"),
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1917,12 +1856,6 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1942,9 +1875,15 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{mastersListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
@@ -1955,12 +1894,6 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 			cacheValue:    "invalid",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -1980,13 +1913,33 @@ func TestCacheDecorator_CountMasters(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{mastersListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			filters:       filters,
+			expectedCount: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					CountMasters(gomock.Any(), filters).
+					Return(count, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2013,11 +1966,23 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	masterID := uint64(1)
 	master := &entities.Master{ID: masterID}
-	cacheKey := fmt.Sprintf("%s:%d", getMasterByIDPrefix, masterID)
+	cacheKey := fmt.Sprintf("%s:%d", getMasterByIDPolicy.name, masterID)
 
 	testCases := []struct {
 		name           string
@@ -2033,16 +1998,10 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 			cacheValue:     `{"id":1,"userId":1}`,
 			expectedMaster: &entities.Master{ID: 1},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"name":"Cached Master"}`, nil).
+					Return(cachedValue(`{"id":1,"name":"Cached Master"}`), nil).
 					Times(1)
 			},
 		},
@@ -2051,12 +2010,6 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 			masterID:       masterID,
 			expectedMaster: master,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2074,33 +2027,16 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 					Return(master, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:           "cache ping error, success from db",
-			masterID:       masterID,
-			expectedMaster: master,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{masterTag(masterID)}, getMasterByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetMasterByID(gomock.Any(), masterID).
-					Return(master, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -2109,12 +2045,6 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 			masterID:      masterID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2138,12 +2068,6 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 			masterID:       masterID,
 			expectedMaster: master,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2161,13 +2085,33 @@ func TestCacheDecorator_GetMasterByID(t *testing.T) {
 					Return(master, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{masterTag(masterID)}, getMasterByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:           "cache circuit open, success from db",
+			masterID:       masterID,
+			expectedMaster: master,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMasterByID(gomock.Any(), masterID).
+					Return(master, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2194,11 +2138,23 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	userID := uint64(1)
 	master := &entities.Master{ID: 1, UserID: userID}
-	cacheKey := fmt.Sprintf("%s:%d", getMasterByUserIDPrefix, userID)
+	cacheKey := fmt.Sprintf("%s:%d", getMasterByUserIDPolicy.name, userID)
 
 	testCases := []struct {
 		name           string
@@ -2214,16 +2170,10 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 			cacheValue:     `{"id":1,"userId":1}`,
 			expectedMaster: &entities.Master{ID: 1, UserID: userID},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"userId":1}`, nil).
+					Return(cachedValue(`{"id":1,"userId":1}`), nil).
 					Times(1)
 			},
 		},
@@ -2232,12 +2182,6 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 			userID:         userID,
 			expectedMaster: master,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2255,33 +2199,16 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 					Return(master, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByUserIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:           "cache ping error, success from db",
-			userID:         userID,
-			expectedMaster: master,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{masterTag(master.ID)}, getMasterByUserIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetMasterByUserID(gomock.Any(), userID).
-					Return(master, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByUserIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -2290,12 +2217,6 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 			userID:        userID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2319,12 +2240,6 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 			userID:         userID,
 			expectedMaster: master,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2342,13 +2257,33 @@ func TestCacheDecorator_GetMasterByUserID(t *testing.T) {
 					Return(master, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{masterTag(master.ID)}, getMasterByUserIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByUserIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMasterByUserIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:           "cache circuit open, success from db",
+			userID:         userID,
+			expectedMaster: master,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), userID).
+					Return(master, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2375,10 +2310,22 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	categories := []entities.Category{{ID: 1, Name: "Test Category"}}
-	cacheKey := getCategoriesPrefix
+	cacheKey := getCategoriesPolicy.name
 
 	testCases := []struct {
 		name               string
@@ -2392,16 +2339,10 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 			cacheValue:         `[{"id":1,"name":"Cached Category"}]`,
 			expectedCategories: []entities.Category{{ID: 1, Name: "Cached Category"}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`[{"id":1,"name":"Cached Category"}]`, nil).
+					Return(cachedValue(`[{"id":1,"name":"Cached Category"}]`), nil).
 					Times(1)
 			},
 		},
@@ -2409,12 +2350,6 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 			name:               "success from db",
 			expectedCategories: categories,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2432,32 +2367,16 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 					Return(categories, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoriesTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:               "cache ping error, success from db",
-			expectedCategories: categories,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoriesPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetAllCategories(gomock.Any()).
-					Return(categories, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoriesPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -2465,12 +2384,6 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 			name:          "db error",
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2493,12 +2406,6 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 			name:               "set cache error",
 			expectedCategories: categories,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2516,13 +2423,32 @@ func TestCacheDecorator_GetAllCategories(t *testing.T) {
 					Return(categories, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoriesPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoriesTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoriesPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:               "cache circuit open, success from db",
+			expectedCategories: categories,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetAllCategories(gomock.Any()).
+					Return(categories, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2549,11 +2475,23 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	categoryID := uint32(1)
 	category := &entities.Category{ID: categoryID, Name: "Test Category"}
-	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPrefix, categoryID)
+	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPolicy.name, categoryID)
 
 	testCases := []struct {
 		name             string
@@ -2569,16 +2507,10 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 			cacheValue:       `{"id":1,"name":"Cached Category"}`,
 			expectedCategory: &entities.Category{ID: 1, Name: "Cached Category"},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"name":"Cached Category"}`, nil).
+					Return(cachedValue(`{"id":1,"name":"Cached Category"}`), nil).
 					Times(1)
 			},
 		},
@@ -2587,12 +2519,6 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 			categoryID:       categoryID,
 			expectedCategory: category,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2610,33 +2536,16 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 					Return(category, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:             "cache ping error, success from db",
-			categoryID:       categoryID,
-			expectedCategory: category,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetCategoryByID(gomock.Any(), categoryID).
-					Return(category, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -2645,12 +2554,6 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 			categoryID:    categoryID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2674,12 +2577,6 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 			categoryID:       categoryID,
 			expectedCategory: category,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2697,13 +2594,33 @@ func TestCacheDecorator_GetCategoryByID(t *testing.T) {
 					Return(category, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:             "cache circuit open, success from db",
+			categoryID:       categoryID,
+			expectedCategory: category,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetCategoryByID(gomock.Any(), categoryID).
+					Return(category, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2730,10 +2647,22 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	tags := []entities.Tag{{ID: 1, Name: "Test Tag"}}
-	cacheKey := getTagsPrefix
+	cacheKey := getTagsPolicy.name
 
 	testCases := []struct {
 		name          string
@@ -2749,14 +2678,8 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 			setupMocks: func() {
 				cacheMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheMock.
-					EXPECT().
-					Get(gomock.Any(), cacheKey).
-					Return(`[{"id":1,"name":"Cached Tag"}]`, nil).
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`[{"id":1,"name":"Cached Tag"}]`), nil).
 					Times(1)
 			},
 		},
@@ -2764,12 +2687,6 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 			name:         "success from db",
 			expectedTags: tags,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2787,32 +2704,16 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 					Return(tags, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTagsTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:         "cache ping error, success from db",
-			expectedTags: tags,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{tagsTag}, getTagsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetAllTags(gomock.Any()).
-					Return(tags, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTagsPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -2820,12 +2721,6 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 			name:          "db error",
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2848,12 +2743,6 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 			name:         "set cache error",
 			expectedTags: tags,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2871,13 +2760,32 @@ func TestCacheDecorator_GetAllTags(t *testing.T) {
 					Return(tags, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{tagsTag}, getTagsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTagsTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTagsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:         "cache circuit open, success from db",
+			expectedTags: tags,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetAllTags(gomock.Any()).
+					Return(tags, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2904,11 +2812,23 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	tagID := uint32(1)
 	tag := &entities.Tag{ID: tagID, Name: "Test Tag"}
-	cacheKey := fmt.Sprintf("%s:%d", getTagByIDPrefix, tagID)
+	cacheKey := fmt.Sprintf("%s:%d", getTagByIDPolicy.name, tagID)
 
 	testCases := []struct {
 		name          string
@@ -2924,16 +2844,10 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 			cacheValue:  `{"id":1,"name":"Cached Tag"}`,
 			expectedTag: &entities.Tag{ID: 1, Name: "Cached Tag"},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"name":"Cached Tag"}`, nil).
+					Return(cachedValue(`{"id":1,"name":"Cached Tag"}`), nil).
 					Times(1)
 			},
 		},
@@ -2942,12 +2856,6 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 			tagID:       tagID,
 			expectedTag: tag,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -2965,33 +2873,16 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 					Return(tag, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTagByIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:        "cache ping error, success from db",
-			tagID:       tagID,
-			expectedTag: tag,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{tagsTag}, getTagByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetTagByID(gomock.Any(), tagID).
-					Return(tag, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTagByIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3000,12 +2891,6 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 			tagID:         tagID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -3029,12 +2914,6 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 			tagID:       tagID,
 			expectedTag: tag,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -3052,13 +2931,33 @@ func TestCacheDecorator_GetTagByID(t *testing.T) {
 					Return(tag, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{tagsTag}, getTagByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTagByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTagByIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:        "cache circuit open, success from db",
+			tagID:       tagID,
+			expectedTag: tag,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetTagByID(gomock.Any(), tagID).
+					Return(tag, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3085,11 +2984,23 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	ticketID := uint64(1)
 	ticket := &entities.Ticket{ID: ticketID, Name: "Test Ticket"}
-	cacheKey := fmt.Sprintf("%s:%d", getTicketByIDPrefix, ticketID)
+	cacheKey := fmt.Sprintf("%s:%d", getTicketByIDPolicy.name, ticketID)
 
 	testCases := []struct {
 		name           string
@@ -3105,16 +3016,10 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 			cacheValue:     `{"id":1,"name":"Cached Ticket"}`,
 			expectedTicket: &entities.Ticket{ID: 1, Name: "Cached Ticket"},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
-					Return(`{"id":1,"name":"Cached Ticket"}`, nil).
+					Return(cachedValue(`{"id":1,"name":"Cached Ticket"}`), nil).
 					Times(1)
 			},
 		},
@@ -3123,12 +3028,6 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 			ticketID:       ticketID,
 			expectedTicket: ticket,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -3146,33 +3045,16 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 					Return(ticket, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTicketByIDTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:           "cache ping error, success from db",
-			ticketID:       ticketID,
-			expectedTicket: ticket,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), cacheKey, []string{ticketTag(ticketID)}, getTicketByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetTicketByID(gomock.Any(), ticketID).
-					Return(ticket, nil).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTicketByIDPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3181,12 +3063,6 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 			ticketID:      ticketID,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -3210,12 +3086,6 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 			ticketID:       ticketID,
 			expectedTicket: ticket,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				cacheMock.
 					EXPECT().
 					Get(gomock.Any(), cacheKey).
@@ -3233,13 +3103,33 @@ func TestCacheDecorator_GetTicketByID(t *testing.T) {
 					Return(ticket, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{ticketTag(ticketID)}, getTicketByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), getTicketByIDTTL).
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTicketByIDPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:           "cache circuit open, success from db",
+			ticketID:       ticketID,
+			expectedTicket: ticket,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetTicketByID(gomock.Any(), ticketID).
+					Return(ticket, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3266,7 +3156,19 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
 	filters := &entities.TicketsFilters{Search: pointers.New("ticket")}
@@ -3288,16 +3190,10 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 			cacheValue:      `[{"id":1,"name":"Cached Ticket"}]`,
 			expectedTickets: []entities.Ticket{{ID: 1, Name: "Cached Ticket"}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1,"name":"Cached Ticket"}]`, nil
+							return cachedValue(`[{"id":1,"name":"Cached Ticket"}]`), nil
 						},
 					),
 				)
@@ -3309,12 +3205,6 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 			filters:         filters,
 			expectedTickets: tickets,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3334,34 +3224,16 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 					Return(tickets, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getTicketsTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:            "cache ping error, success from db",
-			pagination:      pagination,
-			filters:         filters,
-			expectedTickets: tickets,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{ticketsListTag}, getTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetTickets(gomock.Any(), pagination, filters).
-					Return(tickets, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getTicketsPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3371,12 +3243,6 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3403,12 +3269,6 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 			filters:         filters,
 			expectedTickets: tickets,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3428,13 +3288,34 @@ func TestCacheDecorator_GetTickets(t *testing.T) {
 					Return(tickets, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{ticketsListTag}, getTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getTicketsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
+		{
+			name:            "cache circuit open, success from db",
+			pagination:      pagination,
+			filters:         filters,
+			expectedTickets: tickets,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetTickets(gomock.Any(), pagination, filters).
+					Return(tickets, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3461,7 +3342,19 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	userID := uint64(1)
 	pagination := &entities.Pagination{Offset: pointers.New[uint64](1), Limit: pointers.New[uint64](10)}
@@ -3486,16 +3379,10 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 			cacheValue:      `[{"id":1,"name":"Cached Ticket","userId":1}]`,
 			expectedTickets: []entities.Ticket{{ID: 1, Name: "Cached Ticket", UserID: userID}},
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return `[{"id":1,"name":"Cached Ticket","userId":1}]`, nil
+							return cachedValue(`[{"id":1,"name":"Cached Ticket","userId":1}]`), nil
 						},
 					),
 				)
@@ -3508,12 +3395,6 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 			filters:         filters,
 			expectedTickets: tickets,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3533,35 +3414,16 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 					Return(tickets, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUserTicketsTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:            "cache ping error, success from db",
-			userID:          userID,
-			pagination:      pagination,
-			filters:         filters,
-			expectedTickets: tickets,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{userTicketsTag(userID)}, getUserTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					GetUserTickets(gomock.Any(), userID, pagination, filters).
-					Return(tickets, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUserTicketsPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3572,12 +3434,6 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3604,12 +3460,6 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 			filters:         filters,
 			expectedTickets: tickets,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3629,14 +3479,36 @@ func TestCacheDecorator_GetUserTickets(t *testing.T) {
 					Return(tickets, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{userTicketsTag(userID)}, getUserTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUserTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), getUserTicketsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
 		},
-	}
+		{
+			name:            "cache circuit open, success from db",
+			userID:          userID,
+			pagination:      pagination,
+			filters:         filters,
+			expectedTickets: tickets,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetUserTickets(gomock.Any(), userID, pagination, filters).
+					Return(tickets, nil).
+					Times(1)
+			},
+		},
+	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -3662,7 +3534,19 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	filters := &entities.TicketsFilters{Search: pointers.New("ticket")}
 	count := uint64(100)
@@ -3681,16 +3565,10 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 			cacheValue:    "100",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return "100", nil
+							return cachedValue("100"), nil
 						},
 					),
 				)
@@ -3701,12 +3579,6 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3726,33 +3598,16 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:          "cache ping error, success from db",
-			filters:       filters,
-			expectedCount: count,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{ticketsListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					CountTickets(gomock.Any(), filters).
-					Return(count, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3761,12 +3616,6 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3792,12 +3641,6 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3817,9 +3660,15 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{ticketsListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
@@ -3830,12 +3679,6 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 			cacheValue:    "invalid",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3855,13 +3698,33 @@ func TestCacheDecorator_CountTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{ticketsListTag}, countTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countTicketsPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			filters:       filters,
+			expectedCount: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					CountTickets(gomock.Any(), filters).
+					Return(count, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3888,7 +3751,19 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 	cacheMock := mockcache.NewMockProvider(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	userID := uint64(1)
 	filters := &entities.TicketsFilters{Search: pointers.New("ticket")}
@@ -3910,16 +3785,10 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 			cacheValue:    "50",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
-							return "50", nil
+							return cachedValue("50"), nil
 						},
 					),
 				)
@@ -3931,12 +3800,6 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -3956,34 +3819,16 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
-				cacheMock.
-					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsTTL).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:          "cache ping error, success from db",
-			userID:        userID,
-			filters:       filters,
-			expectedCount: count,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Tag(gomock.Any(), gomock.Any(), []string{userTicketsTag(userID)}, countUserTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheMock.
 					EXPECT().
-					CountUserTickets(gomock.Any(), userID, filters).
-					Return(count, nil).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsPolicy.ttl).
+					Return(nil).
 					Times(1)
 			},
 		},
@@ -3993,12 +3838,6 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 			filters:       filters,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -4025,12 +3864,6 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 			filters:       filters,
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -4050,9 +3883,15 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{userTicketsTag(userID)}, countUserTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsPolicy.ttl).
 					Return(errors.New("set cache error")).
 					Times(1)
 			},
@@ -4064,12 +3903,6 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 			cacheValue:    "invalid",
 			expectedCount: count,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				gomock.InOrder(
 					cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(_ context.Context, key string) (string, error) {
@@ -4089,13 +3922,34 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 					Return(count, nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), gomock.Any(), []string{userTicketsTag(userID)}, countUserTicketsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
 				cacheMock.
 					EXPECT().
-					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsTTL).
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), countUserTicketsPolicy.ttl).
 					Return(nil).
 					Times(1)
 			},
 		},
+		{
+			name:          "cache circuit open, success from db",
+			userID:        userID,
+			filters:       filters,
+			expectedCount: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					CountUserTickets(gomock.Any(), userID, filters).
+					Return(count, nil).
+					Times(1)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4117,75 +3971,148 @@ func TestCacheDecorator_CountUserTickets(t *testing.T) {
 	}
 }
 
-func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
+func TestCacheDecorator_RegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
-	userProfileData := entities.RawUpdateUserProfileDTO{AccessToken: "token123"}
-	user := &entities.User{ID: 1, Email: "test@example.com"}
+	userID := uint64(1)
+	userData := entities.RegisterUserDTO{DisplayName: "User", Email: "user@example.com", Password: "password"}
 
 	testCases := []struct {
-		name            string
-		userProfileData entities.RawUpdateUserProfileDTO
-		expectedError   error
-		setupMocks      func()
+		name          string
+		expected      uint64
+		expectedError error
+		setupMocks    func()
 	}{
 		{
-			name:            "success with cache deletion",
-			userProfileData: userProfileData,
+			name:     "success with cache invalidation",
+			expected: userID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					RegisterUser(gomock.Any(), userData).
+					Return(userID, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					UpdateUserProfile(gomock.Any(), userProfileData).
+					Invalidate(gomock.Any(), userTag(userID), usersListTag, usersNotFoundTag).
 					Return(nil).
 					Times(1)
-
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
 				useCasesMock.
 					EXPECT().
-					GetMe(gomock.Any(), userProfileData.AccessToken).
-					Return(user, nil).
+					RegisterUser(gomock.Any(), userData).
+					Return(uint64(0), errors.New("db error")).
 					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success without cache invalidation",
+			expected: userID,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d", getUserByIDPrefix, user.ID), nil).
-					Return(nil).
+					RegisterUser(gomock.Any(), userData).
+					Return(userID, nil).
 					Times(1)
 
-				cacheMock.
+				loggerMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%s", getUserByEmailPrefix, user.Email), nil).
-					Return(nil).
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			id, err := decorator.RegisterUser(context.Background(), userData)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	userProfileData := entities.RawUpdateUserProfileDTO{AccessToken: "token123"}
+	user := &entities.User{ID: 1, Email: "test@example.com"}
+
+	testCases := []struct {
+		name            string
+		userProfileData entities.RawUpdateUserProfileDTO
+		expectedError   error
+		setupMocks      func()
+	}{
 		{
-			name:            "cache ping error, success from db",
+			name:            "success with cache invalidation",
 			userProfileData: userProfileData,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
+					UpdateUserProfile(gomock.Any(), userProfileData).
+					Return(nil).
 					Times(1)
 
-				loggerMock.
+				useCasesMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetMe(gomock.Any(), userProfileData.AccessToken).
+					Return(user, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					UpdateUserProfile(gomock.Any(), userProfileData).
+					Invalidate(gomock.Any(), userTag(user.ID), usersListTag).
 					Return(nil).
 					Times(1)
 			},
@@ -4195,12 +4122,6 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 			userProfileData: userProfileData,
 			expectedError:   errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateUserProfile(gomock.Any(), userProfileData).
@@ -4209,15 +4130,9 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 			},
 		},
 		{
-			name:            "get me error, no cache deletion",
+			name:            "get user error, no cache invalidation",
 			userProfileData: userProfileData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateUserProfile(gomock.Any(), userProfileData).
@@ -4227,7 +4142,7 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 				useCasesMock.
 					EXPECT().
 					GetMe(gomock.Any(), userProfileData.AccessToken).
-					Return(nil, errors.New("get me error")).
+					Return(nil, errors.New("get user error")).
 					Times(1)
 
 				loggerMock.
@@ -4237,15 +4152,9 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 			},
 		},
 		{
-			name:            "delete cache error",
+			name:            "invalidate cache error",
 			userProfileData: userProfileData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateUserProfile(gomock.Any(), userProfileData).
@@ -4258,22 +4167,40 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d", getUserByIDPrefix, user.ID), nil).
-					Return(errors.New("delete cache error")).
+					Invalidate(gomock.Any(), userTag(user.ID), usersListTag).
+					Return(errors.New("invalidate cache error")).
 					Times(1)
 
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+			},
+		},
+		{
+			name:            "cache circuit open, success without cache invalidation",
+			userProfileData: userProfileData,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%s", getUserByEmailPrefix, user.Email), nil).
+					UpdateUserProfile(gomock.Any(), userProfileData).
 					Return(nil).
 					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), userProfileData.AccessToken).
+					Return(user, nil).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 		},
 	}
@@ -4295,83 +4222,195 @@ func TestCacheDecorator_UpdateUserProfile(t *testing.T) {
 	}
 }
 
-func TestCacheDecorator_UpdateToy(t *testing.T) {
+func TestCacheDecorator_AddToy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
-
-	toyData := entities.RawUpdateToyDTO{ID: 1}
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	toyData := entities.RawAddToyDTO{AccessToken: "test-token", Name: "Test Toy"}
 	toy := &entities.Toy{ID: 1, MasterID: 2}
 
 	testCases := []struct {
 		name          string
-		toyData       entities.RawUpdateToyDTO
+		toyData       entities.RawAddToyDTO
+		expected      uint64
 		expectedError error
 		setupMocks    func()
 	}{
 		{
-			name:    "success with cache deletion",
-			toyData: toyData,
+			name:     "success with cache invalidation",
+			toyData:  toyData,
+			expected: toy.ID,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
-					UpdateToy(gomock.Any(), toyData).
-					Return(nil).
+					AddToy(gomock.Any(), toyData).
+					Return(toy.ID, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					GetToyByID(gomock.Any(), toyData.ID).
+					GetToyByID(gomock.Any(), toy.ID).
 					Return(toy, nil).
 					Times(1)
 
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d", getToyByIDPrefix, toy.ID), nil).
+					Invalidate(gomock.Any(), toysListTag, tagsTag, toysNotFoundTag, masterTag(toy.MasterID)).
 					Return(nil).
 					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			toyData:       toyData,
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					AddToy(gomock.Any(), toyData).
+					Return(uint64(0), errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:     "get toy error, lists are still invalidated",
+			toyData:  toyData,
+			expected: toy.ID,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					AddToy(gomock.Any(), toyData).
+					Return(toy.ID, nil).
+					Times(1)
 
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d*", getMasterToysPrefix, toy.MasterID), nil).
-					Return(nil).
+					GetToyByID(gomock.Any(), toy.ID).
+					Return(nil, errors.New("get toy error")).
 					Times(1)
 
-				cacheMock.
+				loggerMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d*", countMasterToysPrefix, toy.MasterID), nil).
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), toysListTag, tagsTag, toysNotFoundTag).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:    "cache ping error, success from db",
-			toyData: toyData,
+			name:     "cache circuit open, success without cache invalidation",
+			toyData:  toyData,
+			expected: toy.ID,
 			setupMocks: func() {
-				cacheMock.
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
+					AddToy(gomock.Any(), toyData).
+					Return(toy.ID, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toy.ID).
+					Return(toy, nil).
 					Times(1)
 
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			toyID, err := decorator.AddToy(context.Background(), tc.toyData)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, toyID)
+		})
+	}
+}
+
+func TestCacheDecorator_UpdateToy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	toyData := entities.RawUpdateToyDTO{ID: 1}
+	toy := &entities.Toy{ID: 1, MasterID: 2}
 
+	testCases := []struct {
+		name          string
+		toyData       entities.RawUpdateToyDTO
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:    "success with cache invalidation",
+			toyData: toyData,
+			setupMocks: func() {
 				useCasesMock.
 					EXPECT().
 					UpdateToy(gomock.Any(), toyData).
 					Return(nil).
 					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyData.ID).
+					Return(toy, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), toyTag(toy.ID), toysListTag, tagsTag, masterTag(toy.MasterID)).
+					Return(nil).
+					Times(1)
 			},
 		},
 		{
@@ -4379,12 +4418,6 @@ func TestCacheDecorator_UpdateToy(t *testing.T) {
 			toyData:       toyData,
 			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateToy(gomock.Any(), toyData).
@@ -4393,15 +4426,9 @@ func TestCacheDecorator_UpdateToy(t *testing.T) {
 			},
 		},
 		{
-			name:    "get toy error, no cache deletion",
+			name:    "get toy error, toy and lists are still invalidated",
 			toyData: toyData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateToy(gomock.Any(), toyData).
@@ -4418,18 +4445,18 @@ func TestCacheDecorator_UpdateToy(t *testing.T) {
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), toyTag(toyData.ID), toysListTag, tagsTag).
+					Return(nil).
+					Times(1)
 			},
 		},
 		{
-			name:    "delete cache error",
+			name:    "invalidate cache error",
 			toyData: toyData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateToy(gomock.Any(), toyData).
@@ -4442,27 +4469,39 @@ func TestCacheDecorator_UpdateToy(t *testing.T) {
 					Return(toy, nil).
 					Times(1)
 
-				cacheMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d", getToyByIDPrefix, toy.ID), nil).
-					Return(errors.New("delete cache error")).
+					Invalidate(gomock.Any(), toyTag(toy.ID), toysListTag, tagsTag, masterTag(toy.MasterID)).
+					Return(errors.New("invalidate cache error")).
 					Times(1)
 
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+			},
+		},
+		{
+			name:    "cache circuit open, success without cache invalidation",
+			toyData: toyData,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d*", getMasterToysPrefix, toy.MasterID), nil).
+					UpdateToy(gomock.Any(), toyData).
 					Return(nil).
 					Times(1)
 
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					DelByPattern(gomock.Any(), fmt.Sprintf("%s:%d*", countMasterToysPrefix, toy.MasterID), nil).
-					Return(nil).
+					GetToyByID(gomock.Any(), toyData.ID).
+					Return(toy, nil).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
 		},
@@ -4488,18 +4527,25 @@ func TestCacheDecorator_UpdateToy(t *testing.T) {
 func TestCacheDecorator_DeleteToy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	accessToken := "test-token"
 	toyID := uint64(1)
 	toy := &entities.Toy{ID: toyID, MasterID: 100, Name: "Test Toy"}
-	patterns := []string{
-		fmt.Sprintf("%s:%d", getToyByIDPrefix, toy.ID),
-		fmt.Sprintf("%s:%d*", getMasterToysPrefix, toy.MasterID),
-		fmt.Sprintf("%s:%d*", countMasterToysPrefix, toy.MasterID),
-	}
 
 	testCases := []struct {
 		name          string
@@ -4509,14 +4555,14 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 		setupMocks    func()
 	}{
 		{
-			name:        "success with cache delete",
+			name:        "success with cache invalidation",
 			accessToken: accessToken,
 			toyID:       toyID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
 					Times(1)
 
 				useCasesMock.
@@ -4525,30 +4571,41 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 					Return(nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), toyTag(toyID), toysListTag, masterTag(toy.MasterID)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error, no cache invalidation",
+			accessToken:   accessToken,
+			toyID:         toyID,
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
 				useCasesMock.
 					EXPECT().
 					GetToyByID(gomock.Any(), toyID).
 					Return(toy, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(nil).
-						Times(1)
-				}
+				useCasesMock.
+					EXPECT().
+					DeleteToy(gomock.Any(), accessToken, toyID).
+					Return(errors.New("db error")).
+					Times(1)
 			},
 		},
 		{
-			name:        "cache ping error",
+			name:        "get toy error, toy and lists are still invalidated",
 			accessToken: accessToken,
 			toyID:       toyID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
+					GetToyByID(gomock.Any(), toyID).
+					Return(nil, errors.New("get toy error")).
 					Times(1)
 
 				loggerMock.
@@ -4561,48 +4618,31 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 					DeleteToy(gomock.Any(), accessToken, toyID).
 					Return(nil).
 					Times(1)
-			},
-		},
-		{
-			name:          "delete toy error",
-			accessToken:   accessToken,
-			toyID:         toyID,
-			expectedError: errors.New("delete failed"),
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					DeleteToy(gomock.Any(), accessToken, toyID).
-					Return(errors.New("delete failed")).
+					Invalidate(gomock.Any(), toyTag(toyID), toysListTag).
+					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:        "get toy error after delete",
+			name:        "cache circuit open, success without cache invalidation",
 			accessToken: accessToken,
 			toyID:       toyID,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+				decorator.circuitBreaker = openCircuitBreaker()
 
 				useCasesMock.
 					EXPECT().
-					DeleteToy(gomock.Any(), accessToken, toyID).
-					Return(nil).
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					GetToyByID(gomock.Any(), toyID).
-					Return(nil, errors.New("not found")).
+					DeleteToy(gomock.Any(), accessToken, toyID).
+					Return(nil).
 					Times(1)
 
 				loggerMock.
@@ -4611,41 +4651,97 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 					Times(1)
 			},
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			err := decorator.DeleteToy(context.Background(), tc.accessToken, tc.toyID)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_RegisterMaster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	masterID := uint64(1)
+	rawMasterData := entities.RawRegisterMasterDTO{AccessToken: "test-token"}
+
+	testCases := []struct {
+		name          string
+		expected      uint64
+		expectedError error
+		setupMocks    func()
+	}{
 		{
-			name:        "cache delete error",
-			accessToken: accessToken,
-			toyID:       toyID,
+			name:     "success with cache invalidation",
+			expected: masterID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					RegisterMaster(gomock.Any(), rawMasterData).
+					Return(masterID, nil).
 					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					DeleteToy(gomock.Any(), accessToken, toyID).
+					Invalidate(gomock.Any(), masterTag(masterID), mastersListTag, mastersNotFoundTag).
 					Return(nil).
 					Times(1)
-
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
 				useCasesMock.
 					EXPECT().
-					GetToyByID(gomock.Any(), toyID).
-					Return(toy, nil).
+					RegisterMaster(gomock.Any(), rawMasterData).
+					Return(uint64(0), errors.New("db error")).
 					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success without cache invalidation",
+			expected: masterID,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(errors.New("cache delete failed")).
-						Times(1)
+				useCasesMock.
+					EXPECT().
+					RegisterMaster(gomock.Any(), rawMasterData).
+					Return(masterID, nil).
+					Times(1)
 
-					loggerMock.
-						EXPECT().
-						ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-						Times(1)
-				}
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 		},
 	}
@@ -4656,13 +4752,15 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 				tc.setupMocks()
 			}
 
-			err := decorator.DeleteToy(context.Background(), tc.accessToken, tc.toyID)
+			id, err := decorator.RegisterMaster(context.Background(), rawMasterData)
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedError.Error())
 			} else {
 				assert.NoError(t, err)
 			}
+
+			assert.Equal(t, tc.expected, id)
 		})
 	}
 }
@@ -4670,21 +4768,24 @@ func TestCacheDecorator_DeleteToy(t *testing.T) {
 func TestCacheDecorator_UpdateMaster(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	masterID := uint64(1)
-	userID := uint64(10)
 	rawMasterData := entities.RawUpdateMasterDTO{ID: masterID}
-	master := &entities.Master{ID: masterID, UserID: userID}
-	patterns := []string{
-		fmt.Sprintf("%s:%d", getMasterByIDPrefix, master.ID),
-		fmt.Sprintf("%s:%d", getMasterByUserIDPrefix, master.UserID),
-		fmt.Sprintf("%s:%d*", getMasterToysPrefix, master.ID),
-		fmt.Sprintf("%s:%d*", countMasterToysPrefix, master.ID),
-		fmt.Sprintf("%s*", getMastersPrefix),
-	}
 
 	testCases := []struct {
 		name          string
@@ -4693,85 +4794,61 @@ func TestCacheDecorator_UpdateMaster(t *testing.T) {
 		setupMocks    func()
 	}{
 		{
-			name:          "success with cache delete",
+			name:          "success with cache invalidation",
 			rawMasterData: rawMasterData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateMaster(gomock.Any(), rawMasterData).
 					Return(nil).
 					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					GetMasterByID(gomock.Any(), masterID).
-					Return(master, nil).
+					Invalidate(gomock.Any(), masterTag(masterID), mastersListTag).
+					Return(nil).
 					Times(1)
-
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(nil).
-						Times(1)
-				}
 			},
 		},
 		{
-			name:          "cache ping error",
+			name:          "db error",
 			rawMasterData: rawMasterData,
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateMaster(gomock.Any(), rawMasterData).
-					Return(nil).
+					Return(errors.New("db error")).
 					Times(1)
 			},
 		},
 		{
-			name:          "update master error",
+			name:          "invalidate cache error",
 			rawMasterData: rawMasterData,
-			expectedError: errors.New("update failed"),
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					UpdateMaster(gomock.Any(), rawMasterData).
+					Return(nil).
 					Times(1)
 
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					UpdateMaster(gomock.Any(), rawMasterData).
-					Return(errors.New("update failed")).
+					Invalidate(gomock.Any(), masterTag(masterID), mastersListTag).
+					Return(errors.New("invalidate cache error")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
 		},
 		{
-			name:          "get master error after update",
+			name:          "cache circuit open, success without cache invalidation",
 			rawMasterData: rawMasterData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+				decorator.circuitBreaker = openCircuitBreaker()
 
 				useCasesMock.
 					EXPECT().
@@ -4779,52 +4856,143 @@ func TestCacheDecorator_UpdateMaster(t *testing.T) {
 					Return(nil).
 					Times(1)
 
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			err := decorator.UpdateMaster(context.Background(), tc.rawMasterData)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_CreateTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	rawTicketData := entities.RawCreateTicketDTO{AccessToken: "test-token", Name: "Test Ticket"}
+	ticket := &entities.Ticket{ID: 1, UserID: 10}
+
+	testCases := []struct {
+		name          string
+		expected      uint64
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success with cache invalidation",
+			expected: ticket.ID,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					CreateTicket(gomock.Any(), rawTicketData).
+					Return(ticket.ID, nil).
+					Times(1)
+
 				useCasesMock.
 					EXPECT().
-					GetMasterByID(gomock.Any(), masterID).
-					Return(nil, errors.New("not found")).
+					GetTicketByID(gomock.Any(), ticket.ID).
+					Return(ticket, nil).
 					Times(1)
 
-				loggerMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Invalidate(gomock.Any(), ticketsListTag, tagsTag, ticketsNotFoundTag, userTicketsTag(ticket.UserID)).
+					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:          "cache delete error",
-			rawMasterData: rawMasterData,
+			name:          "db error",
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
+					EXPECT().
+					CreateTicket(gomock.Any(), rawTicketData).
+					Return(uint64(0), errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:     "get ticket error, lists are still invalidated",
+			expected: ticket.ID,
+			setupMocks: func() {
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					CreateTicket(gomock.Any(), rawTicketData).
+					Return(ticket.ID, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					UpdateMaster(gomock.Any(), rawMasterData).
+					GetTicketByID(gomock.Any(), ticket.ID).
+					Return(nil, errors.New("get ticket error")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketsListTag, tagsTag, ticketsNotFoundTag).
 					Return(nil).
 					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success without cache invalidation",
+			expected: ticket.ID,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
 				useCasesMock.
 					EXPECT().
-					GetMasterByID(gomock.Any(), masterID).
-					Return(master, nil).
+					CreateTicket(gomock.Any(), rawTicketData).
+					Return(ticket.ID, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(errors.New("cache delete failed")).
-						Times(1)
+				useCasesMock.
+					EXPECT().
+					GetTicketByID(gomock.Any(), ticket.ID).
+					Return(ticket, nil).
+					Times(1)
 
-					loggerMock.
-						EXPECT().
-						ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-						Times(1)
-				}
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 		},
 	}
@@ -4835,13 +5003,15 @@ func TestCacheDecorator_UpdateMaster(t *testing.T) {
 				tc.setupMocks()
 			}
 
-			err := decorator.UpdateMaster(context.Background(), tc.rawMasterData)
+			ticketID, err := decorator.CreateTicket(context.Background(), rawTicketData)
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedError.Error())
 			} else {
 				assert.NoError(t, err)
 			}
+
+			assert.Equal(t, tc.expected, ticketID)
 		})
 	}
 }
@@ -4849,19 +5019,26 @@ func TestCacheDecorator_UpdateMaster(t *testing.T) {
 func TestCacheDecorator_UpdateTicket(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	ticketID := uint64(1)
 	userID := uint64(10)
 	rawTicketData := entities.RawUpdateTicketDTO{ID: ticketID}
 	ticket := &entities.Ticket{ID: ticketID, UserID: userID}
-	patterns := []string{
-		fmt.Sprintf("%s:%d", getTicketByIDPrefix, ticket.ID),
-		fmt.Sprintf("%s:%d", getUserTicketsPrefix, ticket.UserID),
-		fmt.Sprintf("%s:%d*", countUserTicketsPrefix, ticket.UserID),
-	}
 
 	testCases := []struct {
 		name          string
@@ -4870,15 +5047,9 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 		setupMocks    func()
 	}{
 		{
-			name:          "success with cache delete",
+			name:          "success with cache invalidation",
 			rawTicketData: rawTicketData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateTicket(gomock.Any(), rawTicketData).
@@ -4891,65 +5062,29 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 					Return(ticket, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(nil).
-						Times(1)
-				}
-			},
-		},
-		{
-			name:          "cache ping error",
-			rawTicketData: rawTicketData,
-			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
-					Times(1)
-
-				loggerMock.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-
-				useCasesMock.
+				cacheTagsRepositoryMock.
 					EXPECT().
-					UpdateTicket(gomock.Any(), rawTicketData).
+					Invalidate(gomock.Any(), ticketTag(ticketID), ticketsListTag, tagsTag, userTicketsTag(userID)).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:          "update ticket error",
+			name:          "db error",
 			rawTicketData: rawTicketData,
-			expectedError: errors.New("update failed"),
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateTicket(gomock.Any(), rawTicketData).
-					Return(errors.New("update failed")).
+					Return(errors.New("db error")).
 					Times(1)
 			},
 		},
 		{
-			name:          "get ticket error after update",
+			name:          "get ticket error, ticket and lists are still invalidated",
 			rawTicketData: rawTicketData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
 				useCasesMock.
 					EXPECT().
 					UpdateTicket(gomock.Any(), rawTicketData).
@@ -4959,24 +5094,26 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 				useCasesMock.
 					EXPECT().
 					GetTicketByID(gomock.Any(), ticketID).
-					Return(nil, errors.New("not found")).
+					Return(nil, errors.New("get ticket error")).
 					Times(1)
 
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketTag(ticketID), ticketsListTag, tagsTag).
+					Return(nil).
+					Times(1)
 			},
 		},
 		{
-			name:          "cache delete error",
+			name:          "cache circuit open, success without cache invalidation",
 			rawTicketData: rawTicketData,
 			setupMocks: func() {
-				cacheMock.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+				decorator.circuitBreaker = openCircuitBreaker()
 
 				useCasesMock.
 					EXPECT().
@@ -4990,18 +5127,10 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 					Return(ticket, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(errors.New("cache delete failed")).
-						Times(1)
-
-					loggerMock.
-						EXPECT().
-						ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-						Times(1)
-				}
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 		},
 	}
@@ -5013,7 +5142,6 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 			}
 
 			err := decorator.UpdateTicket(context.Background(), tc.rawTicketData)
-
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedError.Error())
@@ -5027,18 +5155,25 @@ func TestCacheDecorator_UpdateTicket(t *testing.T) {
 func TestCacheDecorator_DeleteTicket(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
 	loggerMock := mocklogging.NewMockLogger(ctrl)
 	useCasesMock := mockusecases.NewMockUseCases(ctrl)
-	decorator := NewCacheDecorator(useCasesMock, cacheMock, loggerMock)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
 
 	accessToken := "test-token"
 	ticketID := uint64(1)
 	ticket := &entities.Ticket{ID: ticketID, UserID: 10}
-	patterns := []string{
-		fmt.Sprintf("%s:%d", getTicketByIDPrefix, ticket.ID),
-		fmt.Sprintf("%s:%d", getUserTicketsPrefix, ticket.UserID),
-		fmt.Sprintf("%s:%d*", countUserTicketsPrefix, ticket.UserID),
-	}
 
 	testCases := []struct {
 		name          string
@@ -5048,14 +5183,14 @@ func TestCacheDecorator_DeleteTicket(t *testing.T) {
 		setupMocks    func()
 	}{
 		{
-			name:        "success with cache delete",
+			name:        "success with cache invalidation",
 			accessToken: accessToken,
 			ticketID:    ticketID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetTicketByID(gomock.Any(), ticketID).
+					Return(ticket, nil).
 					Times(1)
 
 				useCasesMock.
@@ -5064,30 +5199,41 @@ func TestCacheDecorator_DeleteTicket(t *testing.T) {
 					Return(nil).
 					Times(1)
 
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketTag(ticketID), ticketsListTag, userTicketsTag(ticket.UserID)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error, no cache invalidation",
+			accessToken:   accessToken,
+			ticketID:      ticketID,
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
 				useCasesMock.
 					EXPECT().
 					GetTicketByID(gomock.Any(), ticketID).
 					Return(ticket, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(nil).
-						Times(1)
-				}
+				useCasesMock.
+					EXPECT().
+					DeleteTicket(gomock.Any(), accessToken, ticketID).
+					Return(errors.New("db error")).
+					Times(1)
 			},
 		},
 		{
-			name:        "cache ping error",
+			name:        "get ticket error, ticket and lists are still invalidated",
 			accessToken: accessToken,
 			ticketID:    ticketID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", errors.New("cache unavailable")).
+					GetTicketByID(gomock.Any(), ticketID).
+					Return(nil, errors.New("get ticket error")).
 					Times(1)
 
 				loggerMock.
@@ -5100,91 +5246,171 @@ func TestCacheDecorator_DeleteTicket(t *testing.T) {
 					DeleteTicket(gomock.Any(), accessToken, ticketID).
 					Return(nil).
 					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketTag(ticketID), ticketsListTag).
+					Return(nil).
+					Times(1)
 			},
 		},
 		{
-			name:          "delete ticket error",
-			accessToken:   accessToken,
-			ticketID:      ticketID,
-			expectedError: errors.New("delete failed"),
+			name:        "cache circuit open, success without cache invalidation",
+			accessToken: accessToken,
+			ticketID:    ticketID,
 			setupMocks: func() {
-				cacheMock.
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetTicketByID(gomock.Any(), ticketID).
+					Return(ticket, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
 					DeleteTicket(gomock.Any(), accessToken, ticketID).
-					Return(errors.New("delete failed")).
+					Return(nil).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			err := decorator.DeleteTicket(context.Background(), tc.accessToken, tc.ticketID)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_RespondToTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	respondID := uint64(1)
+	rawRespondData := entities.RawRespondToTicketDTO{AccessToken: "test-token", TicketID: 2}
+	user := &entities.User{ID: 3}
+
+	testCases := []struct {
+		name          string
+		expected      uint64
+		expectedError error
+		setupMocks    func()
+	}{
 		{
-			name:        "get ticket error after delete",
-			accessToken: accessToken,
-			ticketID:    ticketID,
+			name:     "success with cache invalidation",
+			expected: respondID,
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					RespondToTicket(gomock.Any(), rawRespondData).
+					Return(respondID, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					DeleteTicket(gomock.Any(), accessToken, ticketID).
+					GetMe(gomock.Any(), rawRespondData.AccessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketTag(rawRespondData.TicketID), userRespondsTag(user.ID)).
 					Return(nil).
 					Times(1)
+			},
+		},
+		{
+			name:     "get user error, success with ticket cache invalidation",
+			expected: respondID,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					RespondToTicket(gomock.Any(), rawRespondData).
+					Return(respondID, nil).
+					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					GetTicketByID(gomock.Any(), ticketID).
-					Return(nil, errors.New("not found")).
+					GetMe(gomock.Any(), rawRespondData.AccessToken).
+					Return(nil, errors.New("sso error")).
 					Times(1)
 
 				loggerMock.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), ticketTag(rawRespondData.TicketID)).
+					Return(nil).
+					Times(1)
 			},
 		},
 		{
-			name:        "cache delete error",
-			accessToken: accessToken,
-			ticketID:    ticketID,
+			name:          "db error",
+			expectedError: errors.New("db error"),
 			setupMocks: func() {
-				cacheMock.
+				useCasesMock.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					RespondToTicket(gomock.Any(), rawRespondData).
+					Return(uint64(0), errors.New("db error")).
 					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success without cache invalidation",
+			expected: respondID,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
 
 				useCasesMock.
 					EXPECT().
-					DeleteTicket(gomock.Any(), accessToken, ticketID).
-					Return(nil).
+					RespondToTicket(gomock.Any(), rawRespondData).
+					Return(respondID, nil).
 					Times(1)
 
 				useCasesMock.
 					EXPECT().
-					GetTicketByID(gomock.Any(), ticketID).
-					Return(ticket, nil).
+					GetMe(gomock.Any(), rawRespondData.AccessToken).
+					Return(user, nil).
 					Times(1)
 
-				for _, pattern := range patterns {
-					cacheMock.
-						EXPECT().
-						DelByPattern(gomock.Any(), pattern, nil).
-						Return(errors.New("cache delete failed")).
-						Times(1)
-
-					loggerMock.
-						EXPECT().
-						ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-						Times(1)
-				}
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 		},
 	}
@@ -5195,12 +5421,1118 @@ func TestCacheDecorator_DeleteTicket(t *testing.T) {
 				tc.setupMocks()
 			}
 
-			err := decorator.DeleteTicket(context.Background(), tc.accessToken, tc.ticketID)
+			id, err := decorator.RespondToTicket(context.Background(), rawRespondData)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestCacheDecorator_GetRespondByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	user := &entities.User{ID: 3}
+	respondID := uint64(1)
+	respond := &entities.Respond{ID: respondID, TicketID: 2, Price: 100}
+	cacheKey, err := userParamsKey(userArgs[uint64]{userID: user.ID, params: respondID})
+	require.NoError(t, err)
+
+	cacheKey = getRespondByIDPolicy.name + ":" + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      *entities.Respond
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success from cache",
+			expected: &entities.Respond{ID: 1, TicketID: 2, Price: 50},
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`{"id":1,"tagliatelle":2,"price":50}`), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success from db",
+			expected: respond,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetRespondByID(gomock.Any(), respondID, accessToken).
+					Return(respond, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(
+						gomock.Any(),
+						cacheKey,
+						[]string{respondTag(respondID), ticketTag(respond.TicketID)},
+						getRespondByIDPolicy.ttl,
+						gomock.Any(),
+					).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getRespondByIDPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetRespondByID(gomock.Any(), respondID, accessToken).
+					Return(nil, errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:          "get user error, cache is not read",
+			expectedError: errors.New("invalid token"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success from db",
+			expected: respond,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetRespondByID(gomock.Any(), respondID, accessToken).
+					Return(respond, nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			result, err := decorator.GetRespondByID(context.Background(), respondID, accessToken)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_GetTicketResponds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	user := &entities.User{ID: 3}
+	ticketID := uint64(2)
+	responds := []entities.Respond{{ID: 1, TicketID: ticketID}}
+	cacheKey, err := userParamsKey(userArgs[uint64]{userID: user.ID, params: ticketID})
+	require.NoError(t, err)
+
+	cacheKey = getTicketRespondsPolicy.name + ":" + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      []entities.Respond
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success from cache",
+			expected: []entities.Respond{{ID: 1, TicketID: 2, Price: 50}},
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`[{"id":1,"tagliatelle":2,"price":50}]`), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success from db",
+			expected: responds,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetTicketResponds(gomock.Any(), ticketID, accessToken).
+					Return(responds, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(
+						gomock.Any(),
+						cacheKey,
+						[]string{ticketTag(ticketID), respondTag(1)},
+						getTicketRespondsPolicy.ttl,
+						gomock.Any(),
+					).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getTicketRespondsPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetTicketResponds(gomock.Any(), ticketID, accessToken).
+					Return(nil, errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:          "get user error, cache is not read",
+			expectedError: errors.New("invalid token"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success from db",
+			expected: responds,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetTicketResponds(gomock.Any(), ticketID, accessToken).
+					Return(responds, nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			result, err := decorator.GetTicketResponds(context.Background(), ticketID, accessToken)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_GetMyResponds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	user := &entities.User{ID: 3}
+	responds := []entities.Respond{{ID: 1, TicketID: 2}}
+	cacheKey, err := userKey(userArgs[struct{}]{userID: user.ID})
+	require.NoError(t, err)
+
+	cacheKey = getMyRespondsPolicy.name + ":" + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      []entities.Respond
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success from cache",
+			expected: []entities.Respond{{ID: 1, TicketID: 2, Price: 50}},
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`[{"id":1,"tagliatelle":2,"price":50}]`), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success from db",
+			expected: responds,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyResponds(gomock.Any(), accessToken).
+					Return(responds, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(
+						gomock.Any(),
+						cacheKey,
+						[]string{userRespondsTag(user.ID), respondTag(1)},
+						getMyRespondsPolicy.ttl,
+						gomock.Any(),
+					).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMyRespondsPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyResponds(gomock.Any(), accessToken).
+					Return(nil, errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:          "get user error, cache is not read",
+			expectedError: errors.New("invalid token"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success from db",
+			expected: responds,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyResponds(gomock.Any(), accessToken).
+					Return(responds, nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			result, err := decorator.GetMyResponds(context.Background(), accessToken)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_AccessTokenOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		mockcache.NewMockProvider(ctrl),
+		nil,
+		mockrepositories.NewMockCacheTagsRepository(ctrl),
+		mockrepositories.NewMockLocksRepository(ctrl),
+		config.CacheConfig{
+			AccessTokens: config.AccessTokensCacheConfig{Size: 10, TTL: time.Minute},
+		},
+		mocklogging.NewMockLogger(ctrl),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	// Cache is skipped to check only calls of SSO:
+	decorator.circuitBreaker = openCircuitBreaker()
+
+	accessToken := "test-token"
+	responds := []entities.Respond{{ID: 1, TicketID: 2, Price: 100}}
+
+	useCasesMock.
+		EXPECT().
+		GetMyResponds(gomock.Any(), accessToken).
+		Return(responds, nil).
+		Times(3)
+
+	// Owner of token is resolved by SSO once and is remembered until logout:
+	useCasesMock.
+		EXPECT().
+		GetMe(gomock.Any(), accessToken).
+		Return(&entities.User{ID: 3}, nil).
+		Times(2)
+
+	useCasesMock.
+		EXPECT().
+		LogoutUser(gomock.Any(), accessToken).
+		Return(nil).
+		Times(1)
+
+	ctx := requestmeta.WithMetadata(context.Background(), requestmeta.New("request-id", "10.0.0.1"))
+	for range 2 {
+		result, err := decorator.GetMyResponds(ctx, accessToken)
+		require.NoError(t, err)
+		require.Equal(t, responds, result)
+	}
+
+	// User of request is known without call of SSO:
+	require.Equal(t, uint64(3), requestmeta.FromContext(ctx).UserID())
+
+	require.NoError(t, decorator.LogoutUser(context.Background(), accessToken))
+
+	result, err := decorator.GetMyResponds(context.Background(), accessToken)
+	require.NoError(t, err)
+	require.Equal(t, responds, result)
+}
+
+func TestCacheDecorator_UpdateRespond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	rawRespondData := entities.RawUpdateRespondDTO{AccessToken: "test-token", ID: 1, Price: pointers.New[float32](100)}
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name: "success with cache invalidation",
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					UpdateRespond(gomock.Any(), rawRespondData).
+					Return(nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), respondTag(rawRespondData.ID)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error, no cache invalidation",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					UpdateRespond(gomock.Any(), rawRespondData).
+					Return(errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name: "cache circuit open, success without cache invalidation",
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					UpdateRespond(gomock.Any(), rawRespondData).
+					Return(nil).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			err := decorator.UpdateRespond(context.Background(), rawRespondData)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_DeleteRespond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	respondID := uint64(1)
+
+	testCases := []struct {
+		name          string
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name: "success with cache invalidation",
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					DeleteRespond(gomock.Any(), accessToken, respondID).
+					Return(nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Invalidate(gomock.Any(), respondTag(respondID)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error, no cache invalidation",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					DeleteRespond(gomock.Any(), accessToken, respondID).
+					Return(errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name: "cache circuit open, success without cache invalidation",
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					DeleteRespond(gomock.Any(), accessToken, respondID).
+					Return(nil).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			err := decorator.DeleteRespond(context.Background(), accessToken, respondID)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_GetMyEmailCommunications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	user := &entities.User{ID: 3}
+	pagination := &entities.Pagination{Offset: pointers.New[uint64](0), Limit: pointers.New[uint64](10)}
+	emails := []entities.Email{{ID: 1, UserID: 3, Content: "Content"}}
+	cacheKey, err := userParamsKey(userArgs[*entities.Pagination]{userID: user.ID, params: pagination})
+	require.NoError(t, err)
+
+	cacheKey = getMyEmailCommunicationsPolicy.name + ":" + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      []entities.Email
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success from cache",
+			expected: []entities.Email{{ID: 1, UserID: 3, Content: "Cached Content"}},
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`[{"id":1,"userId":3,"content":"Cached Content"}]`), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success from db",
+			expected: emails,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyEmailCommunications(gomock.Any(), accessToken, pagination).
+					Return(emails, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{userTag(user.ID)}, getMyEmailCommunicationsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getMyEmailCommunicationsPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyEmailCommunications(gomock.Any(), accessToken, pagination).
+					Return(nil, errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:          "get user error, cache is not read",
+			expectedError: errors.New("invalid token"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success from db",
+			expected: emails,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetMyEmailCommunications(gomock.Any(), accessToken, pagination).
+					Return(emails, nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			result, err := decorator.GetMyEmailCommunications(context.Background(), accessToken, pagination)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_CountMyEmailCommunications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	accessToken := "test-token"
+	user := &entities.User{ID: 3}
+	count := uint64(5)
+	cacheKey, err := userKey(userArgs[struct{}]{userID: user.ID})
+	require.NoError(t, err)
+
+	cacheKey = countMyEmailCommunicationsPolicy.name + ":" + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      uint64
+		expectedError error
+		setupMocks    func()
+	}{
+		{
+			name:     "success from cache",
+			expected: uint64(7),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(`7`), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success from db",
+			expected: count,
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					CountMyEmailCommunications(gomock.Any(), accessToken).
+					Return(count, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{userTag(user.ID)}, countMyEmailCommunicationsPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), countMyEmailCommunicationsPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "db error",
+			expectedError: errors.New("db error"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					CountMyEmailCommunications(gomock.Any(), accessToken).
+					Return(uint64(0), errors.New("db error")).
+					Times(1)
+			},
+		},
+		{
+			name:          "get user error, cache is not read",
+			expectedError: errors.New("invalid token"),
+			setupMocks: func() {
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+		},
+		{
+			name:     "cache circuit open, success from db",
+			expected: count,
+			setupMocks: func() {
+				decorator.circuitBreaker = openCircuitBreaker()
+
+				useCasesMock.
+					EXPECT().
+					GetMe(gomock.Any(), accessToken).
+					Return(user, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					CountMyEmailCommunications(gomock.Any(), accessToken).
+					Return(count, nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks()
+			}
+
+			result, err := decorator.CountMyEmailCommunications(context.Background(), accessToken)
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Zero(t, result)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
 			}
 		})
 	}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// cacheEntryVersion is stored as first byte of each cache entry. It should be bumped, when layout of entry
+// or cached entities change, so that old entries are skipped instead of being decoded incorrectly.
+const cacheEntryVersion byte = 2
+
+// Entry layout: version byte, flags byte, compressor ID byte (0 means uncompressed value), expiration
+// time and recomputation duration as big-endian int64 and encoded value:
+const (
+	cacheEntryHeaderSize = 19
+	noCacheCompressorID  = 0
+
+	// cacheEntryNotFound marks entries, which remember NotFound result instead of value:
+	cacheEntryNotFound byte = 1 << 0
+)
+
+var (
+	errCacheEntryDecode  = errors.New("failed to decode cache entry")
+	errCacheEntryVersion = errors.New("cache entry has outdated version")
+)
+
+// cacheEntry is stored in cache instead of raw value. Its metadata is used for probabilistic early
+// expiration (XFetch): https://cseweb.ucsd.edu/~avattani/papers/cache_stampede.pdf
+type cacheEntry struct {
+	Value     []byte // Value, encoded by serializer of cache policy, or error message for NotFound entries
+	ExpiresAt int64  // Unix time in milliseconds
+	Delta     int64  // Duration of value recomputation in milliseconds
+	NotFound  bool
+}
+
+// marshalCacheEntry encodes entry. Value is compressed, if compressor is provided and value size
+// reaches threshold.
+func marshalCacheEntry(entry cacheEntry, compressor cacheCompressor, threshold int) ([]byte, error) {
+	compressorID := byte(noCacheCompressorID)
+	value := entry.Value
+
+	if compressor != nil && len(value) >= threshold {
+		compressed, err := compressor.Compress(value)
+		if err != nil {
+			return nil, err
+		}
+
+		compressorID = compressor.ID()
+		value = compressed
+	}
+
+	var flags byte
+	if entry.NotFound {
+		flags |= cacheEntryNotFound
+	}
+
+	encoded := make([]byte, cacheEntryHeaderSize, cacheEntryHeaderSize+len(value))
+	encoded[0] = cacheEntryVersion
+	encoded[1] = flags
+	encoded[2] = compressorID
+	binary.BigEndian.PutUint64(encoded[3:11], uint64(entry.ExpiresAt))
+	binary.BigEndian.PutUint64(encoded[11:19], uint64(entry.Delta))
+
+	return append(encoded, value...), nil
+}
+
+// unmarshalCacheEntry decodes entry. Entries of other versions are skipped with errCacheEntryVersion.
+func unmarshalCacheEntry(data []byte) (*cacheEntry, error) {
+	if len(data) == 0 || data[0] != cacheEntryVersion {
+		return nil, errCacheEntryVersion
+	}
+
+	if len(data) < cacheEntryHeaderSize {
+		return nil, fmt.Errorf("%w: entry is too short", errCacheEntryDecode)
+	}
+
+	value := data[cacheEntryHeaderSize:]
+	if compressorID := data[2]; compressorID != noCacheCompressorID {
+		compressor, ok := cacheCompressors[compressorID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown compressor %d", errCacheEntryDecode, compressorID)
+		}
+
+		decompressed, err := compressor.Decompress(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errCacheEntryDecode, err)
+		}
+
+		value = decompressed
+	}
+
+	return &cacheEntry{
+		Value:     value,
+		ExpiresAt: int64(binary.BigEndian.Uint64(data[3:11])),
+		Delta:     int64(binary.BigEndian.Uint64(data[11:19])),
+		NotFound:  data[1]&cacheEntryNotFound != 0,
+	}, nil
+}
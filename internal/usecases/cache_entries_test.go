@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+)
+
+func TestCacheEntry_Marshal(t *testing.T) {
+	value := bytes.Repeat([]byte(`{"id":1,"name":"Toy"}`), 100)
+
+	testCases := []struct {
+		name               string
+		compressor         cacheCompressor
+		threshold          int
+		notFound           bool
+		expectedCompressor byte
+	}{
+		{
+			name:               "without compression",
+			expectedCompressor: noCacheCompressorID,
+		},
+		{
+			name:               "zstd",
+			compressor:         zstdCacheCompressor{},
+			threshold:          len(value),
+			expectedCompressor: zstdCacheCompressor{}.ID(),
+		},
+		{
+			name:               "snappy",
+			compressor:         snappyCacheCompressor{},
+			threshold:          len(value),
+			expectedCompressor: snappyCacheCompressor{}.ID(),
+		},
+		{
+			name:               "not found",
+			notFound:           true,
+			expectedCompressor: noCacheCompressorID,
+		},
+		{
+			name:               "below threshold",
+			compressor:         zstdCacheCompressor{},
+			threshold:          len(value) + 1,
+			expectedCompressor: noCacheCompressorID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := cacheEntry{Value: value, ExpiresAt: 1700000000000, Delta: 15, NotFound: tc.notFound}
+
+			encoded, err := marshalCacheEntry(entry, tc.compressor, tc.threshold)
+			require.NoError(t, err)
+			assert.Equal(t, cacheEntryVersion, encoded[0])
+			assert.Equal(t, tc.expectedCompressor, encoded[2])
+
+			if tc.expectedCompressor != noCacheCompressorID {
+				assert.Less(t, len(encoded), len(value))
+			}
+
+			decoded, err := unmarshalCacheEntry(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, entry, *decoded)
+		})
+	}
+}
+
+func TestCacheEntry_Unmarshal(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        []byte
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			data:        nil,
+			expectedErr: errCacheEntryVersion,
+		},
+		{
+			name:        "outdated version",
+			data:        []byte(`{"value":"eyJpZCI6MX0=","expiresAt":0}`),
+			expectedErr: errCacheEntryVersion,
+		},
+		{
+			name:        "too short",
+			data:        []byte{cacheEntryVersion, 0, noCacheCompressorID},
+			expectedErr: errCacheEntryDecode,
+		},
+		{
+			name:        "unknown compressor",
+			data:        append([]byte{cacheEntryVersion, 0, 255}, make([]byte, cacheEntryHeaderSize)...),
+			expectedErr: errCacheEntryDecode,
+		},
+		{
+			name:        "corrupted compressed value",
+			data:        append([]byte{cacheEntryVersion, 0, zstdCacheCompressor{}.ID()}, make([]byte, cacheEntryHeaderSize)...),
+			expectedErr: errCacheEntryDecode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, err := unmarshalCacheEntry(tc.data)
+			require.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, entry)
+		})
+	}
+}
+
+func TestNewCacheDecorator_UnknownEncoding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encoding config.CacheEncodingConfig
+	}{
+		{
+			name:     "codec",
+			encoding: config.CacheEncodingConfig{Codec: "xml"},
+		},
+		{
+			name:     "compression",
+			encoding: config.CacheEncodingConfig{Codec: msgpackCacheCodec, Compression: "lz4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decorator, err := NewCacheDecorator(
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				config.CacheConfig{Encoding: tc.encoding},
+				nil,
+				prometheus.NewRegistry(),
+			)
+			require.Error(t, err)
+			require.Nil(t, decorator)
+		})
+	}
+}
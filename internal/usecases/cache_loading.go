@@ -0,0 +1,526 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	"github.com/DKhorkov/hmtm-bff/internal/requestmeta"
+)
+
+const cacheLockKeyPrefix = "cache_locks:"
+
+var errCacheCircuitOpen = errors.New("cache circuit breaker is open")
+
+// cacheLoad is result of value recomputation, which is shared between coalesced callers.
+type cacheLoad struct {
+	value     any // Is nil, if value was recomputed by another instance and received from cache
+	encoded   []byte
+	notCached bool // Value was recomputed, but failed to be cached
+}
+
+// cached returns result of read use case, which is cached according to provided policy. Policy could be
+// disabled or its TTL could be overridden by config. Values are encoded by serializer of policy or, if it
+// is not provided, by serializer from config.
+func cached[A, T any](ctx context.Context, c *CacheDecorator, policy *cachePolicy[A, T], args A) (T, error) {
+	startedAt := time.Now()
+	load := func(ctx context.Context) (T, error) {
+		return policy.load(ctx, c.UseCases, args)
+	}
+
+	policyConfig := c.config.Policies[policy.name]
+	if policyConfig.Disabled {
+		defer c.metrics.observeRequest(policy.name, cacheOutcomeBypassed, startedAt)
+
+		return load(ctx)
+	}
+
+	key, err := policy.cacheKey(args)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			c.logger,
+			fmt.Sprintf("Failed to build cache key for policy=%s", policy.name),
+			err,
+		)
+
+		defer c.metrics.observeRequest(policy.name, cacheOutcomeBypassed, startedAt)
+
+		return load(ctx)
+	}
+
+	ttl := policy.ttl
+	if policyConfig.TTL > 0 {
+		ttl = policyConfig.TTL
+	}
+
+	serializer := c.serializer
+	if policy.serializer != nil {
+		serializer = policy.serializer
+	}
+
+	var notFoundTags func() []string
+	if policy.notFoundTags != nil && c.config.NotFoundTTL > 0 {
+		notFoundTags = func() []string {
+			return policy.notFoundTags(args)
+		}
+	}
+
+	value, outcome, err := getOrLoad(
+		ctx,
+		c,
+		key,
+		ttl,
+		serializer,
+		load,
+		func(value T) []string {
+			return policy.tags(args, value)
+		},
+		notFoundTags,
+	)
+
+	c.metrics.observeRequest(policy.name, outcome, startedAt)
+
+	return value, err
+}
+
+// cachedForUser returns result of read use case, which returns data of authorized user. Cached data is shared
+// only between requests of the same user. Owner of access token is remembered for short TTL, so that SSO is
+// not called before each read, while token is still validated by use case on cache miss.
+func cachedForUser[P, T any](
+	ctx context.Context,
+	c *CacheDecorator,
+	policy *cachePolicy[userArgs[P], T],
+	accessToken string,
+	params P,
+) (T, error) {
+	userID, err := c.accessTokenOwner(ctx, accessToken)
+	if err != nil {
+		var value T
+
+		return value, err
+	}
+
+	return cached(ctx, c, policy, userArgs[P]{userID: userID, accessToken: accessToken, params: params})
+}
+
+// accessTokenOwner returns ID of User, who owns access token. Tokens are remembered by their hashes to
+// avoid keeping tokens themselves in memory.
+func (c *CacheDecorator) accessTokenOwner(ctx context.Context, accessToken string) (uint64, error) {
+	var hash string
+
+	if c.accessTokens != nil {
+		hash = accessTokenHash(accessToken)
+		if userID, ok := c.accessTokens.Get(hash); ok {
+			// User is otherwise remembered by SSO service, when token is validated:
+			requestmeta.SetUserID(ctx, userID)
+
+			return userID, nil
+		}
+	}
+
+	user, err := c.UseCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.accessTokens != nil {
+		c.accessTokens.Add(hash, user.ID)
+	}
+
+	return user.ID, nil
+}
+
+func accessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// validateCachePolicies checks, that config overrides only registered policies.
+func validateCachePolicies(cacheConfig config.CacheConfig) error {
+	names := slices.Concat(slices.Collect(maps.Keys(cacheConfig.Policies)), cacheConfig.Local.Prefixes)
+	for _, name := range names {
+		if _, ok := registeredCachePolicies[name]; !ok {
+			return fmt.Errorf("unknown cache policy %s", name)
+		}
+	}
+
+	return nil
+}
+
+// getOrLoad returns cached value or loads it and caches with provided TTL and tags. Concurrent misses
+// of the same key are coalesced, so that value is recomputed only once. Hot keys are recomputed in
+// background before expiration, while callers still receive cached value. NotFound results are cached
+// with their own TTL, if notFoundTags are provided. Outcome describes, how value was received, for metrics.
+func getOrLoad[T any](
+	ctx context.Context,
+	c *CacheDecorator,
+	key string,
+	ttl time.Duration,
+	serializer cacheSerializer,
+	load func(ctx context.Context) (T, error),
+	tags func(value T) []string,
+	notFoundTags func() []string,
+) (T, string, error) {
+	cachedLocally := c.cachedLocally(key)
+	if cachedLocally {
+		// Local tier stores encoded values, so that callers could not modify shared value:
+		if encoded, ok := c.localCacheRepository.Get(key); ok {
+			var value T
+			if err := serializer.Unmarshal(encoded, &value); err == nil {
+				c.metrics.lookups.WithLabelValues(localCacheTier, cacheHit).Inc()
+
+				return value, cacheOutcomeHit, nil
+			}
+		}
+
+		c.metrics.lookups.WithLabelValues(localCacheTier, cacheMiss).Inc()
+	}
+
+	// Cache is skipped entirely, while Redis is unavailable:
+	if !c.circuitBreaker.Allow() {
+		value, err := load(ctx)
+
+		return value, cacheOutcomeBypassed, err
+	}
+
+	loadValue := func(ctx context.Context) (any, []string, error) {
+		value, err := load(ctx)
+		if err != nil {
+			// Tags are returned with NotFound error, if it should be cached:
+			var notFoundErr *customerrors.NotFoundError
+			if notFoundTags != nil && errors.As(err, &notFoundErr) {
+				return nil, notFoundTags(), err
+			}
+
+			return nil, nil, err
+		}
+
+		return value, tags(value), nil
+	}
+
+	var value T
+
+	outcome := cacheOutcomeMiss
+
+	entry, err := c.getEntry(ctx, key)
+	if err == nil && entry.NotFound {
+		c.metrics.lookups.WithLabelValues(sharedCacheTier, cacheHit).Inc()
+
+		return value, cacheOutcomeHit, notFoundError(entry)
+	}
+
+	if err == nil {
+		if err = serializer.Unmarshal(entry.Value, &value); err == nil {
+			c.metrics.lookups.WithLabelValues(sharedCacheTier, cacheHit).Inc()
+
+			if cachedLocally {
+				c.localCacheRepository.Set(key, entry.Value, tags(value))
+			}
+
+			if c.expiresEarly(entry) {
+				// Refresh is not bounded by budget of operation, which has already got its value:
+				go c.refresh(context.WithoutCancel(ctx), key, ttl, serializer, loadValue)
+			}
+
+			return value, cacheOutcomeHit, nil
+		}
+
+		outcome = cacheOutcomeDecodeError
+	}
+
+	if errors.Is(err, errCacheEntryDecode) {
+		outcome = cacheOutcomeDecodeError
+	}
+
+	c.metrics.lookups.WithLabelValues(sharedCacheTier, cacheMiss).Inc()
+
+	logging.LogErrorContext(
+		ctx,
+		c.logger,
+		fmt.Sprintf("Failed to get cached value with key=%s", key),
+		err,
+	)
+
+	result, err, shared := c.loads.Do(key, func() (any, error) {
+		return c.recompute(ctx, key, ttl, serializer, loadValue)
+	})
+	if err != nil {
+		return value, outcome, err
+	}
+
+	loaded := result.(cacheLoad)
+	if loaded.notCached {
+		outcome = cacheOutcomeSetError
+	}
+
+	// Each coalesced caller receives its own copy of value to avoid data races:
+	if loadedValue, ok := loaded.value.(T); ok && (!shared || loaded.encoded == nil) {
+		return loadedValue, outcome, nil
+	}
+
+	err = serializer.Unmarshal(loaded.encoded, &value)
+
+	return value, outcome, err
+}
+
+// cachedLocally decides by key prefix, whether value should be cached in local tier.
+func (c *CacheDecorator) cachedLocally(key string) bool {
+	if !c.config.Local.Enabled {
+		return false
+	}
+
+	return slices.Contains(c.config.Local.Prefixes, cachePrefix(key))
+}
+
+// cachePrefix returns name of cache policy, which key was built by.
+func cachePrefix(key string) string {
+	prefix, _, _ := strings.Cut(key, ":")
+
+	return prefix
+}
+
+func (c *CacheDecorator) getEntry(ctx context.Context, key string) (*cacheEntry, error) {
+	encoded, err := c.cacheProvider.Get(ctx, key)
+	c.observe(err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.metrics.payloadSize.WithLabelValues(cachePrefix(key), cacheOperationRead).Observe(float64(len(encoded)))
+
+	return unmarshalCacheEntry([]byte(encoded))
+}
+
+// expiresEarly decides, whether entry should be recomputed before expiration. Probability grows as
+// expiration approaches and is higher for values, which take longer to recompute.
+func (c *CacheDecorator) expiresEarly(entry *cacheEntry) bool {
+	if c.config.Stampede.EarlyExpirationBeta <= 0 {
+		return false
+	}
+
+	gap := float64(entry.Delta) * c.config.Stampede.EarlyExpirationBeta * -math.Log(1-rand.Float64())
+
+	return float64(time.Now().UnixMilli())+gap >= float64(entry.ExpiresAt)
+}
+
+// refresh recomputes value in background. Refresh is skipped, if value is already being recomputed.
+func (c *CacheDecorator) refresh(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	serializer cacheSerializer,
+	loadValue func(ctx context.Context) (any, []string, error),
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.LogErrorContext(
+				ctx,
+				c.logger,
+				fmt.Sprintf("Panic occurred while refreshing cached value with key=%s", key),
+				fmt.Errorf("%v", r),
+			)
+		}
+	}()
+
+	_, err, _ := c.loads.Do(key, func() (any, error) {
+		return c.recompute(ctx, key, ttl, serializer, loadValue)
+	})
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			c.logger,
+			fmt.Sprintf("Failed to refresh cached value with key=%s", key),
+			err,
+		)
+	}
+}
+
+// recompute loads value and caches it. If stampede lock is enabled, only one instance recomputes value,
+// while others wait for it to appear in cache.
+func (c *CacheDecorator) recompute(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	serializer cacheSerializer,
+	loadValue func(ctx context.Context) (any, []string, error),
+) (cacheLoad, error) {
+	// Result is shared between callers, so recomputation should not be canceled by the first of them.
+	// Deadline is kept, so that budget of operation is still propagated to downstream services:
+	detachedCtx := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+
+		detachedCtx, cancel = context.WithDeadline(detachedCtx, deadline)
+		defer cancel()
+	}
+
+	ctx = detachedCtx
+
+	if c.config.Stampede.LockEnabled {
+		lockKey := cacheLockKeyPrefix + key
+		token, acquired, err := c.locksRepository.Acquire(ctx, lockKey, c.config.Stampede.LockTTL)
+		c.observe(err)
+
+		switch {
+		case err != nil:
+			logging.LogErrorContext(
+				ctx,
+				c.logger,
+				fmt.Sprintf("Failed to acquire cache lock with key=%s", lockKey),
+				err,
+			)
+		case acquired:
+			defer func() {
+				if err = c.locksRepository.Release(ctx, lockKey, token); err != nil {
+					logging.LogErrorContext(
+						ctx,
+						c.logger,
+						fmt.Sprintf("Failed to release cache lock with key=%s", lockKey),
+						err,
+					)
+				}
+			}()
+		default:
+			// Value is recomputed by another instance. It is recomputed locally only if waiting timed out:
+			if entry, ok := c.waitForEntry(ctx, key); ok {
+				if entry.NotFound {
+					return cacheLoad{}, notFoundError(entry)
+				}
+
+				return cacheLoad{encoded: entry.Value}, nil
+			}
+		}
+	}
+
+	startedAt := time.Now()
+
+	value, tags, err := loadValue(ctx)
+	if err != nil {
+		if tags != nil {
+			c.cacheNotFound(ctx, key, err, startedAt, tags)
+		}
+
+		return cacheLoad{}, err
+	}
+
+	delta := time.Since(startedAt)
+
+	encoded, err := serializer.Marshal(value)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			c.logger,
+			fmt.Sprintf("Failed to cache value with key=%s", key),
+			err,
+		)
+
+		return cacheLoad{value: value, notCached: true}, nil
+	}
+
+	// Stale value is returned to callers, but is not cached in any tier:
+	cached := true
+
+	entry, err := marshalCacheEntry(
+		cacheEntry{
+			Value:     encoded,
+			ExpiresAt: time.Now().Add(ttl).UnixMilli(),
+			Delta:     delta.Milliseconds(),
+		},
+		c.compressor,
+		c.config.Encoding.CompressionThreshold,
+	)
+	if err == nil {
+		c.metrics.payloadSize.WithLabelValues(cachePrefix(key), cacheOperationWrite).Observe(float64(len(entry)))
+		cached, err = c.set(ctx, key, entry, ttl, startedAt, tags...)
+	}
+
+	if c.cachedLocally(key) && (cached || err != nil) {
+		c.localCacheRepository.Set(key, encoded, tags)
+	}
+
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			c.logger,
+			fmt.Sprintf("Failed to cache value with key=%s", key),
+			err,
+		)
+
+		return cacheLoad{value: value, encoded: encoded, notCached: true}, nil
+	}
+
+	return cacheLoad{value: value, encoded: encoded}, nil
+}
+
+// cacheNotFound remembers NotFound result of lookup with short TTL. Error message is stored instead of
+// value to return the same error from cache.
+func (c *CacheDecorator) cacheNotFound(
+	ctx context.Context,
+	key string,
+	notFoundErr error,
+	loadedAt time.Time,
+	tags []string,
+) {
+	entry, err := marshalCacheEntry(
+		cacheEntry{
+			Value:     []byte(notFoundErr.Error()),
+			ExpiresAt: time.Now().Add(c.config.NotFoundTTL).UnixMilli(),
+			NotFound:  true,
+		},
+		nil,
+		0,
+	)
+	if err == nil {
+		c.metrics.payloadSize.WithLabelValues(cachePrefix(key), cacheOperationWrite).Observe(float64(len(entry)))
+		_, err = c.set(ctx, key, entry, c.config.NotFoundTTL, loadedAt, tags...)
+	}
+
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			c.logger,
+			fmt.Sprintf("Failed to cache NotFound result with key=%s", key),
+			err,
+		)
+	}
+}
+
+// notFoundError restores NotFound error from cache entry.
+func notFoundError(entry *cacheEntry) error {
+	return &customerrors.NotFoundError{Message: string(entry.Value)}
+}
+
+// waitForEntry polls cache for entry, which is recomputed by another instance.
+func (c *CacheDecorator) waitForEntry(ctx context.Context, key string) (*cacheEntry, bool) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Stampede.LockWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(c.config.Stampede.LockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-ticker.C:
+			if entry, err := c.getEntry(ctx, key); err == nil {
+				return entry, true
+			}
+		}
+	}
+}
@@ -0,0 +1,1393 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DKhorkov/hmtm-bff/internal/circuitbreaker"
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
+	mockcache "github.com/DKhorkov/libs/cache/mocks"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+)
+
+// cachedValue returns cache entry with provided JSON value, which never expires early.
+func cachedValue(value string) string {
+	return cacheEntryValue(value, math.MaxInt64, 0)
+}
+
+func cacheEntryValue(value string, expiresAt, delta int64) string {
+	entry, err := marshalCacheEntry(cacheEntry{Value: []byte(value), ExpiresAt: expiresAt, Delta: delta}, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(entry)
+}
+
+func TestCacheDecorator_CoalescesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	const callers = 10
+
+	toyID := uint64(1)
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+
+	var misses sync.WaitGroup
+
+	misses.Add(callers)
+
+	release := make(chan struct{})
+
+	cacheMock.
+		EXPECT().
+		Get(gomock.Any(), cacheKey).
+		Return("", errors.New("not found")).
+		Times(callers)
+
+	loggerMock.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(context.Context, string, ...any) { misses.Done() }).
+		Times(callers)
+
+	useCasesMock.
+		EXPECT().
+		GetToyByID(gomock.Any(), toyID).
+		DoAndReturn(
+			func(context.Context, uint64) (*entities.Toy, error) {
+				<-release
+
+				return &entities.Toy{ID: toyID, Name: "Toy"}, nil
+			},
+		).
+		Times(1)
+
+	cacheTagsRepositoryMock.
+		EXPECT().
+		Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+		Return(true, nil).
+		Times(1)
+
+	cacheMock.
+		EXPECT().
+		Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+		Return(nil).
+		Times(1)
+
+	results := make([]*entities.Toy, callers)
+
+	var callersDone sync.WaitGroup
+
+	for i := range callers {
+		callersDone.Add(1)
+
+		go func() {
+			defer callersDone.Done()
+
+			toy, err := decorator.GetToyByID(context.Background(), toyID)
+			assert.NoError(t, err)
+
+			results[i] = toy
+		}()
+	}
+
+	// Giving callers time to join recomputation after cache miss:
+	misses.Wait()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	callersDone.Wait()
+
+	for i := range results {
+		assert.Equal(t, &entities.Toy{ID: toyID, Name: "Toy"}, results[i])
+
+		if i > 0 {
+			assert.NotSame(t, results[0], results[i])
+		}
+	}
+}
+
+func TestCacheDecorator_EarlyExpiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{Stampede: config.CacheStampedeConfig{EarlyExpirationBeta: 1}},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	toyID := uint64(1)
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+	refreshed := make(chan struct{})
+
+	// Entry is still present in cache, but its expiration time has already come:
+	cacheMock.
+		EXPECT().
+		Get(gomock.Any(), cacheKey).
+		Return(cacheEntryValue(`{"id":1,"name":"Cached Toy"}`, 1, 10), nil).
+		Times(1)
+
+	useCasesMock.
+		EXPECT().
+		GetToyByID(gomock.Any(), toyID).
+		Return(&entities.Toy{ID: toyID, Name: "Toy"}, nil).
+		Times(1)
+
+	cacheTagsRepositoryMock.
+		EXPECT().
+		Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+		Return(true, nil).
+		Times(1)
+
+	cacheMock.
+		EXPECT().
+		Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+		DoAndReturn(
+			func(context.Context, string, any, time.Duration) error {
+				close(refreshed)
+
+				return nil
+			},
+		).
+		Times(1)
+
+	toy, err := decorator.GetToyByID(context.Background(), toyID)
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.Toy{ID: toyID, Name: "Cached Toy"}, toy)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("cached value was not refreshed in background")
+	}
+}
+
+func TestCacheDecorator_MissKeepsOperationBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	toyID := uint64(1)
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+
+	// Budget of operation has already been spent:
+	budget := time.Now().Add(-time.Millisecond)
+	ctx, cancel := context.WithDeadline(context.Background(), budget)
+	defer cancel()
+
+	cacheMock.
+		EXPECT().
+		Get(gomock.Any(), cacheKey).
+		Return("", errors.New("not found")).
+		Times(1)
+
+	loggerMock.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	// Load imitates client, which fails fast, when remaining budget is not enough to call downstream service:
+	useCasesMock.
+		EXPECT().
+		GetToyByID(gomock.Any(), toyID).
+		DoAndReturn(
+			func(ctx context.Context, _ uint64) (*entities.Toy, error) {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.Equal(t, budget, deadline)
+
+				return nil, ctx.Err()
+			},
+		).
+		Times(1)
+
+	toy, err := decorator.GetToyByID(ctx, toyID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, toy)
+}
+
+func TestCacheDecorator_StampedeLock(t *testing.T) {
+	stampedeConfig := config.CacheStampedeConfig{
+		LockEnabled:      true,
+		LockTTL:          time.Second,
+		LockWaitTimeout:  100 * time.Millisecond,
+		LockPollInterval: 10 * time.Millisecond,
+	}
+	toyID := uint64(1)
+	toy := &entities.Toy{ID: toyID, Name: "Toy"}
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+	lockKey := cacheLockKeyPrefix + cacheKey
+
+	testCases := []struct {
+		name          string
+		expected      *entities.Toy
+		expectedError error
+		setupMocks    func(
+			cacheMock *mockcache.MockProvider,
+			cacheTagsRepositoryMock *mockrepositories.MockCacheTagsRepository,
+			locksRepositoryMock *mockrepositories.MockLocksRepository,
+			loggerMock *mocklogging.MockLogger,
+			useCasesMock *mockusecases.MockUseCases,
+		)
+	}{
+		{
+			name:     "lock acquired, value is recomputed",
+			expected: toy,
+			setupMocks: func(
+				cacheMock *mockcache.MockProvider,
+				cacheTagsRepositoryMock *mockrepositories.MockCacheTagsRepository,
+				locksRepositoryMock *mockrepositories.MockLocksRepository,
+				loggerMock *mocklogging.MockLogger,
+				useCasesMock *mockusecases.MockUseCases,
+			) {
+				locksRepositoryMock.
+					EXPECT().
+					Acquire(gomock.Any(), lockKey, stampedeConfig.LockTTL).
+					Return("token", true, nil).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+					Return(nil).
+					Times(1)
+
+				locksRepositoryMock.
+					EXPECT().
+					Release(gomock.Any(), lockKey, "token").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "lock is held by another instance, value is received from cache",
+			expected: &entities.Toy{ID: toyID, Name: "Cached Toy"},
+			setupMocks: func(
+				cacheMock *mockcache.MockProvider,
+				cacheTagsRepositoryMock *mockrepositories.MockCacheTagsRepository,
+				locksRepositoryMock *mockrepositories.MockLocksRepository,
+				loggerMock *mocklogging.MockLogger,
+				useCasesMock *mockusecases.MockUseCases,
+			) {
+				locksRepositoryMock.
+					EXPECT().
+					Acquire(gomock.Any(), lockKey, stampedeConfig.LockTTL).
+					Return("", false, nil).
+					Times(1)
+
+				gomock.InOrder(
+					cacheMock.
+						EXPECT().
+						Get(gomock.Any(), cacheKey).
+						Return("", errors.New("not found")).
+						Times(1),
+					cacheMock.
+						EXPECT().
+						Get(gomock.Any(), cacheKey).
+						Return(cachedValue(`{"id":1,"name":"Cached Toy"}`), nil).
+						Times(1),
+				)
+			},
+		},
+		{
+			name:     "lock is held by another instance, waiting timed out",
+			expected: toy,
+			setupMocks: func(
+				cacheMock *mockcache.MockProvider,
+				cacheTagsRepositoryMock *mockrepositories.MockCacheTagsRepository,
+				locksRepositoryMock *mockrepositories.MockLocksRepository,
+				loggerMock *mocklogging.MockLogger,
+				useCasesMock *mockusecases.MockUseCases,
+			) {
+				locksRepositoryMock.
+					EXPECT().
+					Acquire(gomock.Any(), lockKey, stampedeConfig.LockTTL).
+					Return("", false, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					MinTimes(1)
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "lock error, value is recomputed",
+			expected: toy,
+			setupMocks: func(
+				cacheMock *mockcache.MockProvider,
+				cacheTagsRepositoryMock *mockrepositories.MockCacheTagsRepository,
+				locksRepositoryMock *mockrepositories.MockLocksRepository,
+				loggerMock *mocklogging.MockLogger,
+				useCasesMock *mockusecases.MockUseCases,
+			) {
+				locksRepositoryMock.
+					EXPECT().
+					Acquire(gomock.Any(), lockKey, stampedeConfig.LockTTL).
+					Return("", false, errors.New("lock error")).
+					Times(1)
+
+				loggerMock.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCasesMock.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+
+				cacheTagsRepositoryMock.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheMock.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cacheMock := mockcache.NewMockProvider(ctrl)
+			cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+			locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+			loggerMock := mocklogging.NewMockLogger(ctrl)
+			useCasesMock := mockusecases.NewMockUseCases(ctrl)
+			decorator, err := NewCacheDecorator(
+				useCasesMock,
+				cacheMock,
+				nil,
+				cacheTagsRepositoryMock,
+				locksRepositoryMock,
+				config.CacheConfig{Stampede: stampedeConfig},
+				loggerMock,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			cacheMock.
+				EXPECT().
+				Get(gomock.Any(), cacheKey).
+				Return("", errors.New("not found")).
+				Times(1)
+
+			loggerMock.
+				EXPECT().
+				ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(cacheMock, cacheTagsRepositoryMock, locksRepositoryMock, loggerMock, useCasesMock)
+			}
+
+			result, err := decorator.GetToyByID(context.Background(), toyID)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestCacheDecorator_LocalCache(t *testing.T) {
+	toyID := uint64(1)
+	toy := &entities.Toy{ID: toyID, Name: "Toy"}
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+
+	encodedToy, err := json.Marshal(toy)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			cacheProvider *mockcache.MockProvider,
+			localCacheRepository *mockrepositories.MockLocalCacheRepository,
+			cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+			useCases *mockusecases.MockUseCases,
+			logger *mocklogging.MockLogger,
+		)
+		expectedLookups map[string]float64
+	}{
+		{
+			name: "local hit",
+			setupMocks: func(
+				_ *mockcache.MockProvider,
+				localCacheRepository *mockrepositories.MockLocalCacheRepository,
+				_ *mockrepositories.MockCacheTagsRepository,
+				_ *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				localCacheRepository.
+					EXPECT().
+					Get(cacheKey).
+					Return(encodedToy, true).
+					Times(1)
+			},
+			expectedLookups: map[string]float64{
+				localCacheTier + cacheHit: 1,
+			},
+		},
+		{
+			name: "local miss, shared hit",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				localCacheRepository *mockrepositories.MockLocalCacheRepository,
+				_ *mockrepositories.MockCacheTagsRepository,
+				_ *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				localCacheRepository.
+					EXPECT().
+					Get(cacheKey).
+					Return(nil, false).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(string(encodedToy)), nil).
+					Times(1)
+
+				localCacheRepository.
+					EXPECT().
+					Set(cacheKey, encodedToy, []string{toyTag(toyID)}).
+					Times(1)
+			},
+			expectedLookups: map[string]float64{
+				localCacheTier + cacheMiss: 1,
+				sharedCacheTier + cacheHit: 1,
+			},
+		},
+		{
+			name: "local and shared miss",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				localCacheRepository *mockrepositories.MockLocalCacheRepository,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				localCacheRepository.
+					EXPECT().
+					Get(cacheKey).
+					Return(nil, false).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("cache miss")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+
+				localCacheRepository.
+					EXPECT().
+					Set(cacheKey, encodedToy, []string{toyTag(toyID)}).
+					Times(1)
+
+				cacheTagsRepository.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), getToyByIDPolicy.ttl).
+					Return(nil).
+					Times(1)
+			},
+			expectedLookups: map[string]float64{
+				localCacheTier + cacheMiss:  1,
+				sharedCacheTier + cacheMiss: 1,
+			},
+		},
+		{
+			name: "value loaded before invalidation is not cached",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				localCacheRepository *mockrepositories.MockLocalCacheRepository,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				localCacheRepository.
+					EXPECT().
+					Get(cacheKey).
+					Return(nil, false).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("cache miss")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(toy, nil).
+					Times(1)
+
+				cacheTagsRepository.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, getToyByIDPolicy.ttl, gomock.Any()).
+					Return(false, nil).
+					Times(1)
+			},
+			expectedLookups: map[string]float64{
+				localCacheTier + cacheMiss:  1,
+				sharedCacheTier + cacheMiss: 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cacheMock := mockcache.NewMockProvider(ctrl)
+			localCacheRepositoryMock := mockrepositories.NewMockLocalCacheRepository(ctrl)
+			cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+			locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+			loggerMock := mocklogging.NewMockLogger(ctrl)
+			useCasesMock := mockusecases.NewMockUseCases(ctrl)
+			decorator, err := NewCacheDecorator(
+				useCasesMock,
+				cacheMock,
+				localCacheRepositoryMock,
+				cacheTagsRepositoryMock,
+				locksRepositoryMock,
+				config.CacheConfig{
+					Local: config.LocalCacheConfig{
+						Enabled:  true,
+						Prefixes: []string{getToyByIDPolicy.name},
+					},
+				},
+				loggerMock,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			tc.setupMocks(cacheMock, localCacheRepositoryMock, cacheTagsRepositoryMock, useCasesMock, loggerMock)
+
+			result, err := decorator.GetToyByID(context.Background(), toyID)
+			require.NoError(t, err)
+			assert.Equal(t, toy, result)
+
+			for _, tier := range []string{localCacheTier, sharedCacheTier} {
+				for _, lookupResult := range []string{cacheHit, cacheMiss} {
+					assert.Equal(
+						t,
+						tc.expectedLookups[tier+lookupResult],
+						testutil.ToFloat64(decorator.metrics.lookups.WithLabelValues(tier, lookupResult)),
+						"tier=%s, result=%s",
+						tier,
+						lookupResult,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheDecorator_LocalCacheInvalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	localCacheRepositoryMock := mockrepositories.NewMockLocalCacheRepository(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		localCacheRepositoryMock,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{
+			Local: config.LocalCacheConfig{
+				Enabled:  true,
+				Prefixes: []string{getCategoriesPolicy.name},
+			},
+		},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	// Values, which are not cached locally, are not looked up in local tier:
+	cacheMock.
+		EXPECT().
+		Get(gomock.Any(), fmt.Sprintf("%s:%d", getUserByIDPolicy.name, 1)).
+		Return(cachedValue(`{"id":1}`), nil).
+		Times(1)
+
+	_, err = decorator.GetUserByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	// Local tier of current instance is purged without waiting for broadcast:
+	gomock.InOrder(
+		localCacheRepositoryMock.
+			EXPECT().
+			Invalidate(toyTag(1), toysListTag).
+			Times(1),
+		cacheTagsRepositoryMock.
+			EXPECT().
+			Invalidate(gomock.Any(), toyTag(1), toysListTag).
+			Return(nil).
+			Times(1),
+	)
+
+	decorator.invalidate(context.Background(), toyTag(1), toysListTag)
+}
+
+// openCircuitBreaker returns circuit breaker, which does not allow cache calls until the end of test.
+func openCircuitBreaker() *circuitbreaker.CircuitBreaker {
+	circuitBreaker := circuitbreaker.New(
+		config.CircuitBreakerConfig{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Hour,
+		},
+		nil,
+	)
+	circuitBreaker.Failure()
+
+	return circuitBreaker
+}
+
+func TestCacheDecorator_CircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{
+			CircuitBreaker: config.CircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenTimeout:      time.Hour,
+			},
+		},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	categoryID := uint32(1)
+	category := &entities.Category{ID: categoryID, Name: "Category"}
+	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPolicy.name, categoryID)
+
+	useCasesMock.
+		EXPECT().
+		GetCategoryByID(gomock.Any(), categoryID).
+		Return(category, nil).
+		Times(4)
+
+	// Missing key is not a failure, while connection errors open circuit:
+	gomock.InOrder(
+		cacheMock.
+			EXPECT().
+			Get(gomock.Any(), cacheKey).
+			Return("", redis.Nil).
+			Times(1),
+		cacheMock.
+			EXPECT().
+			Get(gomock.Any(), cacheKey).
+			Return("", errors.New("connection refused")).
+			Times(1),
+	)
+
+	cacheTagsRepositoryMock.
+		EXPECT().
+		Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+		Return(true, nil).
+		Times(1)
+
+	cacheMock.
+		EXPECT().
+		Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDPolicy.ttl).
+		Return(nil).
+		Times(1)
+
+	cacheTagsRepositoryMock.
+		EXPECT().
+		Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+		Return(false, errors.New("connection refused")).
+		Times(1)
+
+	loggerMock.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	loggerMock.
+		EXPECT().
+		Info(gomock.Any()).
+		Times(1)
+
+	result, err := decorator.GetCategoryByID(context.Background(), categoryID)
+	require.NoError(t, err)
+	assert.Equal(t, category, result)
+
+	state, ready := decorator.Ready(context.Background())
+	assert.Equal(t, circuitbreaker.StateClosed.String(), state)
+	assert.True(t, ready)
+
+	// Get and Tag failures are consecutive, so circuit is opened and cache is not called anymore:
+	for range 3 {
+		result, err := decorator.GetCategoryByID(context.Background(), categoryID)
+		require.NoError(t, err)
+		assert.Equal(t, category, result)
+	}
+
+	state, ready = decorator.Ready(context.Background())
+	assert.Equal(t, circuitbreaker.StateOpen.String(), state)
+	assert.True(t, ready)
+	assert.Equal(t, float64(circuitbreaker.StateOpen), testutil.ToFloat64(decorator.metrics.circuitBreakerState))
+}
+
+func TestCacheDecorator_CircuitBreakerContextErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheMock := mockcache.NewMockProvider(ctrl)
+	cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+	locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+	loggerMock := mocklogging.NewMockLogger(ctrl)
+	useCasesMock := mockusecases.NewMockUseCases(ctrl)
+	decorator, err := NewCacheDecorator(
+		useCasesMock,
+		cacheMock,
+		nil,
+		cacheTagsRepositoryMock,
+		locksRepositoryMock,
+		config.CacheConfig{
+			CircuitBreaker: config.CircuitBreakerConfig{
+				FailureThreshold: 1,
+				OpenTimeout:      time.Hour,
+			},
+		},
+		loggerMock,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+
+	categoryID := uint32(1)
+	category := &entities.Category{ID: categoryID, Name: "Category"}
+	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPolicy.name, categoryID)
+
+	useCasesMock.
+		EXPECT().
+		GetCategoryByID(gomock.Any(), categoryID).
+		Return(category, nil).
+		Times(1)
+
+	cacheMock.
+		EXPECT().
+		Get(gomock.Any(), cacheKey).
+		Return("", context.Canceled).
+		Times(1)
+
+	cacheTagsRepositoryMock.
+		EXPECT().
+		Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+		Return(false, fmt.Errorf("tag: %w", context.DeadlineExceeded)).
+		Times(1)
+
+	loggerMock.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2)
+
+	result, err := decorator.GetCategoryByID(context.Background(), categoryID)
+	require.NoError(t, err)
+	assert.Equal(t, category, result)
+
+	// Canceled calls and calls, which ran out of budget, are not failures, so circuit is still closed:
+	state, _ := decorator.Ready(context.Background())
+	assert.Equal(t, circuitbreaker.StateClosed.String(), state)
+}
+
+func TestCacheDecorator_Metrics(t *testing.T) {
+	categoryID := uint32(1)
+	category := &entities.Category{ID: categoryID, Name: "Category"}
+	cacheKey := fmt.Sprintf("%s:%d", getCategoryByIDPolicy.name, categoryID)
+
+	encodedCategory, err := json.Marshal(category)
+	require.NoError(t, err)
+
+	// expectMiss sets up loading of value from use cases and caching it with provided Set error:
+	expectMiss := func(
+		cacheProvider *mockcache.MockProvider,
+		cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+		useCases *mockusecases.MockUseCases,
+		setErr error,
+	) {
+		useCases.
+			EXPECT().
+			GetCategoryByID(gomock.Any(), categoryID).
+			Return(category, nil).
+			Times(1)
+
+		cacheTagsRepository.
+			EXPECT().
+			Tag(gomock.Any(), cacheKey, []string{categoriesTag}, getCategoryByIDPolicy.ttl, gomock.Any()).
+			Return(true, nil).
+			Times(1)
+
+		cacheProvider.
+			EXPECT().
+			Set(gomock.Any(), cacheKey, gomock.Any(), getCategoryByIDPolicy.ttl).
+			Return(setErr).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name           string
+		policyDisabled bool
+		circuitOpen    bool
+		setupMocks     func(
+			cacheProvider *mockcache.MockProvider,
+			cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+			useCases *mockusecases.MockUseCases,
+			logger *mocklogging.MockLogger,
+		)
+		expectedOutcome         string
+		expectedPayloadOpsCount int
+	}{
+		{
+			name: "hit",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				_ *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue(string(encodedCategory)), nil).
+					Times(1)
+			},
+			expectedOutcome:         cacheOutcomeHit,
+			expectedPayloadOpsCount: 1,
+		},
+		{
+			name: "miss",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", redis.Nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				expectMiss(cacheProvider, cacheTagsRepository, useCases, nil)
+			},
+			expectedOutcome:         cacheOutcomeMiss,
+			expectedPayloadOpsCount: 1,
+		},
+		{
+			name: "outdated cache entry",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(`{"value":"eyJpZCI6MX0=","expiresAt":0}`, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				expectMiss(cacheProvider, cacheTagsRepository, useCases, nil)
+			},
+			expectedOutcome:         cacheOutcomeMiss,
+			expectedPayloadOpsCount: 2,
+		},
+		{
+			name: "invalid cache entry",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(string([]byte{cacheEntryVersion, 0}), nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				expectMiss(cacheProvider, cacheTagsRepository, useCases, nil)
+			},
+			expectedOutcome:         cacheOutcomeDecodeError,
+			expectedPayloadOpsCount: 2,
+		},
+		{
+			name: "invalid cached value",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(cachedValue("invalid"), nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				expectMiss(cacheProvider, cacheTagsRepository, useCases, nil)
+			},
+			expectedOutcome:         cacheOutcomeDecodeError,
+			expectedPayloadOpsCount: 2,
+		},
+		{
+			name: "set error",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", redis.Nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+
+				expectMiss(cacheProvider, cacheTagsRepository, useCases, errors.New("set error"))
+			},
+			expectedOutcome:         cacheOutcomeSetError,
+			expectedPayloadOpsCount: 1,
+		},
+		{
+			name:           "policy disabled",
+			policyDisabled: true,
+			setupMocks: func(
+				_ *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					GetCategoryByID(gomock.Any(), categoryID).
+					Return(category, nil).
+					Times(1)
+			},
+			expectedOutcome: cacheOutcomeBypassed,
+		},
+		{
+			name:        "circuit open",
+			circuitOpen: true,
+			setupMocks: func(
+				_ *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					GetCategoryByID(gomock.Any(), categoryID).
+					Return(category, nil).
+					Times(1)
+			},
+			expectedOutcome: cacheOutcomeBypassed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cacheMock := mockcache.NewMockProvider(ctrl)
+			cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+			locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+			loggerMock := mocklogging.NewMockLogger(ctrl)
+			useCasesMock := mockusecases.NewMockUseCases(ctrl)
+			decorator, err := NewCacheDecorator(
+				useCasesMock,
+				cacheMock,
+				nil,
+				cacheTagsRepositoryMock,
+				locksRepositoryMock,
+				config.CacheConfig{
+					Policies: map[string]config.CachePolicyConfig{
+						getCategoryByIDPolicy.name: {Disabled: tc.policyDisabled},
+					},
+				},
+				loggerMock,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			if tc.circuitOpen {
+				decorator.circuitBreaker = openCircuitBreaker()
+			}
+
+			tc.setupMocks(cacheMock, cacheTagsRepositoryMock, useCasesMock, loggerMock)
+
+			result, err := decorator.GetCategoryByID(context.Background(), categoryID)
+			require.NoError(t, err)
+			assert.Equal(t, category, result)
+
+			outcomes := []string{
+				cacheOutcomeHit,
+				cacheOutcomeMiss,
+				cacheOutcomeDecodeError,
+				cacheOutcomeSetError,
+				cacheOutcomeBypassed,
+			}
+
+			for _, outcome := range outcomes {
+				var expected float64
+				if outcome == tc.expectedOutcome {
+					expected = 1
+				}
+
+				assert.Equal(
+					t,
+					expected,
+					testutil.ToFloat64(decorator.metrics.requests.WithLabelValues(getCategoryByIDPolicy.name, outcome)),
+					"outcome=%s",
+					outcome,
+				)
+			}
+
+			assert.Equal(t, 1, testutil.CollectAndCount(decorator.metrics.requestDuration))
+			assert.Equal(t, tc.expectedPayloadOpsCount, testutil.CollectAndCount(decorator.metrics.payloadSize))
+		})
+	}
+}
+
+func TestCacheDecorator_NotFound(t *testing.T) {
+	toyID := uint64(1)
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+	notFoundTTL := time.Second * 30
+	notFoundErr := &customerrors.NotFoundError{
+		Message: "toy with id=1 not found",
+		BaseErr: status.Error(codes.NotFound, "toy with id=1 not found"),
+	}
+
+	notFoundEntry, err := marshalCacheEntry(
+		cacheEntry{Value: []byte("toy with id=1 not found"), ExpiresAt: math.MaxInt64, NotFound: true},
+		nil,
+		0,
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		notFoundTTL time.Duration
+		setupMocks  func(
+			cacheProvider *mockcache.MockProvider,
+			cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+			useCases *mockusecases.MockUseCases,
+			logger *mocklogging.MockLogger,
+		)
+		expectedError error
+	}{
+		{
+			name:        "not found is cached",
+			notFoundTTL: notFoundTTL,
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", redis.Nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(nil, notFoundErr).
+					Times(1)
+
+				cacheTagsRepository.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID), toysNotFoundTag}, notFoundTTL, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Set(
+						gomock.Any(),
+						cacheKey,
+						gomock.Cond(func(value []byte) bool {
+							entry, err := unmarshalCacheEntry(value)
+
+							return err == nil && entry.NotFound && string(entry.Value) == "toy with id=1 not found"
+						}),
+						notFoundTTL,
+					).
+					Return(nil).
+					Times(1)
+			},
+			expectedError: notFoundErr,
+		},
+		{
+			name:        "cached not found",
+			notFoundTTL: notFoundTTL,
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				_ *mockusecases.MockUseCases,
+				_ *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return(string(notFoundEntry), nil).
+					Times(1)
+			},
+			expectedError: notFoundErr,
+		},
+		{
+			name:        "other errors are not cached",
+			notFoundTTL: notFoundTTL,
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", redis.Nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(nil, status.Error(codes.Internal, "internal error")).
+					Times(1)
+			},
+			expectedError: status.Error(codes.Internal, "internal error"),
+		},
+		{
+			name: "not found caching disabled",
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				_ *mockrepositories.MockCacheTagsRepository,
+				useCases *mockusecases.MockUseCases,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", redis.Nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				useCases.
+					EXPECT().
+					GetToyByID(gomock.Any(), toyID).
+					Return(nil, notFoundErr).
+					Times(1)
+			},
+			expectedError: notFoundErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cacheMock := mockcache.NewMockProvider(ctrl)
+			cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+			locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+			loggerMock := mocklogging.NewMockLogger(ctrl)
+			useCasesMock := mockusecases.NewMockUseCases(ctrl)
+			decorator, err := NewCacheDecorator(
+				useCasesMock,
+				cacheMock,
+				nil,
+				cacheTagsRepositoryMock,
+				locksRepositoryMock,
+				config.CacheConfig{NotFoundTTL: tc.notFoundTTL},
+				loggerMock,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			tc.setupMocks(cacheMock, cacheTagsRepositoryMock, useCasesMock, loggerMock)
+
+			toy, err := decorator.GetToyByID(context.Background(), toyID)
+			require.Error(t, err)
+			assert.IsType(t, tc.expectedError, err)
+			assert.EqualError(t, err, tc.expectedError.Error())
+			assert.Nil(t, toy)
+		})
+	}
+}
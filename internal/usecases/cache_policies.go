@@ -0,0 +1,485 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rxwycdh/rxhash"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// cachePolicy describes, how result of read use case is cached. Name of policy is used as prefix of
+// cache keys and as name of policy in config overrides.
+type cachePolicy[A, T any] struct {
+	name string
+	ttl  time.Duration
+
+	// key returns part of cache key, which identifies arguments. Empty part is used for use cases
+	// without arguments.
+	key        func(args A) (string, error)
+	load       func(ctx context.Context, useCases interfaces.UseCases, args A) (T, error)
+	tags       func(args A, value T) []string
+	serializer cacheSerializer
+
+	// notFoundTags enables caching of NotFound results. Tags should be invalidated by mutations, which
+	// create entity with provided arguments.
+	notFoundTags func(args A) []string
+}
+
+func (p *cachePolicy[A, T]) cacheKey(args A) (string, error) {
+	key, err := p.key(args)
+	if err != nil || key == "" {
+		return p.name, err
+	}
+
+	return p.name + ":" + key, nil
+}
+
+// registeredCachePolicies contains names of all policies to validate config overrides.
+var registeredCachePolicies = make(map[string]struct{})
+
+// newCachePolicy registers policy.
+func newCachePolicy[A, T any](policy cachePolicy[A, T]) *cachePolicy[A, T] {
+	if _, ok := registeredCachePolicies[policy.name]; ok {
+		panic(fmt.Sprintf("cache policy %s is already registered", policy.name))
+	}
+
+	registeredCachePolicies[policy.name] = struct{}{}
+
+	return &policy
+}
+
+// listArgs are arguments of use cases, which return filtered page of entities. Owner ID is used only
+// by use cases, which return entities of specific owner.
+type listArgs[F any] struct {
+	ownerID    uint64
+	pagination *entities.Pagination
+	filters    F
+}
+
+// userArgs are arguments of use cases, which return data of authorized user. User ID is resolved by
+// access token before reading cache and is part of cache key, so that users never share cached data.
+type userArgs[P any] struct {
+	userID      uint64
+	accessToken string
+	params      P
+}
+
+func noKey(struct{}) (string, error) {
+	return "", nil
+}
+
+func idKey[ID uint32 | uint64](id ID) (string, error) {
+	return strconv.FormatUint(uint64(id), 10), nil
+}
+
+// hashKey joins owner IDs and hashes of structs, so that equal arguments produce equal keys.
+func hashKey(parts ...any) (string, error) {
+	hashes := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		if id, ok := part.(uint64); ok {
+			hashes = append(hashes, strconv.FormatUint(id, 10))
+
+			continue
+		}
+
+		hash, err := rxhash.HashStruct(part)
+		if err != nil {
+			return "", err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return strings.Join(hashes, "_"), nil
+}
+
+func userKey(args userArgs[struct{}]) (string, error) {
+	return strconv.FormatUint(args.userID, 10), nil
+}
+
+func userParamsKey[P any](args userArgs[P]) (string, error) {
+	return hashKey(args.userID, args.params)
+}
+
+func listKey[F any](args listArgs[F]) (string, error) {
+	return hashKey(args.pagination, args.filters)
+}
+
+func ownerListKey[F any](args listArgs[F]) (string, error) {
+	return hashKey(args.ownerID, args.pagination, args.filters)
+}
+
+func ownerCountKey[F any](args listArgs[F]) (string, error) {
+	return hashKey(args.ownerID, args.filters)
+}
+
+// withTags is used for values, which tags do not depend on arguments and value itself.
+func withTags[A, T any](tags ...string) func(A, T) []string {
+	return func(A, T) []string {
+		return tags
+	}
+}
+
+var (
+	getUserByIDPolicy = newCachePolicy(cachePolicy[uint64, *entities.User]{
+		name: "get_user_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint64) (*entities.User, error) {
+			return useCases.GetUserByID(ctx, id)
+		},
+		tags: func(id uint64, _ *entities.User) []string {
+			return []string{userTag(id)}
+		},
+		notFoundTags: func(id uint64) []string {
+			return []string{userTag(id), usersNotFoundTag}
+		},
+	})
+
+	getUserByEmailPolicy = newCachePolicy(cachePolicy[string, *entities.User]{
+		name: "get_user_by_email",
+		ttl:  time.Hour * 24,
+		key: func(email string) (string, error) {
+			return email, nil
+		},
+		load: func(ctx context.Context, useCases interfaces.UseCases, email string) (*entities.User, error) {
+			return useCases.GetUserByEmail(ctx, email)
+		},
+		tags: func(_ string, user *entities.User) []string {
+			return []string{userTag(user.ID)}
+		},
+	})
+
+	getUsersPolicy = newCachePolicy(cachePolicy[*entities.Pagination, []entities.User]{
+		name: "users",
+		ttl:  time.Minute * 5,
+		key: func(pagination *entities.Pagination) (string, error) {
+			return hashKey(pagination)
+		},
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			pagination *entities.Pagination,
+		) ([]entities.User, error) {
+			return useCases.GetUsers(ctx, pagination)
+		},
+		tags: withTags[*entities.Pagination, []entities.User](usersListTag),
+	})
+
+	getToysPolicy = newCachePolicy(cachePolicy[listArgs[*entities.ToysFilters], []entities.Toy]{
+		name: "toys",
+		ttl:  time.Minute * 5,
+		key:  listKey[*entities.ToysFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.ToysFilters],
+		) ([]entities.Toy, error) {
+			return useCases.GetToys(ctx, args.pagination, args.filters)
+		},
+		tags: withTags[listArgs[*entities.ToysFilters], []entities.Toy](toysListTag),
+	})
+
+	countToysPolicy = newCachePolicy(cachePolicy[*entities.ToysFilters, uint64]{
+		name: "toys_count",
+		ttl:  time.Minute * 5,
+		key: func(filters *entities.ToysFilters) (string, error) {
+			return hashKey(filters)
+		},
+		load: func(ctx context.Context, useCases interfaces.UseCases, filters *entities.ToysFilters) (uint64, error) {
+			return useCases.CountToys(ctx, filters)
+		},
+		tags: withTags[*entities.ToysFilters, uint64](toysListTag),
+	})
+
+	getMasterToysPolicy = newCachePolicy(cachePolicy[listArgs[*entities.ToysFilters], []entities.Toy]{
+		name: "master_toys",
+		ttl:  time.Hour * 6,
+		key:  ownerListKey[*entities.ToysFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.ToysFilters],
+		) ([]entities.Toy, error) {
+			return useCases.GetMasterToys(ctx, args.ownerID, args.pagination, args.filters)
+		},
+		tags: func(args listArgs[*entities.ToysFilters], _ []entities.Toy) []string {
+			return []string{masterTag(args.ownerID)}
+		},
+	})
+
+	countMasterToysPolicy = newCachePolicy(cachePolicy[listArgs[*entities.ToysFilters], uint64]{
+		name: "master_toys_count",
+		ttl:  time.Hour * 6,
+		key:  ownerCountKey[*entities.ToysFilters],
+		load: func(ctx context.Context, useCases interfaces.UseCases, args listArgs[*entities.ToysFilters]) (uint64, error) {
+			return useCases.CountMasterToys(ctx, args.ownerID, args.filters)
+		},
+		tags: func(args listArgs[*entities.ToysFilters], _ uint64) []string {
+			return []string{masterTag(args.ownerID)}
+		},
+	})
+
+	getToyByIDPolicy = newCachePolicy(cachePolicy[uint64, *entities.Toy]{
+		name: "get_toy_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint64) (*entities.Toy, error) {
+			return useCases.GetToyByID(ctx, id)
+		},
+		tags: func(id uint64, _ *entities.Toy) []string {
+			return []string{toyTag(id)}
+		},
+		notFoundTags: func(id uint64) []string {
+			return []string{toyTag(id), toysNotFoundTag}
+		},
+	})
+
+	getMastersPolicy = newCachePolicy(cachePolicy[listArgs[*entities.MastersFilters], []entities.Master]{
+		name: "get_masters",
+		ttl:  time.Hour * 6,
+		key:  listKey[*entities.MastersFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.MastersFilters],
+		) ([]entities.Master, error) {
+			return useCases.GetMasters(ctx, args.pagination, args.filters)
+		},
+		tags: withTags[listArgs[*entities.MastersFilters], []entities.Master](mastersListTag),
+	})
+
+	countMastersPolicy = newCachePolicy(cachePolicy[*entities.MastersFilters, uint64]{
+		name: "masters_count",
+		ttl:  time.Minute * 5,
+		key: func(filters *entities.MastersFilters) (string, error) {
+			return hashKey(filters)
+		},
+		load: func(ctx context.Context, useCases interfaces.UseCases, filters *entities.MastersFilters) (uint64, error) {
+			return useCases.CountMasters(ctx, filters)
+		},
+		tags: withTags[*entities.MastersFilters, uint64](mastersListTag),
+	})
+
+	getMasterByIDPolicy = newCachePolicy(cachePolicy[uint64, *entities.Master]{
+		name: "get_master_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint64) (*entities.Master, error) {
+			return useCases.GetMasterByID(ctx, id)
+		},
+		tags: func(id uint64, _ *entities.Master) []string {
+			return []string{masterTag(id)}
+		},
+		notFoundTags: func(id uint64) []string {
+			return []string{masterTag(id), mastersNotFoundTag}
+		},
+	})
+
+	getMasterByUserIDPolicy = newCachePolicy(cachePolicy[uint64, *entities.Master]{
+		name: "get_master_by_user_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, userID uint64) (*entities.Master, error) {
+			return useCases.GetMasterByUserID(ctx, userID)
+		},
+		tags: func(_ uint64, master *entities.Master) []string {
+			return []string{masterTag(master.ID)}
+		},
+	})
+
+	getCategoriesPolicy = newCachePolicy(cachePolicy[struct{}, []entities.Category]{
+		name: "get_categories",
+		ttl:  time.Hour * 24,
+		key:  noKey,
+		load: func(ctx context.Context, useCases interfaces.UseCases, _ struct{}) ([]entities.Category, error) {
+			return useCases.GetAllCategories(ctx)
+		},
+		tags: withTags[struct{}, []entities.Category](categoriesTag),
+	})
+
+	getCategoryByIDPolicy = newCachePolicy(cachePolicy[uint32, *entities.Category]{
+		name: "get_category_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint32],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint32) (*entities.Category, error) {
+			return useCases.GetCategoryByID(ctx, id)
+		},
+		tags: withTags[uint32, *entities.Category](categoriesTag),
+	})
+
+	getTagsPolicy = newCachePolicy(cachePolicy[struct{}, []entities.Tag]{
+		name: "get_tags",
+		ttl:  time.Hour * 24,
+		key:  noKey,
+		load: func(ctx context.Context, useCases interfaces.UseCases, _ struct{}) ([]entities.Tag, error) {
+			return useCases.GetAllTags(ctx)
+		},
+		tags: withTags[struct{}, []entities.Tag](tagsTag),
+	})
+
+	getTagByIDPolicy = newCachePolicy(cachePolicy[uint32, *entities.Tag]{
+		name: "get_tag_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint32],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint32) (*entities.Tag, error) {
+			return useCases.GetTagByID(ctx, id)
+		},
+		tags: withTags[uint32, *entities.Tag](tagsTag),
+	})
+
+	getTicketByIDPolicy = newCachePolicy(cachePolicy[uint64, *entities.Ticket]{
+		name: "get_ticket_by_id",
+		ttl:  time.Hour * 24,
+		key:  idKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, id uint64) (*entities.Ticket, error) {
+			return useCases.GetTicketByID(ctx, id)
+		},
+		tags: func(id uint64, _ *entities.Ticket) []string {
+			return []string{ticketTag(id)}
+		},
+		notFoundTags: func(id uint64) []string {
+			return []string{ticketTag(id), ticketsNotFoundTag}
+		},
+	})
+
+	getTicketsPolicy = newCachePolicy(cachePolicy[listArgs[*entities.TicketsFilters], []entities.Ticket]{
+		name: "get_tickets",
+		ttl:  time.Minute * 5,
+		key:  listKey[*entities.TicketsFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.TicketsFilters],
+		) ([]entities.Ticket, error) {
+			return useCases.GetTickets(ctx, args.pagination, args.filters)
+		},
+		tags: withTags[listArgs[*entities.TicketsFilters], []entities.Ticket](ticketsListTag),
+	})
+
+	getUserTicketsPolicy = newCachePolicy(cachePolicy[listArgs[*entities.TicketsFilters], []entities.Ticket]{
+		name: "get_user_tickets",
+		ttl:  time.Minute * 5,
+		key:  ownerListKey[*entities.TicketsFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.TicketsFilters],
+		) ([]entities.Ticket, error) {
+			return useCases.GetUserTickets(ctx, args.ownerID, args.pagination, args.filters)
+		},
+		tags: func(args listArgs[*entities.TicketsFilters], _ []entities.Ticket) []string {
+			return []string{userTicketsTag(args.ownerID)}
+		},
+	})
+
+	countTicketsPolicy = newCachePolicy(cachePolicy[*entities.TicketsFilters, uint64]{
+		name: "tickets_count",
+		ttl:  time.Minute * 5,
+		key: func(filters *entities.TicketsFilters) (string, error) {
+			return hashKey(filters)
+		},
+		load: func(ctx context.Context, useCases interfaces.UseCases, filters *entities.TicketsFilters) (uint64, error) {
+			return useCases.CountTickets(ctx, filters)
+		},
+		tags: withTags[*entities.TicketsFilters, uint64](ticketsListTag),
+	})
+
+	countUserTicketsPolicy = newCachePolicy(cachePolicy[listArgs[*entities.TicketsFilters], uint64]{
+		name: "user_tickets_count",
+		ttl:  time.Hour * 6,
+		key:  ownerCountKey[*entities.TicketsFilters],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args listArgs[*entities.TicketsFilters],
+		) (uint64, error) {
+			return useCases.CountUserTickets(ctx, args.ownerID, args.filters)
+		},
+		tags: func(args listArgs[*entities.TicketsFilters], _ uint64) []string {
+			return []string{userTicketsTag(args.ownerID)}
+		},
+	})
+
+	getRespondByIDPolicy = newCachePolicy(cachePolicy[userArgs[uint64], *entities.Respond]{
+		name: "get_respond_by_id",
+		ttl:  time.Hour,
+		key:  userParamsKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, args userArgs[uint64]) (*entities.Respond, error) {
+			return useCases.GetRespondByID(ctx, args.params, args.accessToken)
+		},
+		tags: func(args userArgs[uint64], respond *entities.Respond) []string {
+			return []string{respondTag(args.params), ticketTag(respond.TicketID)}
+		},
+	})
+
+	getTicketRespondsPolicy = newCachePolicy(cachePolicy[userArgs[uint64], []entities.Respond]{
+		name: "get_ticket_responds",
+		ttl:  time.Hour,
+		key:  userParamsKey[uint64],
+		load: func(ctx context.Context, useCases interfaces.UseCases, args userArgs[uint64]) ([]entities.Respond, error) {
+			return useCases.GetTicketResponds(ctx, args.params, args.accessToken)
+		},
+		tags: func(args userArgs[uint64], responds []entities.Respond) []string {
+			return respondsTags(responds, ticketTag(args.params))
+		},
+	})
+
+	getMyRespondsPolicy = newCachePolicy(cachePolicy[userArgs[struct{}], []entities.Respond]{
+		name: "get_my_responds",
+		ttl:  time.Hour,
+		key:  userKey,
+		load: func(ctx context.Context, useCases interfaces.UseCases, args userArgs[struct{}]) ([]entities.Respond, error) {
+			return useCases.GetMyResponds(ctx, args.accessToken)
+		},
+		tags: func(args userArgs[struct{}], responds []entities.Respond) []string {
+			return respondsTags(responds, userRespondsTag(args.userID))
+		},
+	})
+
+	// Emails are sent by Notifications asynchronously, so that email communications rely on short TTL:
+	getMyEmailCommunicationsPolicy = newCachePolicy(cachePolicy[userArgs[*entities.Pagination], []entities.Email]{
+		name: "get_my_email_communications",
+		ttl:  time.Minute * 5,
+		key:  userParamsKey[*entities.Pagination],
+		load: func(
+			ctx context.Context,
+			useCases interfaces.UseCases,
+			args userArgs[*entities.Pagination],
+		) ([]entities.Email, error) {
+			return useCases.GetMyEmailCommunications(ctx, args.accessToken, args.params)
+		},
+		tags: func(args userArgs[*entities.Pagination], _ []entities.Email) []string {
+			return []string{userTag(args.userID)}
+		},
+	})
+
+	countMyEmailCommunicationsPolicy = newCachePolicy(cachePolicy[userArgs[struct{}], uint64]{
+		name: "my_email_communications_count",
+		ttl:  time.Minute * 5,
+		key:  userKey,
+		load: func(ctx context.Context, useCases interfaces.UseCases, args userArgs[struct{}]) (uint64, error) {
+			return useCases.CountMyEmailCommunications(ctx, args.accessToken)
+		},
+		tags: func(args userArgs[struct{}], _ uint64) []string {
+			return []string{userTag(args.userID)}
+		},
+	})
+)
+
+// respondsTags attaches list of Responds to each of them, so that list is invalidated by mutations of
+// any Respond.
+func respondsTags(responds []entities.Respond, tags ...string) []string {
+	for _, respond := range responds {
+		tags = append(tags, respondTag(respond.ID))
+	}
+
+	return tags
+}
@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rxwycdh/rxhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DKhorkov/hmtm-bff/internal/config"
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockrepositories "github.com/DKhorkov/hmtm-bff/mocks/repositories"
+	mockusecases "github.com/DKhorkov/hmtm-bff/mocks/usecases"
+	mockcache "github.com/DKhorkov/libs/cache/mocks"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+)
+
+func TestCachePolicy_CacheKey(t *testing.T) {
+	pagination := &entities.Pagination{Limit: pointers.New[uint64](10)}
+	filters := &entities.ToysFilters{Search: pointers.New("toy")}
+
+	paginationHash, err := rxhash.HashStruct(pagination)
+	require.NoError(t, err)
+
+	filtersHash, err := rxhash.HashStruct(filters)
+	require.NoError(t, err)
+
+	key, err := getCategoriesPolicy.cacheKey(struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "get_categories", key)
+
+	key, err = getToyByIDPolicy.cacheKey(1)
+	require.NoError(t, err)
+	assert.Equal(t, "get_toy_by_id:1", key)
+
+	key, err = getToysPolicy.cacheKey(listArgs[*entities.ToysFilters]{pagination: pagination, filters: filters})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("toys:%s_%s", paginationHash, filtersHash), key)
+
+	key, err = getMasterToysPolicy.cacheKey(
+		listArgs[*entities.ToysFilters]{ownerID: 2, pagination: pagination, filters: filters},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("master_toys:2_%s_%s", paginationHash, filtersHash), key)
+
+	key, err = countMasterToysPolicy.cacheKey(listArgs[*entities.ToysFilters]{ownerID: 2, filters: filters})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("master_toys_count:2_%s", filtersHash), key)
+}
+
+func TestCacheDecorator_PolicyOverrides(t *testing.T) {
+	toyID := uint64(1)
+	toy := &entities.Toy{ID: toyID, Name: "Toy"}
+	cacheKey := fmt.Sprintf("%s:%d", getToyByIDPolicy.name, toyID)
+
+	testCases := []struct {
+		name       string
+		policies   map[string]config.CachePolicyConfig
+		setupMocks func(
+			cacheProvider *mockcache.MockProvider,
+			cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+			logger *mocklogging.MockLogger,
+		)
+	}{
+		{
+			name: "disabled policy, cache is not called",
+			policies: map[string]config.CachePolicyConfig{
+				getToyByIDPolicy.name: {Disabled: true},
+			},
+		},
+		{
+			name: "TTL override",
+			policies: map[string]config.CachePolicyConfig{
+				getToyByIDPolicy.name: {TTL: time.Minute},
+			},
+			setupMocks: func(
+				cacheProvider *mockcache.MockProvider,
+				cacheTagsRepository *mockrepositories.MockCacheTagsRepository,
+				logger *mocklogging.MockLogger,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), cacheKey).
+					Return("", errors.New("not found")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				cacheTagsRepository.
+					EXPECT().
+					Tag(gomock.Any(), cacheKey, []string{toyTag(toyID)}, time.Minute, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Set(gomock.Any(), cacheKey, gomock.Any(), time.Minute).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cacheMock := mockcache.NewMockProvider(ctrl)
+			cacheTagsRepositoryMock := mockrepositories.NewMockCacheTagsRepository(ctrl)
+			locksRepositoryMock := mockrepositories.NewMockLocksRepository(ctrl)
+			loggerMock := mocklogging.NewMockLogger(ctrl)
+			useCasesMock := mockusecases.NewMockUseCases(ctrl)
+			decorator, err := NewCacheDecorator(
+				useCasesMock,
+				cacheMock,
+				nil,
+				cacheTagsRepositoryMock,
+				locksRepositoryMock,
+				config.CacheConfig{Policies: tc.policies},
+				loggerMock,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(t, err)
+
+			useCasesMock.
+				EXPECT().
+				GetToyByID(gomock.Any(), toyID).
+				Return(toy, nil).
+				Times(1)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(cacheMock, cacheTagsRepositoryMock, loggerMock)
+			}
+
+			result, err := decorator.GetToyByID(context.Background(), toyID)
+			require.NoError(t, err)
+			assert.Equal(t, toy, result)
+		})
+	}
+}
+
+func TestNewCacheDecorator_UnknownPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cacheConfig config.CacheConfig
+	}{
+		{
+			name: "policy override",
+			cacheConfig: config.CacheConfig{
+				Policies: map[string]config.CachePolicyConfig{"unknown": {Disabled: true}},
+			},
+		},
+		{
+			name: "local cache",
+			cacheConfig: config.CacheConfig{
+				Local: config.LocalCacheConfig{Prefixes: []string{getTagsPolicy.name, "unknown"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decorator, err := NewCacheDecorator(nil, nil, nil, nil, nil, tc.cacheConfig, nil, prometheus.NewRegistry())
+			require.Error(t, err)
+			require.Nil(t, decorator)
+		})
+	}
+}
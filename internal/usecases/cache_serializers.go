@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Names of cache serializers, which could be used in config:
+const (
+	jsonCacheCodec    = "json"
+	msgpackCacheCodec = "msgpack"
+)
+
+// cacheSerializer converts values of cache policy to bytes, which are stored in cache, and back.
+type cacheSerializer interface {
+	Marshal(value any) ([]byte, error)
+	Unmarshal(data []byte, value any) error
+}
+
+// newCacheSerializer returns serializer by its name from config.
+func newCacheSerializer(codec string) (cacheSerializer, error) {
+	switch codec {
+	case "", jsonCacheCodec:
+		return jsonCacheSerializer{}, nil
+	case msgpackCacheCodec:
+		return msgpackCacheSerializer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache codec %s", codec)
+	}
+}
+
+type jsonCacheSerializer struct{}
+
+func (jsonCacheSerializer) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCacheSerializer) Unmarshal(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
+
+// msgpackCacheSerializer stores values in compact binary format. Struct fields are encoded by names,
+// so that adding new fields to entities does not break decoding of already cached values.
+type msgpackCacheSerializer struct{}
+
+// Msgpack timestamps do not keep time zone, so times are encoded in binary format of time package,
+// which keeps zone offset like JSON does:
+func init() {
+	msgpack.Register(
+		time.Time{},
+		func(encoder *msgpack.Encoder, value reflect.Value) error {
+			data, err := value.Interface().(time.Time).MarshalBinary()
+			if err != nil {
+				return err
+			}
+
+			return encoder.EncodeBytes(data)
+		},
+		func(decoder *msgpack.Decoder, value reflect.Value) error {
+			data, err := decoder.DecodeBytes()
+			if err != nil {
+				return err
+			}
+
+			return value.Addr().Interface().(*time.Time).UnmarshalBinary(data)
+		},
+	)
+}
+
+func (msgpackCacheSerializer) Marshal(value any) ([]byte, error) {
+	return msgpack.Marshal(value)
+}
+
+func (msgpackCacheSerializer) Unmarshal(data []byte, value any) error {
+	return msgpack.Unmarshal(data, value)
+}
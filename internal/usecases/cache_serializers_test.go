@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+)
+
+func TestCacheSerializers(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	toys := []entities.Toy{
+		{
+			ID:          1,
+			Name:        "Toy",
+			Description: "Description",
+			Price:       120.5,
+			Quantity:    2,
+			Tags:        []entities.Tag{{ID: 1, Name: "Tag"}},
+			Attachments: []entities.ToyAttachment{{ID: 1, ToyID: 1, Link: "link", CreatedAt: createdAt, UpdatedAt: createdAt}},
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+	}
+
+	master := &entities.Master{ID: 1, UserID: 1, Info: pointers.New("Info"), CreatedAt: createdAt}
+
+	for _, codec := range []string{jsonCacheCodec, msgpackCacheCodec} {
+		t.Run(codec, func(t *testing.T) {
+			serializer, err := newCacheSerializer(codec)
+			require.NoError(t, err)
+
+			encoded, err := serializer.Marshal(toys)
+			require.NoError(t, err)
+
+			var decodedToys []entities.Toy
+			require.NoError(t, serializer.Unmarshal(encoded, &decodedToys))
+			assert.Equal(t, toys, decodedToys)
+
+			encoded, err = serializer.Marshal(master)
+			require.NoError(t, err)
+
+			var decodedMaster *entities.Master
+			require.NoError(t, serializer.Unmarshal(encoded, &decodedMaster))
+			assert.Equal(t, master, decodedMaster)
+		})
+	}
+}
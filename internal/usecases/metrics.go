@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace      = "hmtm_bff"
+	cacheMetricsSubsystem = "cache"
+
+	// Cache tiers:
+	localCacheTier  = "local"
+	sharedCacheTier = "redis"
+
+	// Cache lookup results:
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+
+	// Outcomes of cached use case requests:
+	cacheOutcomeHit         = "hit"
+	cacheOutcomeMiss        = "miss"
+	cacheOutcomeDecodeError = "decode_error"
+	cacheOutcomeSetError    = "set_error"
+	cacheOutcomeBypassed    = "bypassed"
+
+	// Operations with cache payloads:
+	cacheOperationRead  = "read"
+	cacheOperationWrite = "write"
+)
+
+type cacheMetrics struct {
+	lookups             *prometheus.CounterVec
+	requests            *prometheus.CounterVec
+	requestDuration     *prometheus.HistogramVec
+	payloadSize         *prometheus.HistogramVec
+	circuitBreakerState prometheus.Gauge
+}
+
+func newCacheMetrics(registerer prometheus.Registerer) (*cacheMetrics, error) {
+	metrics := &cacheMetrics{
+		lookups: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheMetricsSubsystem,
+				Name:      "lookups_total",
+				Help:      "Number of cache lookups by tier and result.",
+			},
+			[]string{"tier", "result"},
+		),
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheMetricsSubsystem,
+				Name:      "requests_total",
+				Help:      "Number of cached use case requests by cache prefix and outcome.",
+			},
+			[]string{"prefix", "outcome"},
+		),
+		requestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheMetricsSubsystem,
+				Name:      "request_duration_seconds",
+				Help:      "Duration of cached use case requests by cache prefix and outcome.",
+				Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14), // From 0.5ms to ~4s
+			},
+			[]string{"prefix", "outcome"},
+		),
+		payloadSize: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheMetricsSubsystem,
+				Name:      "payload_size_bytes",
+				Help:      "Size of cache entries, read from or written to Redis, by cache prefix.",
+				Buckets:   prometheus.ExponentialBuckets(64, 4, 9), // From 64B to 4MiB
+			},
+			[]string{"prefix", "operation"},
+		),
+		circuitBreakerState: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: cacheMetricsSubsystem,
+				Name:      "circuit_breaker_state",
+				Help:      "State of cache circuit breaker: 0 - closed, 1 - half-open, 2 - open.",
+			},
+		),
+	}
+
+	collectors := []prometheus.Collector{
+		metrics.lookups,
+		metrics.requests,
+		metrics.requestDuration,
+		metrics.payloadSize,
+		metrics.circuitBreakerState,
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return metrics, nil
+}
+
+// observeRequest records outcome and duration of cached use case request.
+func (m *cacheMetrics) observeRequest(prefix, outcome string, startedAt time.Time) {
+	m.requests.WithLabelValues(prefix, outcome).Inc()
+	m.requestDuration.WithLabelValues(prefix, outcome).Observe(time.Since(startedAt).Seconds())
+}
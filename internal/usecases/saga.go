@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+)
+
+// saga tracks completed steps of multistep operation and undoes them in reverse order,
+// if one of the next steps fails. Compensations, which failed during execution,
+// are saved for further retry.
+type saga struct {
+	name                 string
+	compensations        []entities.Compensation
+	compensationsService interfaces.CompensationsService
+	logger               logging.Logger
+}
+
+func newSaga(
+	name string,
+	compensationsService interfaces.CompensationsService,
+	logger logging.Logger,
+) *saga {
+	return &saga{
+		name:                 name,
+		compensationsService: compensationsService,
+		logger:               logger,
+	}
+}
+
+// addDeleteFilesCompensation registers deletion of uploaded files as compensation of step.
+func (s *saga) addDeleteFilesCompensation(step string, links []string) {
+	if len(links) == 0 {
+		return
+	}
+
+	s.compensations = append(
+		s.compensations,
+		entities.Compensation{
+			Saga:     s.name,
+			Step:     step,
+			Kind:     entities.CompensationKindDeleteFiles,
+			FileKeys: filenamesFromLinks(links),
+		},
+	)
+}
+
+// compensate undoes all registered steps in reverse order.
+func (s *saga) compensate(ctx context.Context) {
+	// Compensation should be processed even if request was canceled:
+	ctx = context.WithoutCancel(ctx)
+
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		s.execute(ctx, s.compensations[i])
+	}
+
+	s.compensations = nil
+}
+
+// deleteFiles deletes files, which are not used anymore after successful saga completion.
+// Failed deletion is saved for further retry and does not fail saga.
+func (s *saga) deleteFiles(ctx context.Context, step string, filenames []string) {
+	if len(filenames) == 0 {
+		return
+	}
+
+	s.execute(
+		context.WithoutCancel(ctx),
+		entities.Compensation{
+			Saga:     s.name,
+			Step:     step,
+			Kind:     entities.CompensationKindDeleteFiles,
+			FileKeys: filenames,
+		},
+	)
+}
+
+func (s *saga) execute(ctx context.Context, compensation entities.Compensation) {
+	err := s.compensationsService.ExecuteCompensation(ctx, compensation)
+	if err == nil {
+		return
+	}
+
+	logging.LogErrorContext(
+		ctx,
+		s.logger,
+		fmt.Sprintf(
+			"Failed to execute Compensation for step=%s of saga=%s. Saving it for retry",
+			compensation.Step,
+			compensation.Saga,
+		),
+		err,
+	)
+
+	compensation.Error = err.Error()
+	compensation.Attempts++
+	compensation.CreatedAt = time.Now().UTC()
+
+	// Error is logged by CompensationsService:
+	_ = s.compensationsService.SaveCompensation(ctx, compensation)
+}
+
+func filenamesFromLinks(links []string) []string {
+	filenames := make([]string, 0, len(links))
+	for _, link := range links {
+		filenames = append(filenames, entities.FileKeyFromLink(link))
+	}
+
+	return filenames
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogger "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
+)
+
+func TestSaga_Compensate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setupSaga  func(s *saga)
+		setupMocks func(
+			compensationsService *mockservices.MockCompensationsService,
+			logger *mocklogger.MockLogger,
+		)
+	}{
+		{
+			name: "compensations are executed in reverse order",
+			setupSaga: func(s *saga) {
+				s.addDeleteFilesCompensation("first", []string{"https://bucket/first.jpg"})
+				s.addDeleteFilesCompensation("empty", nil)
+				s.addDeleteFilesCompensation("second", []string{"second.jpg"})
+			},
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mocklogger.MockLogger,
+			) {
+				gomock.InOrder(
+					compensationsService.
+						EXPECT().
+						ExecuteCompensation(
+							gomock.Any(),
+							entities.Compensation{
+								Saga:     "test",
+								Step:     "second",
+								Kind:     entities.CompensationKindDeleteFiles,
+								FileKeys: []string{"second.jpg"},
+							},
+						).
+						Return(nil),
+					compensationsService.
+						EXPECT().
+						ExecuteCompensation(
+							gomock.Any(),
+							entities.Compensation{
+								Saga:     "test",
+								Step:     "first",
+								Kind:     entities.CompensationKindDeleteFiles,
+								FileKeys: []string{"first.jpg"},
+							},
+						).
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "failed compensation is saved and next ones are executed",
+			setupSaga: func(s *saga) {
+				s.addDeleteFilesCompensation("first", []string{"first.jpg"})
+				s.addDeleteFilesCompensation("second", []string{"second.jpg"})
+			},
+			setupMocks: func(
+				compensationsService *mockservices.MockCompensationsService,
+				logger *mocklogger.MockLogger,
+			) {
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("test error")).
+					Times(2)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(
+						gomock.Any(),
+						gomock.Cond(func(compensation entities.Compensation) bool {
+							return compensation.Attempts == 1 &&
+								compensation.Error == "test error" &&
+								!compensation.CreatedAt.IsZero()
+						}),
+					).
+					Return(errors.New("test error")).
+					Times(2)
+			},
+		},
+		{
+			name:      "nothing to compensate",
+			setupSaga: func(_ *saga) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			compensationsService := mockservices.NewMockCompensationsService(ctrl)
+			logger := mocklogger.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(compensationsService, logger)
+			}
+
+			s := newSaga("test", compensationsService, logger)
+			tc.setupSaga(s)
+			s.compensate(ctx)
+
+			require.Empty(t, s.compensations)
+		})
+	}
+}
+
+func TestSaga_DeleteFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	logger := mocklogger.NewMockLogger(ctrl)
+
+	compensationsService.
+		EXPECT().
+		ExecuteCompensation(
+			gomock.Any(),
+			entities.Compensation{
+				Saga:     "test",
+				Step:     "cleanup",
+				Kind:     entities.CompensationKindDeleteFiles,
+				FileKeys: []string{"old.jpg"},
+			},
+		).
+		Return(nil).
+		Times(1)
+
+	s := newSaga("test", compensationsService, logger)
+	s.deleteFiles(ctx, "cleanup", []string{"old.jpg"})
+
+	// No calls expected for empty files list:
+	s.deleteFiles(ctx, "cleanup", nil)
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path"
@@ -17,12 +18,19 @@ import (
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
 	customerrors "github.com/DKhorkov/hmtm-bff/internal/errors"
 	"github.com/DKhorkov/hmtm-bff/internal/interfaces"
+	"github.com/DKhorkov/hmtm-bff/internal/requestcache"
 )
 
 const (
 	tagsLimit              = 10
 	attachmentsLimit       = 5
 	searchQueryLengthLimit = 50
+
+	// Toy photos and avatars are shown to everyone, while Ticket attachments could contain
+	// private data of customer and are available only via presigned links:
+	toyAttachmentsVisibility    = entities.FileVisibilityPublic
+	avatarsVisibility           = entities.FileVisibilityPublic
+	ticketAttachmentsVisibility = entities.FileVisibilityPrivate
 )
 
 func New(
@@ -31,6 +39,8 @@ func New(
 	fileStorageService interfaces.FileStorageService,
 	ticketsService interfaces.TicketsService,
 	notificationsService interfaces.NotificationsService,
+	compensationsService interfaces.CompensationsService,
+	uploadsService interfaces.UploadsService,
 	validationConfig config.ValidationConfig,
 	logger logging.Logger,
 	traceProvider tracing.Provider,
@@ -41,6 +51,8 @@ func New(
 		fileStorageService:   fileStorageService,
 		ticketsService:       ticketsService,
 		notificationsService: notificationsService,
+		compensationsService: compensationsService,
+		uploadsService:       uploadsService,
 		validationConfig:     validationConfig,
 		logger:               logger,
 		traceProvider:        traceProvider,
@@ -53,6 +65,8 @@ type UseCases struct {
 	fileStorageService   interfaces.FileStorageService
 	ticketsService       interfaces.TicketsService
 	notificationsService interfaces.NotificationsService
+	compensationsService interfaces.CompensationsService
+	uploadsService       interfaces.UploadsService
 	validationConfig     config.ValidationConfig
 	logger               logging.Logger
 	traceProvider        tracing.Provider
@@ -137,7 +151,7 @@ func (useCases *UseCases) AddToy(
 		}
 	}
 
-	if len(rawToyData.Attachments) > attachmentsLimit {
+	if len(rawToyData.Attachments)+len(rawToyData.AttachmentUploadIDs) > attachmentsLimit {
 		return 0, &customerrors.LimitExceededError{
 			Message: fmt.Sprintf("Too many Attachments. Limit is %d", attachmentsLimit),
 		}
@@ -148,13 +162,34 @@ func (useCases *UseCases) AddToy(
 		return 0, err
 	}
 
-	uploadedFiles, err := useCases.UploadFiles(ctx, user.ID, rawToyData.Attachments)
+	addToySaga := newSaga("AddToy", useCases.compensationsService, useCases.logger)
+
+	uploadedFiles, createdFiles, err := useCases.uploadFiles(
+		ctx,
+		user.ID,
+		rawToyData.Attachments,
+		toyAttachmentsVisibility,
+	)
 	if err != nil {
 		return 0, err
 	}
 
+	// Overwritten files of User with the same names could be used by other Toys and Tickets:
+	addToySaga.addDeleteFilesCompensation("UploadFiles", createdFiles)
+
+	// Files of resumable uploads are not compensated for upload to be reused on retry:
+	uploadLinks, err := useCases.useUploads(ctx, user.ID, rawToyData.AttachmentUploadIDs, toyAttachmentsVisibility)
+	if err != nil {
+		addToySaga.compensate(ctx)
+
+		return 0, err
+	}
+
+	// Tags are shared between Toys and Tickets, so they are not compensated:
 	tagIDs, err := useCases.toysService.CreateTags(ctx, useCases.prepareTagsForCreation(rawToyData.Tags))
 	if err != nil {
+		addToySaga.compensate(ctx)
+
 		return 0, err
 	}
 
@@ -166,10 +201,19 @@ func (useCases *UseCases) AddToy(
 		Price:       rawToyData.Price,
 		Quantity:    rawToyData.Quantity,
 		TagIDs:      tagIDs,
-		Attachments: uploadedFiles,
+		Attachments: append(uploadedFiles, uploadLinks...),
 	}
 
-	return useCases.toysService.AddToy(ctx, toyData)
+	toyID, err := useCases.toysService.AddToy(ctx, toyData)
+	if err != nil {
+		addToySaga.compensate(ctx)
+
+		return 0, err
+	}
+
+	useCases.deleteUploads(ctx, rawToyData.AttachmentUploadIDs)
+
+	return toyID, nil
 }
 
 func (useCases *UseCases) GetToys(
@@ -367,34 +411,48 @@ func (useCases *UseCases) UploadFile(
 	ctx context.Context,
 	userID uint64,
 	file *graphql.Upload,
+	visibility entities.FileVisibility,
 ) (string, error) {
-	filename, err := useCases.createFilename(userID, file)
-	if err != nil {
-		return "", err
-	}
+	link, _, err := useCases.uploadFile(ctx, userID, useCases.storageLimit(ctx, userID), file, visibility)
 
-	binaryFile, err := io.ReadAll(file.File)
-	if err != nil {
-		return "", err
-	}
-
-	return useCases.fileStorageService.Upload(ctx, filename, binaryFile)
+	return link, err
 }
 
 func (useCases *UseCases) UploadFiles(
 	ctx context.Context,
 	userID uint64,
 	files []*graphql.Upload,
+	visibility entities.FileVisibility,
 ) ([]string, error) {
+	uploadedFiles, _, err := useCases.uploadFiles(ctx, userID, files, visibility)
+
+	return uploadedFiles, err
+}
+
+// uploadFiles additionally returns links of created files, which did not exist before upload. Only such files
+// could be deleted on failure, because overwritten files of User with the same names could be used by other entities.
+func (useCases *UseCases) uploadFiles(
+	ctx context.Context,
+	userID uint64,
+	files []*graphql.Upload,
+	visibility entities.FileVisibility,
+) ([]string, []string, error) {
+	limit := useCases.storageLimit(ctx, userID)
 	uploadedFiles := make([]string, 0, len(files))
+	createdFiles := make([]string, 0, len(files))
 	uploadingErrors := make([]error, 0, len(files))
 
 	for _, file := range files {
-		filename, err := useCases.UploadFile(ctx, userID, file)
+		filename, overwritten, err := useCases.uploadFile(ctx, userID, limit, file, visibility)
 		if err != nil {
 			uploadingErrors = append(uploadingErrors, err)
-		} else {
-			uploadedFiles = append(uploadedFiles, filename)
+
+			continue
+		}
+
+		uploadedFiles = append(uploadedFiles, filename)
+		if !overwritten {
+			createdFiles = append(createdFiles, filename)
 		}
 	}
 
@@ -416,12 +474,158 @@ func (useCases *UseCases) UploadFiles(
 		)
 	}
 
+	// Partially uploaded files are not allowed, if quota was exceeded, for User to know,
+	// that some files were not saved:
+	for _, err := range uploadingErrors {
+		var quotaErr *customerrors.StorageQuotaExceededError
+		if errors.As(err, &quotaErr) {
+			if len(createdFiles) > 0 {
+				// Error is logged by FileStorageService. Not deleted files will be removed by garbage collector:
+				_ = useCases.fileStorageService.DeleteMany(ctx, filenamesFromLinks(createdFiles))
+			}
+
+			return nil, nil, err
+		}
+	}
+
 	if len(uploadedFiles) == 0 && len(uploadingErrors) > 0 {
-		return nil, &customerrors.UploadFileError{Message: concatenatedErrBuilder.String()}
+		return nil, nil, &customerrors.UploadFileError{Message: concatenatedErrBuilder.String()}
 	}
 
 	// Return no err and any amount of uploaded files, if exists:
-	return uploadedFiles, nil
+	return uploadedFiles, createdFiles, nil
+}
+
+func (useCases *UseCases) GetUserStorageUsage(
+	ctx context.Context,
+	accessToken string,
+	userID uint64,
+) (*entities.StorageUsage, error) {
+	user, err := useCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage usage is private information:
+	if user.ID != userID {
+		return nil, &customerrors.PermissionDeniedError{
+			Message: fmt.Sprintf(
+				"User with ID=%d is not allowed to see storage usage of User with ID=%d",
+				user.ID,
+				userID,
+			),
+		}
+	}
+
+	used, err := useCases.fileStorageService.GetUsedBytes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.StorageUsage{
+		UsedBytes:  used,
+		LimitBytes: useCases.storageLimit(ctx, user.ID),
+	}, nil
+}
+
+func (useCases *UseCases) CreateUpload(
+	ctx context.Context,
+	accessToken string,
+	filename string,
+	length int64,
+) (*entities.ResumableUpload, error) {
+	user, err := useCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extension and size are validated before receiving any data:
+	if err = useCases.validateFile(&graphql.Upload{Filename: filename, Size: length}); err != nil {
+		return nil, err
+	}
+
+	upload := entities.ResumableUpload{
+		UserID:   user.ID,
+		Filename: filename,
+		Length:   length,
+	}
+
+	if upload.ID, err = useCases.uploadsService.CreateUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+func (useCases *UseCases) GetUpload(ctx context.Context, accessToken, id string) (*entities.ResumableUpload, error) {
+	user, err := useCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return useCases.getUserUpload(ctx, user.ID, id)
+}
+
+func (useCases *UseCases) WriteUploadChunk(
+	ctx context.Context,
+	accessToken string,
+	id string,
+	offset int64,
+	chunk []byte,
+) (*entities.ResumableUpload, error) {
+	user, err := useCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := useCases.getUserUpload(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != upload.Offset {
+		return nil, &customerrors.UploadOffsetMismatchError{
+			Message: fmt.Sprintf("expected offset=%d, received offset=%d", upload.Offset, offset),
+		}
+	}
+
+	if offset+int64(len(chunk)) > upload.Length {
+		return nil, &customerrors.InvalidFileSizeError{Message: strconv.FormatInt(offset+int64(len(chunk)), 10)}
+	}
+
+	if upload.Offset, err = useCases.uploadsService.AppendChunk(ctx, id, offset, chunk); err != nil {
+		return nil, err
+	}
+
+	if upload.Offset < upload.Length {
+		return upload, nil
+	}
+
+	data, err := useCases.uploadsService.GetUploadData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// File is private until upload is used by mutation, which defines required visibility:
+	link, _, err := useCases.fileStorageService.UploadUserFile(
+		ctx,
+		user.ID,
+		useCases.storageLimit(ctx, user.ID),
+		uploadFilename(upload),
+		data,
+		entities.FileVisibilityPrivate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCases.uploadsService.CompleteUpload(ctx, id, link); err != nil {
+		return nil, err
+	}
+
+	upload.Link = link
+
+	return upload, nil
 }
 
 func (useCases *UseCases) CreateTicket(
@@ -434,7 +638,7 @@ func (useCases *UseCases) CreateTicket(
 		}
 	}
 
-	if len(rawTicketData.Attachments) > attachmentsLimit {
+	if len(rawTicketData.Attachments)+len(rawTicketData.AttachmentUploadIDs) > attachmentsLimit {
 		return 0, &customerrors.LimitExceededError{
 			Message: fmt.Sprintf("Too many Attachments. Limit is %d", attachmentsLimit),
 		}
@@ -445,13 +649,39 @@ func (useCases *UseCases) CreateTicket(
 		return 0, err
 	}
 
-	uploadedFiles, err := useCases.UploadFiles(ctx, user.ID, rawTicketData.Attachments)
+	createTicketSaga := newSaga("CreateTicket", useCases.compensationsService, useCases.logger)
+
+	uploadedFiles, createdFiles, err := useCases.uploadFiles(
+		ctx,
+		user.ID,
+		rawTicketData.Attachments,
+		ticketAttachmentsVisibility,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Overwritten files of User with the same names could be used by other Toys and Tickets:
+	createTicketSaga.addDeleteFilesCompensation("UploadFiles", createdFiles)
+
+	// Files of resumable uploads are not compensated for upload to be reused on retry:
+	uploadLinks, err := useCases.useUploads(
+		ctx,
+		user.ID,
+		rawTicketData.AttachmentUploadIDs,
+		ticketAttachmentsVisibility,
+	)
 	if err != nil {
+		createTicketSaga.compensate(ctx)
+
 		return 0, err
 	}
 
+	// Tags are shared between Toys and Tickets, so they are not compensated:
 	tagIDs, err := useCases.toysService.CreateTags(ctx, useCases.prepareTagsForCreation(rawTicketData.Tags))
 	if err != nil {
+		createTicketSaga.compensate(ctx)
+
 		return 0, err
 	}
 
@@ -463,10 +693,19 @@ func (useCases *UseCases) CreateTicket(
 		Price:       rawTicketData.Price,
 		Quantity:    rawTicketData.Quantity,
 		TagIDs:      tagIDs,
-		Attachments: uploadedFiles,
+		Attachments: append(uploadedFiles, uploadLinks...),
 	}
 
-	return useCases.ticketsService.CreateTicket(ctx, ticketData)
+	ticketID, err := useCases.ticketsService.CreateTicket(ctx, ticketData)
+	if err != nil {
+		createTicketSaga.compensate(ctx)
+
+		return 0, err
+	}
+
+	useCases.deleteUploads(ctx, rawTicketData.AttachmentUploadIDs)
+
+	return ticketID, nil
 }
 
 func (useCases *UseCases) GetTicketByID(ctx context.Context, id uint64) (*entities.Ticket, error) {
@@ -481,6 +720,73 @@ func (useCases *UseCases) GetTicketByID(ctx context.Context, id uint64) (*entiti
 	return useCases.processRawTicket(*rawTicket, tags), nil
 }
 
+// GetTicketAttachments returns Ticket attachments with presigned links, which are available
+// only for Ticket owner and Masters. Any Master could respond to any Ticket, so that attachments of
+// every Ticket are intentionally available to every Master. Other Users receive no attachments.
+func (useCases *UseCases) GetTicketAttachments(
+	ctx context.Context,
+	ticket entities.Ticket,
+	accessToken string,
+) ([]entities.TicketAttachment, error) {
+	if len(ticket.Attachments) == 0 || accessToken == "" {
+		return nil, nil
+	}
+
+	// Viewer is resolved once per request, because attachments are resolved for each Ticket of list:
+	user, err := requestcache.Get(ctx, "me:"+accessToken, func() (*entities.User, error) {
+		return useCases.GetMe(ctx, accessToken)
+	})
+
+	var unauthenticatedErr *customerrors.UnauthenticatedError
+	if errors.As(err, &unauthenticatedErr) {
+		// User with expired access token is anonymous:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.UserID != user.ID {
+		isMaster, err := requestcache.Get(ctx, fmt.Sprintf("isMaster:%d", user.ID), func() (bool, error) {
+			return useCases.isMaster(ctx, user.ID)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// User without Master profile is not allowed to see attachments of another User:
+		if !isMaster {
+			return nil, nil
+		}
+	}
+
+	attachments := make([]entities.TicketAttachment, len(ticket.Attachments))
+	for i, attachment := range ticket.Attachments {
+		link, err := useCases.fileStorageService.GetPresignedURL(ctx, entities.FileKeyFromLink(attachment.Link))
+		if err != nil {
+			return nil, err
+		}
+
+		attachment.Link = link
+		attachments[i] = attachment
+	}
+
+	return attachments, nil
+}
+
+// isMaster checks, whether User has Master profile. Only NotFound error means, that User is not a Master.
+func (useCases *UseCases) isMaster(ctx context.Context, userID uint64) (bool, error) {
+	_, err := useCases.GetMasterByUserID(ctx, userID)
+
+	var notFoundErr *customerrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 func (useCases *UseCases) GetTickets(
 	ctx context.Context,
 	pagination *entities.Pagination,
@@ -730,39 +1036,64 @@ func (useCases *UseCases) UpdateUserProfile(
 		return err
 	}
 
+	updateUserProfileSaga := newSaga("UpdateUserProfile", useCases.compensationsService, useCases.logger)
+
 	// Check old avatar existence and necessary to upload or delete files:
-	var avatar *string
+	var (
+		avatar            *string
+		oldAvatarFilename string
+		avatarReplaced    bool
+	)
 
-	if rawUserProfileData.Avatar != nil {
-		var newAvatarFilename string
+	if user.Avatar != nil && *user.Avatar != "" {
+		oldAvatarFilename = entities.FileKeyFromLink(*user.Avatar)
+	}
 
-		newAvatarFilename, err = useCases.createFilename(user.ID, rawUserProfileData.Avatar)
+	switch {
+	case rawUserProfileData.AvatarUploadID != nil:
+		var links []string
+
+		links, err = useCases.useUploads(ctx, user.ID, []string{*rawUserProfileData.AvatarUploadID}, avatarsVisibility)
 		if err != nil {
 			return err
 		}
 
-		var oldAvatarFilename string
-
-		if user.Avatar != nil && *user.Avatar != "" {
-			split := strings.Split(*user.Avatar, "/")
-			oldAvatarFilename = split[len(split)-1]
+		// Upload of file with the same name overwrites old avatar, which should not be deleted:
+		avatar = &links[0]
+		avatarReplaced = entities.FileKeyFromLink(links[0]) != oldAvatarFilename
+	case rawUserProfileData.Avatar != nil:
+		var newAvatarFilename string
 
-			if newAvatarFilename != oldAvatarFilename {
-				if err = useCases.fileStorageService.Delete(ctx, oldAvatarFilename); err != nil {
-					return err
-				}
-			}
+		newAvatarFilename, err = useCases.createFilename(user.ID, rawUserProfileData.Avatar)
+		if err != nil {
+			return err
 		}
 
 		if newAvatarFilename != oldAvatarFilename {
-			var avatarURL string
+			var (
+				avatarURL   string
+				overwritten bool
+			)
 
-			if avatarURL, err = useCases.UploadFile(ctx, user.ID, rawUserProfileData.Avatar); err != nil {
+			avatarURL, overwritten, err = useCases.uploadFile(
+				ctx,
+				user.ID,
+				useCases.storageLimit(ctx, user.ID),
+				rawUserProfileData.Avatar,
+				avatarsVisibility,
+			)
+			if err != nil {
 				return err
 			}
 
 			if avatarURL != "" {
 				avatar = &avatarURL
+				avatarReplaced = true
+
+				// Overwritten file of User with the same name could be used by Toys and Tickets:
+				if !overwritten {
+					updateUserProfileSaga.addDeleteFilesCompensation("UploadFile", []string{avatarURL})
+				}
 			}
 		}
 	}
@@ -775,7 +1106,22 @@ func (useCases *UseCases) UpdateUserProfile(
 		Avatar:      avatar,
 	}
 
-	return useCases.ssoService.UpdateUserProfile(ctx, userProfileData)
+	if err = useCases.ssoService.UpdateUserProfile(ctx, userProfileData); err != nil {
+		updateUserProfileSaga.compensate(ctx)
+
+		return err
+	}
+
+	// Old avatar is deleted only after SSO accepted new one:
+	if avatarReplaced && oldAvatarFilename != "" {
+		updateUserProfileSaga.deleteFiles(ctx, "DeleteOldAvatar", []string{oldAvatarFilename})
+	}
+
+	if rawUserProfileData.AvatarUploadID != nil {
+		useCases.deleteUploads(ctx, []string{*rawUserProfileData.AvatarUploadID})
+	}
+
+	return nil
 }
 
 func (useCases *UseCases) UpdateToy(
@@ -884,33 +1230,24 @@ func (useCases *UseCases) UpdateToy(
 		}
 	}
 
-	if len(attachmentsToDelete) > 0 {
-		deleteAttachmentErrors := useCases.fileStorageService.DeleteMany(ctx, attachmentsToDelete)
-		if len(deleteAttachmentErrors) > 0 {
-			concatenatedErrBuilder := strings.Builder{}
-			concatenatedErrBuilder.WriteString("Failed to delete files:\n")
-
-			for i, err := range deleteAttachmentErrors {
-				// i + 1 due to index starts from zero
-				concatenatedErrBuilder.WriteString(fmt.Sprintf("%d) %v\n", i+1, err))
-			}
-
-			// Logging errors for further investigate:
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				concatenatedErrBuilder.String(),
-				&customerrors.DeleteFileError{Message: concatenatedErrBuilder.String()},
-			)
-		}
-	}
+	updateToySaga := newSaga("UpdateToy", useCases.compensationsService, useCases.logger)
 
 	var uploadedFiles []string
 	if len(attachmentsToAdd) > 0 {
-		uploadedFiles, err = useCases.UploadFiles(ctx, user.ID, attachmentsToAdd)
+		var createdFiles []string
+
+		uploadedFiles, createdFiles, err = useCases.uploadFiles(
+			ctx,
+			user.ID,
+			attachmentsToAdd,
+			toyAttachmentsVisibility,
+		)
 		if err != nil {
 			return err
 		}
+
+		// Overwritten files of User with the same names could be used by other Toys and Tickets:
+		updateToySaga.addDeleteFilesCompensation("UploadFiles", createdFiles)
 	}
 
 	updatedAttachments := append(stillUsedAttachments, uploadedFiles...)
@@ -925,7 +1262,16 @@ func (useCases *UseCases) UpdateToy(
 		Attachments: updatedAttachments,
 	}
 
-	return useCases.toysService.UpdateToy(ctx, toyData)
+	if err = useCases.toysService.UpdateToy(ctx, toyData); err != nil {
+		updateToySaga.compensate(ctx)
+
+		return err
+	}
+
+	// Old Attachments are deleted only after Toy stopped using them:
+	updateToySaga.deleteFiles(ctx, "DeleteOldAttachments", attachmentsToDelete)
+
+	return nil
 }
 
 func (useCases *UseCases) DeleteToy(ctx context.Context, accessToken string, id uint64) error {
@@ -1213,7 +1559,7 @@ func (useCases *UseCases) UpdateTicket(
 
 	var uploadedFiles []string
 	if len(attachmentsToAdd) > 0 {
-		uploadedFiles, err = useCases.UploadFiles(ctx, user.ID, attachmentsToAdd)
+		uploadedFiles, err = useCases.UploadFiles(ctx, user.ID, attachmentsToAdd, ticketAttachmentsVisibility)
 		if err != nil {
 			return err
 		}
@@ -1288,21 +1634,121 @@ func (useCases *UseCases) DeleteTicket(ctx context.Context, accessToken string,
 	return useCases.ticketsService.DeleteTicket(ctx, ticket.ID)
 }
 
+func (useCases *UseCases) uploadFile(
+	ctx context.Context,
+	userID uint64,
+	limit int64,
+	file *graphql.Upload,
+	visibility entities.FileVisibility,
+) (string, bool, error) {
+	filename, err := useCases.createFilename(userID, file)
+	if err != nil {
+		return "", false, err
+	}
+
+	binaryFile, err := io.ReadAll(file.File)
+	if err != nil {
+		return "", false, err
+	}
+
+	return useCases.fileStorageService.UploadUserFile(ctx, userID, limit, filename, binaryFile, visibility)
+}
+
+// getUserUpload returns upload only if it belongs to User. Foreign uploads are treated as not existing.
+func (useCases *UseCases) getUserUpload(ctx context.Context, userID uint64, id string) (*entities.ResumableUpload, error) {
+	upload, err := useCases.uploadsService.GetUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.UserID != userID {
+		return nil, &customerrors.UploadNotFoundError{Message: id}
+	}
+
+	return upload, nil
+}
+
+// useUploads returns links to files of completed resumable uploads and sets required visibility of files.
+func (useCases *UseCases) useUploads(
+	ctx context.Context,
+	userID uint64,
+	ids []string,
+	visibility entities.FileVisibility,
+) ([]string, error) {
+	links := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		upload, err := useCases.getUserUpload(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if upload.Link == "" {
+			return nil, &customerrors.UploadNotCompletedError{Message: id}
+		}
+
+		// Completed uploads are stored as private files:
+		if visibility != entities.FileVisibilityPrivate {
+			if err = useCases.fileStorageService.SetVisibility(ctx, uploadFilename(upload), visibility); err != nil {
+				return nil, err
+			}
+		}
+
+		links = append(links, upload.Link)
+	}
+
+	return links, nil
+}
+
+// deleteUploads removes state of uploads, which files were used by mutation. Not deleted uploads expire by TTL.
+func (useCases *UseCases) deleteUploads(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		// Error is logged by UploadsService:
+		_ = useCases.uploadsService.DeleteUpload(ctx, id)
+	}
+}
+
+// storageLimit returns storage quota of User, which is bigger for Masters.
+func (useCases *UseCases) storageLimit(ctx context.Context, userID uint64) int64 {
+	// User without Master profile receives default quota:
+	if _, err := useCases.GetMasterByUserID(ctx, userID); err != nil {
+		return useCases.validationConfig.UserStorageLimit
+	}
+
+	return useCases.validationConfig.MasterStorageLimit
+}
+
 func (useCases *UseCases) createFilename(userID uint64, file *graphql.Upload) (string, error) {
+	if err := useCases.validateFile(file); err != nil {
+		return "", err
+	}
+
+	filename := security.RawEncode(
+		[]byte(fmt.Sprintf("%d:%s", userID, file.Filename)),
+	) + path.Ext(file.Filename)
+
+	return filename, nil
+}
+
+func (useCases *UseCases) validateFile(file *graphql.Upload) error {
 	fileExtension := path.Ext(file.Filename)
 	if !validateFileExtension(fileExtension, useCases.validationConfig.FileAllowedExtensions) {
-		return "", &customerrors.InvalidFileExtensionError{Message: fileExtension}
+		return &customerrors.InvalidFileExtensionError{Message: fileExtension}
 	}
 
 	if !validateFileSize(file.Size, useCases.validationConfig.FileMaxSize) {
-		return "", &customerrors.InvalidFileSizeError{Message: strconv.FormatInt(file.Size, 10)}
+		return &customerrors.InvalidFileSizeError{Message: strconv.FormatInt(file.Size, 10)}
 	}
 
-	filename := security.RawEncode(
-		[]byte(fmt.Sprintf("%d:%s", userID, file.Filename)),
-	) + fileExtension
+	return nil
+}
 
-	return filename, nil
+// uploadFilename returns key of resumable upload file, which is unique for each upload, so that completed upload
+// does not overwrite other file of User with the same name, while it is not used by any mutation.
+func uploadFilename(upload *entities.ResumableUpload) string {
+	return security.RawEncode(
+		[]byte(fmt.Sprintf("%d:%s:%s", upload.UserID, upload.ID, upload.Filename)),
+	) + path.Ext(upload.Filename)
 }
 
 func (useCases *UseCases) processRawTicket(
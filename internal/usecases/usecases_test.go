@@ -21,6 +21,7 @@ import (
 
 	"github.com/DKhorkov/hmtm-bff/internal/config"
 	"github.com/DKhorkov/hmtm-bff/internal/entities"
+	"github.com/DKhorkov/hmtm-bff/internal/requestcache"
 	mockservices "github.com/DKhorkov/hmtm-bff/mocks/services"
 )
 
@@ -38,7 +39,12 @@ var (
 			".pjpeg",
 			".pjp",
 		},
+		UserStorageLimit:   int64(10 * 1024 * 1024),  // 10 Mb
+		MasterStorageLimit: int64(100 * 1024 * 1024), // 100 Mb
 	}
+
+	// Key of file of resumable upload with ID "id" of User with ID 1:
+	uploadKey = security.RawEncode([]byte("1:id:photo.jpg")) + ".jpg"
 )
 
 func TestUseCases_RegisterUser(t *testing.T) {
@@ -126,6 +132,8 @@ func TestUseCases_RegisterUser(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -135,6 +143,8 @@ func TestUseCases_RegisterUser(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -255,6 +265,8 @@ func TestUseCases_LoginUser(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -264,6 +276,8 @@ func TestUseCases_LoginUser(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -363,6 +377,8 @@ func TestUseCases_LogoutUser(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -372,6 +388,8 @@ func TestUseCases_LogoutUser(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -469,6 +487,8 @@ func TestUseCases_VerifyUserEmail(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -478,6 +498,8 @@ func TestUseCases_VerifyUserEmail(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -575,6 +597,8 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -584,6 +608,8 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -681,6 +707,8 @@ func TestUseCases_SendForgetPasswordMessage(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -690,6 +718,8 @@ func TestUseCases_SendForgetPasswordMessage(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -824,6 +854,8 @@ func TestUseCases_ChangePassword(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -833,6 +865,8 @@ func TestUseCases_ChangePassword(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -935,6 +969,8 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -944,6 +980,8 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1053,6 +1091,8 @@ func TestUseCases_GetMe(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1062,6 +1102,8 @@ func TestUseCases_GetMe(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1171,6 +1213,8 @@ func TestUseCases_RefreshTokens(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1180,6 +1224,8 @@ func TestUseCases_RefreshTokens(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1226,6 +1272,7 @@ func TestUseCases_GetUserByID(t *testing.T) {
 		)
 		expected      *entities.User
 		errorExpected bool
+		expectedError error
 	}{
 		{
 			name: "success",
@@ -1278,11 +1325,12 @@ func TestUseCases_GetUserByID(t *testing.T) {
 						gomock.Any(),
 						uint64(999),
 					).
-					Return(nil, errors.New("user not found")).
+					Return(nil, &customerrors.NotFoundError{Message: "user not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
+			expectedError: &customerrors.NotFoundError{},
 		},
 	}
 
@@ -1291,6 +1339,8 @@ func TestUseCases_GetUserByID(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1300,6 +1350,8 @@ func TestUseCases_GetUserByID(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1322,6 +1374,10 @@ func TestUseCases_GetUserByID(t *testing.T) {
 			actual, err := useCases.GetUserByID(ctx, tc.id)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -1411,6 +1467,8 @@ func TestUseCases_GetUserByEmail(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1420,6 +1478,8 @@ func TestUseCases_GetUserByEmail(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1588,6 +1648,8 @@ func TestUseCases_GetUsers(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1597,6 +1659,8 @@ func TestUseCases_GetUsers(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1638,6 +1702,7 @@ func TestUseCases_AddToy(t *testing.T) {
 			fileStorageService *mockservices.MockFileStorageService,
 			ticketsService *mockservices.MockTicketsService,
 			notificationsService *mockservices.MockNotificationsService,
+			compensationsService *mockservices.MockCompensationsService,
 			logger *mocklogger.MockLogger,
 			traceProvider *tracingmock.MockProvider,
 		)
@@ -1664,6 +1729,7 @@ func TestUseCases_AddToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -1676,10 +1742,16 @@ func TestUseCases_AddToy(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file1.jpg", false, nil).
 					Times(1)
 
 				toysService.
@@ -1725,6 +1797,7 @@ func TestUseCases_AddToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -1753,6 +1826,7 @@ func TestUseCases_AddToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -1764,10 +1838,16 @@ func TestUseCases_AddToy(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("test")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("test")).
 					Times(1)
 
 				logger.
@@ -1798,6 +1878,7 @@ func TestUseCases_AddToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -1809,10 +1890,16 @@ func TestUseCases_AddToy(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file1.jpg", false, nil).
 					Times(1)
 
 				toysService.
@@ -1824,6 +1911,193 @@ func TestUseCases_AddToy(t *testing.T) {
 					Return(nil, errors.New("test")).
 					Times(1)
 
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "AddToy",
+							Step:     "UploadFiles",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"file1.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+			expected:      0,
+			errorExpected: true,
+		},
+		{
+			name: "add toy error with failed compensation",
+			rawToyData: entities.RawAddToyDTO{
+				AccessToken: "valid_access_token",
+				CategoryID:  1,
+				Name:        "Test Toy",
+				Description: "Test Description",
+				Price:       100,
+				Quantity:    10,
+				Tags:        []string{"tag1"},
+				Attachments: []*graphql.Upload{
+					{File: strings.NewReader("test content"), Filename: "file1.jpg"},
+				},
+			},
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
+				logger *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				user := &entities.User{ID: 1}
+
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_access_token").
+					Return(user, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file1.jpg", false, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					CreateTags(gomock.Any(), []entities.CreateTagDTO{{Name: "tag1"}}).
+					Return([]uint32{1}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					AddToy(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("delete failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(
+						gomock.Any(),
+						gomock.Cond(func(compensation entities.Compensation) bool {
+							return compensation.Saga == "AddToy" &&
+								compensation.Attempts == 1 &&
+								compensation.Error == "delete failed" &&
+								!compensation.CreatedAt.IsZero()
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			expected:      0,
+			errorExpected: true,
+		},
+		{
+			name: "add toy error with filename of existing toy",
+			rawToyData: entities.RawAddToyDTO{
+				AccessToken: "valid_access_token",
+				CategoryID:  1,
+				Name:        "Second Toy",
+				Attachments: []*graphql.Upload{
+					{File: strings.NewReader("test content"), Filename: "file1.jpg"},
+					{File: strings.NewReader("test content"), Filename: "file2.jpg"},
+				},
+			},
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_access_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				// File with the same name was already uploaded for first Toy:
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						gomock.Any(),
+						security.RawEncode([]byte("1:file1.jpg"))+".jpg",
+						gomock.Any(),
+						entities.FileVisibilityPublic,
+					).
+					Return("uploaded/file1.jpg", true, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						gomock.Any(),
+						security.RawEncode([]byte("1:file2.jpg"))+".jpg",
+						gomock.Any(),
+						entities.FileVisibilityPublic,
+					).
+					Return("uploaded/file2.jpg", false, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					CreateTags(gomock.Any(), []entities.CreateTagDTO{}).
+					Return([]uint32{}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					AddToy(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+
+				// File of first Toy is not deleted:
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "AddToy",
+							Step:     "UploadFiles",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"file2.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
 			},
 			expected:      0,
 			errorExpected: true,
@@ -1870,6 +2144,8 @@ func TestUseCases_AddToy(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -1879,6 +2155,8 @@ func TestUseCases_AddToy(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -1893,6 +2171,7 @@ func TestUseCases_AddToy(t *testing.T) {
 					fileStorageService,
 					ticketsService,
 					notificationsService,
+					compensationsService,
 					logger,
 					traceProvider,
 				)
@@ -1936,23 +2215,29 @@ func TestUseCases_UploadFiles(t *testing.T) {
 			},
 			setupMocks: func(
 				_ *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file1.jpg", false, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/file2.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file2.jpg", false, nil).
 					Times(1)
 			},
 			expected:      []string{"uploaded/file1.jpg", "uploaded/file2.jpg"},
@@ -1967,23 +2252,29 @@ func TestUseCases_UploadFiles(t *testing.T) {
 			},
 			setupMocks: func(
 				_ *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/file1.jpg", false, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload failed")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("upload failed")).
 					Times(1)
 
 				logger.
@@ -1994,6 +2285,104 @@ func TestUseCases_UploadFiles(t *testing.T) {
 			expected:      []string{"uploaded/file1.jpg"},
 			errorExpected: false,
 		},
+		{
+			name:   "user storage limit",
+			userID: 1,
+			files: []*graphql.Upload{
+				{File: strings.NewReader("test content"), Filename: "file1.jpg"},
+			},
+			setupMocks: func(
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("master not found")).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						validationConfig.UserStorageLimit,
+						gomock.Any(),
+						[]byte("test content"),
+						entities.FileVisibilityPublic,
+					).
+					Return("uploaded/file1.jpg", false, nil).
+					Times(1)
+			},
+			expected: []string{"uploaded/file1.jpg"},
+		},
+		{
+			name:   "storage quota exceeded",
+			userID: 1,
+			files: []*graphql.Upload{
+				{File: strings.NewReader("test content"), Filename: "file1.jpg"},
+				{File: strings.NewReader("test content"), Filename: "file2.jpg"},
+			},
+			setupMocks: func(
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				logger *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						validationConfig.MasterStorageLimit,
+						gomock.Any(),
+						gomock.Any(),
+						entities.FileVisibilityPublic,
+					).
+					Return("https://storage/uploaded-file1.jpg", false, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						validationConfig.MasterStorageLimit,
+						gomock.Any(),
+						gomock.Any(),
+						entities.FileVisibilityPublic,
+					).
+					Return("", false, &customerrors.StorageQuotaExceededError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					DeleteMany(gomock.Any(), []string{"uploaded-file1.jpg"}).
+					Return(nil).
+					Times(1)
+			},
+			expected:      nil,
+			errorExpected: true,
+		},
 		{
 			name:   "all files failed",
 			userID: 1,
@@ -2003,17 +2392,23 @@ func TestUseCases_UploadFiles(t *testing.T) {
 			},
 			setupMocks: func(
 				_ *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload failed")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("upload failed")).
 					AnyTimes()
 
 				logger.
@@ -2031,6 +2426,8 @@ func TestUseCases_UploadFiles(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -2040,6 +2437,8 @@ func TestUseCases_UploadFiles(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -2059,7 +2458,7 @@ func TestUseCases_UploadFiles(t *testing.T) {
 				)
 			}
 
-			actual, err := useCases.UploadFiles(ctx, tc.userID, tc.files)
+			actual, err := useCases.UploadFiles(ctx, tc.userID, tc.files, entities.FileVisibilityPublic)
 			if tc.errorExpected {
 				require.Error(t, err)
 			} else {
@@ -2097,17 +2496,23 @@ func TestUseCases_UploadFile(t *testing.T) {
 			},
 			setupMocks: func(
 				_ *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/test.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/test.jpg", false, nil).
 					Times(1)
 			},
 			expected:      "uploaded/test.jpg",
@@ -2120,97 +2525,971 @@ func TestUseCases_UploadFile(t *testing.T) {
 				File:     strings.NewReader("test content"),
 				Filename: "invalid/file/name",
 			},
-			expected:      "",
+			setupMocks: func(
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("master not found")).
+					Times(1)
+			},
+			expected:      "",
+			errorExpected: true,
+		},
+		{
+			name:   "upload error",
+			userID: 1,
+			file: &graphql.Upload{
+				File:     strings.NewReader("test content"),
+				Filename: "test.jpg",
+			},
+			setupMocks: func(
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("upload failed")).
+					Times(1)
+			},
+			expected:      "",
+			errorExpected: true,
+		},
+		{
+			name:   "read file error",
+			userID: 1,
+			file: &graphql.Upload{
+				File:     &errorReader{err: errors.New("read error")},
+				Filename: "test.jpg",
+				Size:     1024,
+			},
+			setupMocks: func(
+				_ *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+			},
+			expected:      "",
+			errorExpected: true,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	ssoService := mockservices.NewMockSsoService(ctrl)
+	toysService := mockservices.NewMockToysService(ctrl)
+	ticketsService := mockservices.NewMockTicketsService(ctrl)
+	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
+	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+	logger := mocklogger.NewMockLogger(ctrl)
+	traceProvider := tracingmock.NewMockProvider(ctrl)
+	useCases := New(
+		ssoService,
+		toysService,
+		fileStorageService,
+		ticketsService,
+		notificationsService,
+		compensationsService,
+		uploadsService,
+		validationConfig,
+		logger,
+		traceProvider,
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					ssoService,
+					toysService,
+					fileStorageService,
+					ticketsService,
+					notificationsService,
+					logger,
+					traceProvider,
+				)
+			}
+
+			actual, err := useCases.UploadFile(ctx, tc.userID, tc.file, entities.FileVisibilityPublic)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+// errorReader - вспомогательная структура для имитации ошибки чтения
+func TestUseCases_CreateUpload(t *testing.T) {
+	testCases := []struct {
+		name       string
+		filename   string
+		length     int64
+		setupMocks func(
+			ssoService *mockservices.MockSsoService,
+			uploadsService *mockservices.MockUploadsService,
+		)
+		expected      *entities.ResumableUpload
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name:     "success",
+			filename: "photo.jpg",
+			length:   1024,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					CreateUpload(
+						gomock.Any(),
+						entities.ResumableUpload{
+							UserID:   1,
+							Filename: "photo.jpg",
+							Length:   1024,
+						},
+					).
+					Return("id", nil).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{
+				ID:       "id",
+				UserID:   1,
+				Filename: "photo.jpg",
+				Length:   1024,
+			},
+		},
+		{
+			name:     "invalid extension",
+			filename: "script.exe",
+			length:   1024,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.InvalidFileExtensionError{},
+		},
+		{
+			name:     "too large file",
+			filename: "photo.jpg",
+			length:   validationConfig.FileMaxSize + 1,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.InvalidFileSizeError{},
+		},
+		{
+			name:     "get me error",
+			filename: "photo.jpg",
+			length:   1024,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:     "create upload error",
+			filename: "photo.jpg",
+			length:   1024,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					CreateUpload(gomock.Any(), gomock.Any()).
+					Return("", errors.New("redis error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			uploadsService := mockservices.NewMockUploadsService(ctrl)
+			useCases := &UseCases{
+				ssoService:       ssoService,
+				uploadsService:   uploadsService,
+				validationConfig: validationConfig,
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(ssoService, uploadsService)
+			}
+
+			upload, err := useCases.CreateUpload(ctx, "valid_token", tc.filename, tc.length)
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, upload)
+		})
+	}
+}
+
+func TestUseCases_GetUpload(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			ssoService *mockservices.MockSsoService,
+			uploadsService *mockservices.MockUploadsService,
+		)
+		expected      *entities.ResumableUpload
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 1, Length: 10, Offset: 5}, nil).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{ID: "id", UserID: 1, Length: 10, Offset: 5},
+		},
+		{
+			name: "upload of another user",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 2}, nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotFoundError{},
+		},
+		{
+			name: "get me error",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "get upload error",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(nil, &customerrors.UploadNotFoundError{Message: "id"}).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotFoundError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			uploadsService := mockservices.NewMockUploadsService(ctrl)
+			useCases := &UseCases{
+				ssoService:       ssoService,
+				uploadsService:   uploadsService,
+				validationConfig: validationConfig,
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(ssoService, uploadsService)
+			}
+
+			upload, err := useCases.GetUpload(ctx, "valid_token", "id")
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, upload)
+		})
+	}
+}
+
+func TestUseCases_WriteUploadChunk(t *testing.T) {
+	inProgress := func() *entities.ResumableUpload {
+		return &entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg", Length: 10, Offset: 5}
+	}
+
+	testCases := []struct {
+		name       string
+		offset     int64
+		chunk      []byte
+		setupMocks func(
+			ssoService *mockservices.MockSsoService,
+			toysService *mockservices.MockToysService,
+			fileStorageService *mockservices.MockFileStorageService,
+			uploadsService *mockservices.MockUploadsService,
+		)
+		expected      *entities.ResumableUpload
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name:   "intermediate chunk",
+			offset: 5,
+			chunk:  []byte("ab"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(5), []byte("ab")).
+					Return(int64(7), nil).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg", Length: 10, Offset: 7},
+		},
+		{
+			name:   "last chunk",
+			offset: 5,
+			chunk:  []byte("abcde"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(5), []byte("abcde")).
+					Return(int64(10), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUploadData(gomock.Any(), "id").
+					Return([]byte("0123456789"), nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("not found")).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						validationConfig.UserStorageLimit,
+						uploadKey,
+						[]byte("0123456789"),
+						entities.FileVisibilityPrivate,
+					).
+					Return("https://link/key.jpg", false, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					CompleteUpload(gomock.Any(), "id", "https://link/key.jpg").
+					Return(nil).
+					Times(1)
+			},
+			expected: &entities.ResumableUpload{
+				ID:       "id",
+				UserID:   1,
+				Filename: "photo.jpg",
+				Length:   10,
+				Offset:   10,
+				Link:     "https://link/key.jpg",
+			},
+		},
+		{
+			name:   "offset mismatch",
+			offset: 3,
+			chunk:  []byte("ab"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadOffsetMismatchError{},
+		},
+		{
+			name:   "chunk exceeds upload length",
+			offset: 5,
+			chunk:  []byte("abcdef"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.InvalidFileSizeError{},
+		},
+		{
+			name:   "upload of another user",
+			offset: 5,
+			chunk:  []byte("ab"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 2}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotFoundError{},
+		},
+		{
+			name:   "append chunk error",
+			offset: 5,
+			chunk:  []byte("ab"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(5), []byte("ab")).
+					Return(int64(0), &customerrors.UploadOffsetMismatchError{}).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadOffsetMismatchError{},
+		},
+		{
+			name:   "storage quota exceeded",
+			offset: 5,
+			chunk:  []byte("abcde"),
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(inProgress(), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					AppendChunk(gomock.Any(), "id", int64(5), []byte("abcde")).
+					Return(int64(10), nil).
+					Times(1)
+
+				uploadsService.
+					EXPECT().
+					GetUploadData(gomock.Any(), "id").
+					Return([]byte("0123456789"), nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(
+						gomock.Any(),
+						uint64(1),
+						validationConfig.MasterStorageLimit,
+						uploadKey,
+						[]byte("0123456789"),
+						entities.FileVisibilityPrivate,
+					).
+					Return("", false, &customerrors.StorageQuotaExceededError{}).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.StorageQuotaExceededError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			toysService := mockservices.NewMockToysService(ctrl)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			uploadsService := mockservices.NewMockUploadsService(ctrl)
+			useCases := &UseCases{
+				ssoService:         ssoService,
+				toysService:        toysService,
+				fileStorageService: fileStorageService,
+				uploadsService:     uploadsService,
+				validationConfig:   validationConfig,
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(ssoService, toysService, fileStorageService, uploadsService)
+			}
+
+			upload, err := useCases.WriteUploadChunk(ctx, "valid_token", "id", tc.offset, tc.chunk)
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, upload)
+		})
+	}
+}
+
+func TestUseCases_useUploads(t *testing.T) {
+	testCases := []struct {
+		name       string
+		visibility entities.FileVisibility
+		setupMocks func(
+			fileStorageService *mockservices.MockFileStorageService,
+			uploadsService *mockservices.MockUploadsService,
+		)
+		expected      []string
+		errorExpected bool
+		expectedError error
+	}{
+		{
+			name:       "public files",
+			visibility: entities.FileVisibilityPublic,
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg", Link: "link"}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), uploadKey, entities.FileVisibilityPublic).
+					Return(nil).
+					Times(1)
+			},
+			expected: []string{"link"},
+		},
+		{
+			name:       "private files",
+			visibility: entities.FileVisibilityPrivate,
+			setupMocks: func(
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg", Link: "link"}, nil).
+					Times(1)
+			},
+			expected: []string{"link"},
+		},
+		{
+			name:       "not completed upload",
+			visibility: entities.FileVisibilityPublic,
+			setupMocks: func(
+				_ *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg"}, nil).
+					Times(1)
+			},
+			errorExpected: true,
+			expectedError: &customerrors.UploadNotCompletedError{},
+		},
+		{
+			name:       "set visibility error",
+			visibility: entities.FileVisibilityPublic,
+			setupMocks: func(
+				fileStorageService *mockservices.MockFileStorageService,
+				uploadsService *mockservices.MockUploadsService,
+			) {
+				uploadsService.
+					EXPECT().
+					GetUpload(gomock.Any(), "id").
+					Return(&entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg", Link: "link"}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					SetVisibility(gomock.Any(), uploadKey, entities.FileVisibilityPublic).
+					Return(errors.New("acl error")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			uploadsService := mockservices.NewMockUploadsService(ctrl)
+			useCases := &UseCases{
+				fileStorageService: fileStorageService,
+				uploadsService:     uploadsService,
+				validationConfig:   validationConfig,
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(fileStorageService, uploadsService)
+			}
+
+			links, err := useCases.useUploads(ctx, 1, []string{"id"}, tc.visibility)
+			if tc.errorExpected {
+				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, links)
+		})
+	}
+}
+
+type errorReader struct {
+	err error
+}
+
+func TestUseCases_GetUserStorageUsage(t *testing.T) {
+	testCases := []struct {
+		name       string
+		userID     uint64
+		setupMocks func(
+			ssoService *mockservices.MockSsoService,
+			toysService *mockservices.MockToysService,
+			fileStorageService *mockservices.MockFileStorageService,
+		)
+		expected      *entities.StorageUsage
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					GetUsedBytes(gomock.Any(), uint64(1)).
+					Return(int64(1024), nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+			},
+			expected: &entities.StorageUsage{
+				UsedBytes:  1024,
+				LimitBytes: validationConfig.MasterStorageLimit,
+			},
+		},
+		{
+			name:   "storage usage of another user",
+			userID: 2,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:   "get me error",
+			userID: 1,
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				_ *mockservices.MockToysService,
+				_ *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
 			errorExpected: true,
 		},
 		{
-			name:   "upload error",
+			name:   "get used bytes error",
 			userID: 1,
-			file: &graphql.Upload{
-				File:     strings.NewReader("test content"),
-				Filename: "test.jpg",
-			},
 			setupMocks: func(
-				_ *mockservices.MockSsoService,
+				ssoService *mockservices.MockSsoService,
 				_ *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
-				_ *mockservices.MockTicketsService,
-				_ *mockservices.MockNotificationsService,
-				_ *mocklogger.MockLogger,
-				_ *tracingmock.MockProvider,
 			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload failed")).
+					GetUsedBytes(gomock.Any(), uint64(1)).
+					Return(int64(0), errors.New("redis error")).
 					Times(1)
 			},
-			expected:      "",
-			errorExpected: true,
-		},
-		{
-			name:   "read file error",
-			userID: 1,
-			file: &graphql.Upload{
-				File:     &errorReader{err: errors.New("read error")},
-				Filename: "test.jpg",
-				Size:     1024,
-			},
-			expected:      "",
 			errorExpected: true,
 		},
 	}
 
-	ctrl := gomock.NewController(t)
-	ssoService := mockservices.NewMockSsoService(ctrl)
-	toysService := mockservices.NewMockToysService(ctrl)
-	ticketsService := mockservices.NewMockTicketsService(ctrl)
-	notificationsService := mockservices.NewMockNotificationsService(ctrl)
-	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
-	logger := mocklogger.NewMockLogger(ctrl)
-	traceProvider := tracingmock.NewMockProvider(ctrl)
-	useCases := New(
-		ssoService,
-		toysService,
-		fileStorageService,
-		ticketsService,
-		notificationsService,
-		validationConfig,
-		logger,
-		traceProvider,
-	)
-
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			toysService := mockservices.NewMockToysService(ctrl)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			useCases := &UseCases{
+				ssoService:         ssoService,
+				toysService:        toysService,
+				fileStorageService: fileStorageService,
+				validationConfig:   validationConfig,
+			}
+
 			if tc.setupMocks != nil {
-				tc.setupMocks(
-					ssoService,
-					toysService,
-					fileStorageService,
-					ticketsService,
-					notificationsService,
-					logger,
-					traceProvider,
-				)
+				tc.setupMocks(ssoService, toysService, fileStorageService)
 			}
 
-			actual, err := useCases.UploadFile(ctx, tc.userID, tc.file)
+			usage, err := useCases.GetUserStorageUsage(ctx, "valid_token", tc.userID)
 			if tc.errorExpected {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
 			}
 
-			require.Equal(t, tc.expected, actual)
+			require.Equal(t, tc.expected, usage)
 		})
 	}
 }
 
-// errorReader - вспомогательная структура для имитации ошибки чтения
-type errorReader struct {
-	err error
-}
-
 func (r *errorReader) Seek(offset int64, whence int) (int64, error) {
 	return 0, nil
 }
@@ -2273,6 +3552,8 @@ func TestUseCases_createFilename(t *testing.T) {
 			toysService := mockservices.NewMockToysService(ctrl)
 			ticketsService := mockservices.NewMockTicketsService(ctrl)
 			notificationsService := mockservices.NewMockNotificationsService(ctrl)
+			compensationsService := mockservices.NewMockCompensationsService(ctrl)
+			uploadsService := mockservices.NewMockUploadsService(ctrl)
 			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 			logger := mocklogger.NewMockLogger(ctrl)
 			traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -2283,6 +3564,8 @@ func TestUseCases_createFilename(t *testing.T) {
 				fileStorageService,
 				ticketsService,
 				notificationsService,
+				compensationsService,
+				uploadsService,
 				tc.validationConfig,
 				logger,
 				traceProvider,
@@ -2301,6 +3584,16 @@ func TestUseCases_createFilename(t *testing.T) {
 	}
 }
 
+func TestUploadFilename(t *testing.T) {
+	upload := &entities.ResumableUpload{ID: "id", UserID: 1, Filename: "photo.jpg"}
+	require.Equal(t, uploadKey, uploadFilename(upload))
+
+	// Upload of file with the same name does not overwrite file of other upload:
+	otherUpload := &entities.ResumableUpload{ID: "other-id", UserID: 1, Filename: "photo.jpg"}
+	require.NotEqual(t, uploadFilename(upload), uploadFilename(otherUpload))
+	require.NotEqual(t, security.RawEncode([]byte("1:photo.jpg"))+".jpg", uploadFilename(upload))
+}
+
 func TestUseCases_GetToys(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -2525,6 +3818,8 @@ func TestUseCases_GetToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -2534,6 +3829,8 @@ func TestUseCases_GetToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -2683,6 +3980,8 @@ func TestUseCases_CountToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -2692,6 +3991,8 @@ func TestUseCases_CountToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -2848,6 +4149,8 @@ func TestUseCases_CountMasterToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -2857,6 +4160,8 @@ func TestUseCases_CountMasterToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -3058,6 +4363,8 @@ func TestUseCases_CountMyToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -3067,6 +4374,8 @@ func TestUseCases_CountMyToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -3329,6 +4638,8 @@ func TestUseCases_GetMasterToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -3338,6 +4649,8 @@ func TestUseCases_GetMasterToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -3655,6 +4968,8 @@ func TestUseCases_GetMyToys(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -3664,6 +4979,8 @@ func TestUseCases_GetMyToys(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -3710,6 +5027,7 @@ func TestUseCases_GetToyByID(t *testing.T) {
 		)
 		expected      *entities.Toy
 		errorExpected bool
+		expectedError error
 	}{
 		{
 			name: "success",
@@ -3763,11 +5081,12 @@ func TestUseCases_GetToyByID(t *testing.T) {
 				toysService.
 					EXPECT().
 					GetToyByID(gomock.Any(), uint64(999)).
-					Return(nil, errors.New("toy not found")).
+					Return(nil, &customerrors.NotFoundError{Message: "toy not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
+			expectedError: &customerrors.NotFoundError{},
 		},
 	}
 
@@ -3776,6 +5095,8 @@ func TestUseCases_GetToyByID(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -3785,6 +5106,8 @@ func TestUseCases_GetToyByID(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -3807,6 +5130,10 @@ func TestUseCases_GetToyByID(t *testing.T) {
 			actual, err := useCases.GetToyByID(ctx, tc.id)
 			if tc.errorExpected {
 				require.Error(t, err)
+
+				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -4144,6 +5471,8 @@ func TestUseCases_DeleteTicket(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		nil,              // notificationsService not needed
+		nil,              // compensationsService not needed
+		nil,              // uploadsService not needed
 		validationConfig, // validationConfig not needed
 		logger,
 		traceProvider,
@@ -4384,12 +5713,12 @@ func TestUseCases_GetTicketByID(t *testing.T) {
 				ticketsService.
 					EXPECT().
 					GetTicketByID(gomock.Any(), uint64(999)).
-					Return(nil, errors.New("ticket not found")).
+					Return(nil, &customerrors.NotFoundError{Message: "ticket not found"}).
 					Times(1)
 			},
 			expected:      nil,
 			errorExpected: true,
-			expectedError: errors.New("ticket not found"),
+			expectedError: &customerrors.NotFoundError{Message: "ticket not found"},
 		},
 		{
 			name: "tags service error (soft processing)",
@@ -4410,7 +5739,7 @@ func TestUseCases_GetTicketByID(t *testing.T) {
 				toysService.
 					EXPECT().
 					GetAllTags(gomock.Any()).
-					Return(nil, errors.New("service unavailable")).
+					Return(nil, &customerrors.UnavailableError{Message: "service unavailable"}).
 					Times(1)
 			},
 			expected: &entities.Ticket{
@@ -4452,6 +5781,7 @@ func TestUseCases_GetTicketByID(t *testing.T) {
 			if tc.errorExpected {
 				require.Error(t, err)
 				if tc.expectedError != nil {
+					require.IsType(t, tc.expectedError, err)
 					require.EqualError(t, err, tc.expectedError.Error())
 				}
 				require.Nil(t, result)
@@ -4463,6 +5793,274 @@ func TestUseCases_GetTicketByID(t *testing.T) {
 	}
 }
 
+func TestUseCases_GetTicketAttachments(t *testing.T) {
+	testTicket := entities.Ticket{
+		ID:     1,
+		UserID: 1,
+		Attachments: []entities.TicketAttachment{
+			{ID: 1, TicketID: 1, Link: "https://bucket/file1.jpg"},
+			{ID: 2, TicketID: 1, Link: "https://bucket/file2.jpg"},
+		},
+	}
+
+	presignedAttachments := []entities.TicketAttachment{
+		{ID: 1, TicketID: 1, Link: "https://presigned/file1.jpg"},
+		{ID: 2, TicketID: 1, Link: "https://presigned/file2.jpg"},
+	}
+
+	setupPresign := func(fileStorageService *mockservices.MockFileStorageService) {
+		fileStorageService.
+			EXPECT().
+			GetPresignedURL(gomock.Any(), "file1.jpg").
+			Return("https://presigned/file1.jpg", nil).
+			Times(1)
+
+		fileStorageService.
+			EXPECT().
+			GetPresignedURL(gomock.Any(), "file2.jpg").
+			Return("https://presigned/file2.jpg", nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name        string
+		ticket      entities.Ticket
+		accessToken string
+		setupMocks  func(
+			ssoService *mockservices.MockSsoService,
+			toysService *mockservices.MockToysService,
+			fileStorageService *mockservices.MockFileStorageService,
+		)
+		expected      []entities.TicketAttachment
+		errorExpected bool
+	}{
+		{
+			name:        "ticket owner",
+			ticket:      testTicket,
+			accessToken: "valid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				setupPresign(fileStorageService)
+			},
+			expected: presignedAttachments,
+		},
+		{
+			name:        "master",
+			ticket:      testTicket,
+			accessToken: "valid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 2}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(2)).
+					Return(&entities.Master{ID: 1, UserID: 2}, nil).
+					Times(1)
+
+				setupPresign(fileStorageService)
+			},
+			expected: presignedAttachments,
+		},
+		{
+			name:        "user is neither owner nor master",
+			ticket:      testTicket,
+			accessToken: "valid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 2}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(2)).
+					Return(nil, &customerrors.NotFoundError{Message: "master not found"}).
+					Times(1)
+			},
+		},
+		{
+			name:        "get master error",
+			ticket:      testTicket,
+			accessToken: "valid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 2}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(2)).
+					Return(nil, &customerrors.UnavailableError{Message: "upstream toys is unavailable"}).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:   "anonymous user",
+			ticket: testTicket,
+		},
+		{
+			name:        "ticket without attachments",
+			ticket:      entities.Ticket{ID: 1, UserID: 1},
+			accessToken: "valid_token",
+		},
+		{
+			name:        "expired access token",
+			ticket:      testTicket,
+			accessToken: "expired_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "expired_token").
+					Return(nil, &customerrors.UnauthenticatedError{Message: "token expired"}).
+					Times(1)
+			},
+		},
+		{
+			name:        "get me error",
+			ticket:      testTicket,
+			accessToken: "invalid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "invalid_token").
+					Return(nil, errors.New("invalid token")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+		{
+			name:        "presign error",
+			ticket:      testTicket,
+			accessToken: "valid_token",
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+			) {
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_token").
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					GetPresignedURL(gomock.Any(), "file1.jpg").
+					Return("", errors.New("presign failed")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ssoService := mockservices.NewMockSsoService(ctrl)
+			toysService := mockservices.NewMockToysService(ctrl)
+			fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+			useCases := &UseCases{
+				ssoService:         ssoService,
+				toysService:        toysService,
+				fileStorageService: fileStorageService,
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(ssoService, toysService, fileStorageService)
+			}
+
+			attachments, err := useCases.GetTicketAttachments(context.Background(), tc.ticket, tc.accessToken)
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, attachments)
+		})
+	}
+}
+
+func TestUseCases_GetTicketAttachments_ViewerIsResolvedOncePerRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ssoService := mockservices.NewMockSsoService(ctrl)
+	toysService := mockservices.NewMockToysService(ctrl)
+	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
+	useCases := &UseCases{
+		ssoService:         ssoService,
+		toysService:        toysService,
+		fileStorageService: fileStorageService,
+	}
+
+	ssoService.
+		EXPECT().
+		GetMe(gomock.Any(), "valid_token").
+		Return(&entities.User{ID: 2}, nil).
+		Times(1)
+
+	toysService.
+		EXPECT().
+		GetMasterByUserID(gomock.Any(), uint64(2)).
+		Return(&entities.Master{ID: 1, UserID: 2}, nil).
+		Times(1)
+
+	fileStorageService.
+		EXPECT().
+		GetPresignedURL(gomock.Any(), gomock.Any()).
+		Return("https://presigned/file.jpg", nil).
+		Times(2)
+
+	ctx := requestcache.WithCache(context.Background(), requestcache.New())
+	for ticketID := range uint64(2) {
+		ticket := entities.Ticket{
+			ID:          ticketID + 1,
+			UserID:      1,
+			Attachments: []entities.TicketAttachment{{ID: ticketID + 1, Link: "https://bucket/file.jpg"}},
+		}
+
+		attachments, err := useCases.GetTicketAttachments(ctx, ticket, "valid_token")
+		require.NoError(t, err)
+		require.Len(t, attachments, 1)
+	}
+}
+
 func TestUseCases_UpdateTicket(t *testing.T) {
 	type args struct {
 		ctx           context.Context
@@ -4587,10 +6185,16 @@ func TestUseCases_UpdateTicket(t *testing.T) {
 					Times(1)
 
 				// Upload new files
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("http://storage.com/new_file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("http://storage.com/new_file1.jpg", false, nil).
 					Times(1)
 
 				// Update ticket
@@ -4805,14 +6409,20 @@ func TestUseCases_UpdateTicket(t *testing.T) {
 
 				logger.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				// Upload new files
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
 					Times(1)
 
-				// Upload new files
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("http://storage.com/new_file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("http://storage.com/new_file1.jpg", false, nil).
 					Times(1)
 
 				ticketsService.
@@ -4870,10 +6480,16 @@ func TestUseCases_UpdateTicket(t *testing.T) {
 					Times(1)
 
 				// Upload new files
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload error")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("", false, errors.New("upload error")).
 					Times(1)
 
 				logger.
@@ -4982,10 +6598,16 @@ func TestUseCases_UpdateTicket(t *testing.T) {
 					Times(1)
 
 				// Upload new files
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("http://storage.com/new_file1.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("http://storage.com/new_file1.jpg", false, nil).
 					Times(1)
 
 				ticketsService.
@@ -5055,6 +6677,8 @@ func TestUseCases_UpdateTicket(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		nil, // notificationsService not needed
+		nil, // compensationsService not needed
+		nil, // uploadsService not needed
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5167,6 +6791,8 @@ func TestUseCases_GetMasterByID(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -5176,6 +6802,8 @@ func TestUseCases_GetMasterByID(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5369,6 +6997,8 @@ func TestUseCases_GetMasters(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -5378,6 +7008,8 @@ func TestUseCases_GetMasters(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5499,6 +7131,8 @@ func TestUseCases_CountMasters(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -5508,6 +7142,8 @@ func TestUseCases_CountMasters(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5660,6 +7296,8 @@ func TestUseCases_RegisterMaster(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -5669,6 +7307,8 @@ func TestUseCases_RegisterMaster(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5710,6 +7350,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 			fileStorageService *mockservices.MockFileStorageService,
 			ticketsService *mockservices.MockTicketsService,
 			notificationsService *mockservices.MockNotificationsService,
+			compensationsService *mockservices.MockCompensationsService,
 			logger *mocklogger.MockLogger,
 			traceProvider *tracingmock.MockProvider,
 		)
@@ -5736,6 +7377,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				ticketsService *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -5747,10 +7389,16 @@ func TestUseCases_CreateTicket(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/test.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("uploaded/test.jpg", false, nil).
 					Times(1)
 
 				toysService.
@@ -5796,6 +7444,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -5820,10 +7469,11 @@ func TestUseCases_CreateTicket(t *testing.T) {
 			},
 			setupMocks: func(
 				ssoService *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -5835,6 +7485,12 @@ func TestUseCases_CreateTicket(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), user.ID).
+					Return(nil, errors.New("master not found")).
+					Times(1)
+
 				logger.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -5857,6 +7513,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -5868,9 +7525,15 @@ func TestUseCases_CreateTicket(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
 					Times(0) // Нет вложений
 
 				toysService.
@@ -5895,6 +7558,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				ticketsService *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -5906,9 +7570,15 @@ func TestUseCases_CreateTicket(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
 					Times(0) // Нет вложений
 
 				toysService.
@@ -5933,6 +7603,75 @@ func TestUseCases_CreateTicket(t *testing.T) {
 			expected:      0,
 			errorExpected: true,
 		},
+		{
+			name: "create ticket error compensates uploaded attachments",
+			rawTicketData: entities.RawCreateTicketDTO{
+				AccessToken: "valid_access_token",
+				CategoryID:  1,
+				Name:        "Test Ticket",
+				Attachments: []*graphql.Upload{
+					{Filename: "test.jpg", Size: 1024, File: strings.NewReader("test content")},
+				},
+			},
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				ticketsService *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				user := &entities.User{ID: 1}
+
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_access_token").
+					Return(user, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPrivate).
+					Return("https://bucket/uploaded.jpg", false, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					CreateTags(gomock.Any(), []entities.CreateTagDTO{}).
+					Return(nil, nil).
+					Times(1)
+
+				ticketsService.
+					EXPECT().
+					CreateTicket(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("ticket creation failed")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "CreateTicket",
+							Step:     "UploadFiles",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"uploaded.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+			expected:      0,
+			errorExpected: true,
+		},
 		{
 			name: "too many tags",
 			rawTicketData: entities.RawCreateTicketDTO{
@@ -5975,6 +7714,8 @@ func TestUseCases_CreateTicket(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -5984,6 +7725,8 @@ func TestUseCases_CreateTicket(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -5998,6 +7741,7 @@ func TestUseCases_CreateTicket(t *testing.T) {
 					fileStorageService,
 					ticketsService,
 					notificationsService,
+					compensationsService,
 					logger,
 					traceProvider,
 				)
@@ -6340,6 +8084,8 @@ func TestUseCases_GetRespondByID(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -6349,6 +8095,8 @@ func TestUseCases_GetRespondByID(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -6390,6 +8138,7 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 			fileStorageService *mockservices.MockFileStorageService,
 			ticketsService *mockservices.MockTicketsService,
 			notificationsService *mockservices.MockNotificationsService,
+			compensationsService *mockservices.MockCompensationsService,
 			logger *mocklogger.MockLogger,
 			traceProvider *tracingmock.MockProvider,
 		)
@@ -6406,10 +8155,11 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 			},
 			setupMocks: func(
 				ssoService *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6424,16 +8174,16 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
-				fileStorageService.
+				toysService.
 					EXPECT().
-					Delete(gomock.Any(), "old_avatar.jpg").
-					Return(nil).
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/new_avatar.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/new_avatar.jpg", false, nil).
 					Times(1)
 
 				ssoService.
@@ -6447,6 +8197,20 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					}).
 					Return(nil).
 					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "UpdateUserProfile",
+							Step:     "DeleteOldAvatar",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"old_avatar.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
 			},
 			errorExpected: false,
 		},
@@ -6464,6 +8228,7 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6507,6 +8272,7 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6533,6 +8299,7 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6560,11 +8327,12 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 			},
 			setupMocks: func(
 				ssoService *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
-				_ *mocklogger.MockLogger,
+				compensationsService *mockservices.MockCompensationsService,
+				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
 				user := &entities.User{
@@ -6578,13 +8346,49 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Delete(gomock.Any(), "old_avatar.jpg").
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/new_avatar.jpg", false, nil).
+					Times(1)
+
+				ssoService.
+					EXPECT().
+					UpdateUserProfile(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
 					Return(errors.New("delete failed")).
 					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(
+						gomock.Any(),
+						gomock.Cond(func(compensation entities.Compensation) bool {
+							return compensation.Step == "DeleteOldAvatar" &&
+								compensation.Attempts == 1 &&
+								compensation.Error == "delete failed"
+						}),
+					).
+					Return(nil).
+					Times(1)
 			},
-			errorExpected: true,
+			errorExpected: false,
 		},
 		{
 			name: "upload new avatar error",
@@ -6597,10 +8401,11 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 			},
 			setupMocks: func(
 				ssoService *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6615,16 +8420,16 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
-				fileStorageService.
+				toysService.
 					EXPECT().
-					Delete(gomock.Any(), "old_avatar.jpg").
-					Return(nil).
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload failed")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("upload failed")).
 					Times(1)
 			},
 			errorExpected: true,
@@ -6640,10 +8445,11 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 			},
 			setupMocks: func(
 				ssoService *mockservices.MockSsoService,
-				_ *mockservices.MockToysService,
+				toysService *mockservices.MockToysService,
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6658,16 +8464,30 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					Return(user, nil).
 					Times(1)
 
-				fileStorageService.
+				toysService.
 					EXPECT().
-					Delete(gomock.Any(), "old_avatar.jpg").
-					Return(nil).
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/new_avatar.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/new_avatar.jpg", false, nil).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "UpdateUserProfile",
+							Step:     "UploadFile",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"new_avatar.jpg"},
+						},
+					).
+					Return(nil).
 					Times(1)
 
 				ssoService.
@@ -6691,6 +8511,8 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -6700,6 +8522,8 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -6714,6 +8538,7 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 					fileStorageService,
 					ticketsService,
 					notificationsService,
+					compensationsService,
 					logger,
 					traceProvider,
 				)
@@ -6739,6 +8564,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 			fileStorageService *mockservices.MockFileStorageService,
 			ticketsService *mockservices.MockTicketsService,
 			notificationsService *mockservices.MockNotificationsService,
+			compensationsService *mockservices.MockCompensationsService,
 			logger *mocklogger.MockLogger,
 			traceProvider *tracingmock.MockProvider,
 		)
@@ -6766,6 +8592,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6809,16 +8636,16 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					Return([]uint32{1, 2}, nil).
 					Times(1)
 
-				fileStorageService.
+				toysService.
 					EXPECT().
-					DeleteMany(gomock.Any(), []string{"to_delete.jpg"}).
-					Return([]error{}).
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
 					Times(1)
 
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("uploaded/new_attachment.jpg", nil).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("uploaded/new_attachment.jpg", false, nil).
 					Times(1)
 
 				toysService.
@@ -6835,6 +8662,20 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					}).
 					Return(nil).
 					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "UpdateToy",
+							Step:     "DeleteOldAttachments",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"to_delete.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
 			},
 			errorExpected: false,
 		},
@@ -6850,6 +8691,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6873,6 +8715,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6904,6 +8747,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6943,6 +8787,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -6988,6 +8833,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -7041,6 +8887,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				_ *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -7091,6 +8938,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -7130,17 +8978,6 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					Return([]uint32{}, nil).
 					Times(1)
 
-				fileStorageService.
-					EXPECT().
-					DeleteMany(gomock.Any(), []string{"old_attachment.jpg"}).
-					Return([]error{errors.New("delete failed")}).
-					Times(1)
-
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-
 				toysService.
 					EXPECT().
 					UpdateToy(gomock.Any(), entities.UpdateToyDTO{
@@ -7155,6 +8992,30 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					}).
 					Return(nil).
 					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(gomock.Any(), gomock.Any()).
+					Return(errors.New("delete failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					SaveCompensation(
+						gomock.Any(),
+						gomock.Cond(func(compensation entities.Compensation) bool {
+							return compensation.Step == "DeleteOldAttachments" &&
+								compensation.Attempts == 1 &&
+								compensation.Error == "delete failed"
+						}),
+					).
+					Return(nil).
+					Times(1)
 			},
 			errorExpected: false,
 		},
@@ -7173,6 +9034,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				logger *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -7209,10 +9071,16 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					Return([]uint32{}, nil).
 					Times(1)
 
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
 				fileStorageService.
 					EXPECT().
-					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", errors.New("upload failed")).
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("", false, errors.New("upload failed")).
 					Times(1)
 
 				logger.
@@ -7234,6 +9102,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 				fileStorageService *mockservices.MockFileStorageService,
 				_ *mockservices.MockTicketsService,
 				_ *mockservices.MockNotificationsService,
+				_ *mockservices.MockCompensationsService,
 				_ *mocklogger.MockLogger,
 				_ *tracingmock.MockProvider,
 			) {
@@ -7287,6 +9156,95 @@ func TestUseCases_UpdateToy(t *testing.T) {
 			},
 			errorExpected: true,
 		},
+		{
+			name: "update toy error compensates uploaded attachments and keeps old ones",
+			rawToyData: entities.RawUpdateToyDTO{
+				AccessToken: "valid_access_token",
+				ID:          1,
+				Attachments: []*graphql.Upload{
+					{Filename: "new_attachment.jpg", Size: 1024, File: strings.NewReader("new content")},
+				},
+			},
+			setupMocks: func(
+				ssoService *mockservices.MockSsoService,
+				toysService *mockservices.MockToysService,
+				fileStorageService *mockservices.MockFileStorageService,
+				_ *mockservices.MockTicketsService,
+				_ *mockservices.MockNotificationsService,
+				compensationsService *mockservices.MockCompensationsService,
+				_ *mocklogger.MockLogger,
+				_ *tracingmock.MockProvider,
+			) {
+				user := &entities.User{ID: 1}
+
+				master := &entities.Master{ID: 1}
+
+				toy := &entities.Toy{
+					ID:       1,
+					MasterID: 1,
+					Attachments: []entities.ToyAttachment{
+						{Link: "path/to/old_attachment.jpg"},
+					},
+				}
+
+				ssoService.
+					EXPECT().
+					GetMe(gomock.Any(), "valid_access_token").
+					Return(user, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), uint64(1)).
+					Return(master, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetToyByID(gomock.Any(), uint64(1)).
+					Return(toy, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					CreateTags(gomock.Any(), []entities.CreateTagDTO{}).
+					Return([]uint32{}, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					GetMasterByUserID(gomock.Any(), gomock.Any()).
+					Return(&entities.Master{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				fileStorageService.
+					EXPECT().
+					UploadUserFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), entities.FileVisibilityPublic).
+					Return("path/to/new_attachment.jpg", false, nil).
+					Times(1)
+
+				toysService.
+					EXPECT().
+					UpdateToy(gomock.Any(), gomock.Any()).
+					Return(errors.New("update failed")).
+					Times(1)
+
+				compensationsService.
+					EXPECT().
+					ExecuteCompensation(
+						gomock.Any(),
+						entities.Compensation{
+							Saga:     "UpdateToy",
+							Step:     "UploadFiles",
+							Kind:     entities.CompensationKindDeleteFiles,
+							FileKeys: []string{"new_attachment.jpg"},
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: true,
+		},
 		{
 			name: "too many tags",
 			rawToyData: entities.RawUpdateToyDTO{
@@ -7331,6 +9289,8 @@ func TestUseCases_UpdateToy(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -7340,6 +9300,8 @@ func TestUseCases_UpdateToy(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -7354,6 +9316,7 @@ func TestUseCases_UpdateToy(t *testing.T) {
 					fileStorageService,
 					ticketsService,
 					notificationsService,
+					compensationsService,
 					logger,
 					traceProvider,
 				)
@@ -7746,6 +9709,8 @@ func TestUseCases_DeleteToy(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -7755,6 +9720,8 @@ func TestUseCases_DeleteToy(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -8058,6 +10025,8 @@ func TestUseCases_UpdateRespond(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -8067,6 +10036,8 @@ func TestUseCases_UpdateRespond(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -8344,6 +10315,8 @@ func TestUseCases_DeleteRespond(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -8353,6 +10326,8 @@ func TestUseCases_DeleteRespond(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -8592,6 +10567,8 @@ func TestUseCases_UpdateMaster(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -8601,6 +10578,8 @@ func TestUseCases_UpdateMaster(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -10877,6 +12856,8 @@ func TestUseCases_CountTickets(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -10886,6 +12867,8 @@ func TestUseCases_CountTickets(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -11042,6 +13025,8 @@ func TestUseCases_CountUserTickets(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -11051,6 +13036,8 @@ func TestUseCases_CountUserTickets(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
@@ -11252,6 +13239,8 @@ func TestUseCases_CountMyTickets(t *testing.T) {
 	toysService := mockservices.NewMockToysService(ctrl)
 	ticketsService := mockservices.NewMockTicketsService(ctrl)
 	notificationsService := mockservices.NewMockNotificationsService(ctrl)
+	compensationsService := mockservices.NewMockCompensationsService(ctrl)
+	uploadsService := mockservices.NewMockUploadsService(ctrl)
 	fileStorageService := mockservices.NewMockFileStorageService(ctrl)
 	logger := mocklogger.NewMockLogger(ctrl)
 	traceProvider := tracingmock.NewMockProvider(ctrl)
@@ -11261,6 +13250,8 @@ func TestUseCases_CountMyTickets(t *testing.T) {
 		fileStorageService,
 		ticketsService,
 		notificationsService,
+		compensationsService,
+		uploadsService,
 		validationConfig,
 		logger,
 		traceProvider,
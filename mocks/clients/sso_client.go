@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=clients.go -destination=../../mocks/clients/sso_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,NotificationsClient,S3Client
+//	mockgen -source=clients.go -destination=../../mocks/clients/sso_client.go -package=mockclients -exclude_interfaces=ToysClient,TicketsClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 //
 
 // Package mockclients is a generated GoMock package.
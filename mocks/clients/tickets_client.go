@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=clients.go -destination=../../mocks/clients/tickets_client.go -package=mockclients -exclude_interfaces=ToysClient,SsoClient,NotificationsClient,S3Client
+//	mockgen -source=clients.go -destination=../../mocks/clients/tickets_client.go -package=mockclients -exclude_interfaces=ToysClient,SsoClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 //
 
 // Package mockclients is a generated GoMock package.
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=clients.go -destination=../../mocks/clients/toys_client.go -package=mockclients -exclude_interfaces=SsoClient,TicketsClient,NotificationsClient,S3Client
+//	mockgen -source=clients.go -destination=../../mocks/clients/toys_client.go -package=mockclients -exclude_interfaces=SsoClient,TicketsClient,NotificationsClient,S3Client,RedisClient,S3Presigner
 //
 
 // Package mockclients is a generated GoMock package.
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/cache_tags_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,LocalCacheRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCacheTagsRepository is a mock of CacheTagsRepository interface.
+type MockCacheTagsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheTagsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheTagsRepositoryMockRecorder is the mock recorder for MockCacheTagsRepository.
+type MockCacheTagsRepositoryMockRecorder struct {
+	mock *MockCacheTagsRepository
+}
+
+// NewMockCacheTagsRepository creates a new mock instance.
+func NewMockCacheTagsRepository(ctrl *gomock.Controller) *MockCacheTagsRepository {
+	mock := &MockCacheTagsRepository{ctrl: ctrl}
+	mock.recorder = &MockCacheTagsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheTagsRepository) EXPECT() *MockCacheTagsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *MockCacheTagsRepository) Invalidate(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Invalidate", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCacheTagsRepositoryMockRecorder) Invalidate(ctx any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCacheTagsRepository)(nil).Invalidate), varargs...)
+}
+
+// Invalidations mocks base method.
+func (m *MockCacheTagsRepository) Invalidations(ctx context.Context) <-chan []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidations", ctx)
+	ret0, _ := ret[0].(<-chan []string)
+	return ret0
+}
+
+// Invalidations indicates an expected call of Invalidations.
+func (mr *MockCacheTagsRepositoryMockRecorder) Invalidations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidations", reflect.TypeOf((*MockCacheTagsRepository)(nil).Invalidations), ctx)
+}
+
+// Tag mocks base method.
+func (m *MockCacheTagsRepository) Tag(ctx context.Context, key string, tags []string, ttl time.Duration, loadedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", ctx, key, tags, ttl, loadedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockCacheTagsRepositoryMockRecorder) Tag(ctx, key, tags, ttl, loadedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockCacheTagsRepository)(nil).Tag), ctx, key, tags, ttl, loadedAt)
+}
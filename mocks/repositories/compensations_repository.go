@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/compensations_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository,NotificationsRepository,LocksRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompensationsRepository is a mock of CompensationsRepository interface.
+type MockCompensationsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompensationsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCompensationsRepositoryMockRecorder is the mock recorder for MockCompensationsRepository.
+type MockCompensationsRepositoryMockRecorder struct {
+	mock *MockCompensationsRepository
+}
+
+// NewMockCompensationsRepository creates a new mock instance.
+func NewMockCompensationsRepository(ctrl *gomock.Controller) *MockCompensationsRepository {
+	mock := &MockCompensationsRepository{ctrl: ctrl}
+	mock.recorder = &MockCompensationsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompensationsRepository) EXPECT() *MockCompensationsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// PopCompensation mocks base method.
+func (m *MockCompensationsRepository) PopCompensation(ctx context.Context) (*entities.Compensation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopCompensation", ctx)
+	ret0, _ := ret[0].(*entities.Compensation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopCompensation indicates an expected call of PopCompensation.
+func (mr *MockCompensationsRepositoryMockRecorder) PopCompensation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopCompensation", reflect.TypeOf((*MockCompensationsRepository)(nil).PopCompensation), ctx)
+}
+
+// SaveCompensation mocks base method.
+func (m *MockCompensationsRepository) SaveCompensation(ctx context.Context, compensation entities.Compensation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCompensation", ctx, compensation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCompensation indicates an expected call of SaveCompensation.
+func (mr *MockCompensationsRepositoryMockRecorder) SaveCompensation(ctx, compensation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCompensation", reflect.TypeOf((*MockCompensationsRepository)(nil).SaveCompensation), ctx, compensation)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=repositories.go -destination=../../mocks/repositories/file_storage_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,SsoRepository,TicketsRepository,NotificationsRepository
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/file_storage_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,SsoRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
 //
 
 // Package mockrepositories is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -68,17 +69,61 @@ func (mr *MockFileStorageRepositoryMockRecorder) DeleteMany(ctx, keys any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockFileStorageRepository)(nil).DeleteMany), ctx, keys)
 }
 
+// GetPresignedURL mocks base method.
+func (m *MockFileStorageRepository) GetPresignedURL(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresignedURL", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresignedURL indicates an expected call of GetPresignedURL.
+func (mr *MockFileStorageRepositoryMockRecorder) GetPresignedURL(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedURL", reflect.TypeOf((*MockFileStorageRepository)(nil).GetPresignedURL), ctx, key)
+}
+
+// List mocks base method.
+func (m *MockFileStorageRepository) List(ctx context.Context) ([]entities.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]entities.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFileStorageRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFileStorageRepository)(nil).List), ctx)
+}
+
+// SetVisibility mocks base method.
+func (m *MockFileStorageRepository) SetVisibility(ctx context.Context, key string, visibility entities.FileVisibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVisibility", ctx, key, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVisibility indicates an expected call of SetVisibility.
+func (mr *MockFileStorageRepositoryMockRecorder) SetVisibility(ctx, key, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibility", reflect.TypeOf((*MockFileStorageRepository)(nil).SetVisibility), ctx, key, visibility)
+}
+
 // Upload mocks base method.
-func (m *MockFileStorageRepository) Upload(ctx context.Context, key string, file []byte) (string, error) {
+func (m *MockFileStorageRepository) Upload(ctx context.Context, key string, file []byte, visibility entities.FileVisibility) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upload", ctx, key, file)
+	ret := m.ctrl.Call(m, "Upload", ctx, key, file, visibility)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Upload indicates an expected call of Upload.
-func (mr *MockFileStorageRepositoryMockRecorder) Upload(ctx, key, file any) *gomock.Call {
+func (mr *MockFileStorageRepositoryMockRecorder) Upload(ctx, key, file, visibility any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockFileStorageRepository)(nil).Upload), ctx, key, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockFileStorageRepository)(nil).Upload), ctx, key, file, visibility)
 }
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/local_cache_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLocalCacheRepository is a mock of LocalCacheRepository interface.
+type MockLocalCacheRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocalCacheRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLocalCacheRepositoryMockRecorder is the mock recorder for MockLocalCacheRepository.
+type MockLocalCacheRepositoryMockRecorder struct {
+	mock *MockLocalCacheRepository
+}
+
+// NewMockLocalCacheRepository creates a new mock instance.
+func NewMockLocalCacheRepository(ctrl *gomock.Controller) *MockLocalCacheRepository {
+	mock := &MockLocalCacheRepository{ctrl: ctrl}
+	mock.recorder = &MockLocalCacheRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocalCacheRepository) EXPECT() *MockLocalCacheRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLocalCacheRepository) Get(key string) ([]byte, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLocalCacheRepositoryMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLocalCacheRepository)(nil).Get), key)
+}
+
+// Invalidate mocks base method.
+func (m *MockLocalCacheRepository) Invalidate(tags ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Invalidate", varargs...)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockLocalCacheRepositoryMockRecorder) Invalidate(tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockLocalCacheRepository)(nil).Invalidate), tags...)
+}
+
+// Set mocks base method.
+func (m *MockLocalCacheRepository) Set(key string, value []byte, tags []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", key, value, tags)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockLocalCacheRepositoryMockRecorder) Set(key, value, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLocalCacheRepository)(nil).Set), key, value, tags)
+}
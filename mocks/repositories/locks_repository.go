@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/locks_repository.go -package=mockrepositories -exclude_interfaces=ToysRepository,FileStorageRepository,TicketsRepository,SsoRepository,NotificationsRepository,CompensationsRepository,StorageUsageRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLocksRepository is a mock of LocksRepository interface.
+type MockLocksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocksRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLocksRepositoryMockRecorder is the mock recorder for MockLocksRepository.
+type MockLocksRepositoryMockRecorder struct {
+	mock *MockLocksRepository
+}
+
+// NewMockLocksRepository creates a new mock instance.
+func NewMockLocksRepository(ctrl *gomock.Controller) *MockLocksRepository {
+	mock := &MockLocksRepository{ctrl: ctrl}
+	mock.recorder = &MockLocksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocksRepository) EXPECT() *MockLocksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockLocksRepository) Acquire(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, key, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockLocksRepositoryMockRecorder) Acquire(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockLocksRepository)(nil).Acquire), ctx, key, ttl)
+}
+
+// Release mocks base method.
+func (m *MockLocksRepository) Release(ctx context.Context, key, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLocksRepositoryMockRecorder) Release(ctx, key, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLocksRepository)(nil).Release), ctx, key, token)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/storage_usage_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,UploadsRepository,CacheTagsRepository,LocalCacheRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorageUsageRepository is a mock of StorageUsageRepository interface.
+type MockStorageUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageUsageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageUsageRepositoryMockRecorder is the mock recorder for MockStorageUsageRepository.
+type MockStorageUsageRepositoryMockRecorder struct {
+	mock *MockStorageUsageRepository
+}
+
+// NewMockStorageUsageRepository creates a new mock instance.
+func NewMockStorageUsageRepository(ctrl *gomock.Controller) *MockStorageUsageRepository {
+	mock := &MockStorageUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockStorageUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageUsageRepository) EXPECT() *MockStorageUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUsedBytes mocks base method.
+func (m *MockStorageUsageRepository) GetUsedBytes(ctx context.Context, userID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsedBytes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsedBytes indicates an expected call of GetUsedBytes.
+func (mr *MockStorageUsageRepositoryMockRecorder) GetUsedBytes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedBytes", reflect.TypeOf((*MockStorageUsageRepository)(nil).GetUsedBytes), ctx, userID)
+}
+
+// Release mocks base method.
+func (m *MockStorageUsageRepository) Release(ctx context.Context, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStorageUsageRepositoryMockRecorder) Release(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStorageUsageRepository)(nil).Release), ctx, keys)
+}
+
+// Reserve mocks base method.
+func (m *MockStorageUsageRepository) Reserve(ctx context.Context, userID uint64, key string, size, limit int64) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, userID, key, size, limit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStorageUsageRepositoryMockRecorder) Reserve(ctx, userID, key, size, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStorageUsageRepository)(nil).Reserve), ctx, userID, key, size, limit)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/uploads_repository.go -package=mockrepositories -exclude_interfaces=SsoRepository,ToysRepository,FileStorageRepository,TicketsRepository,NotificationsRepository,LocksRepository,CompensationsRepository,StorageUsageRepository,CacheTagsRepository,LocalCacheRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadsRepository is a mock of UploadsRepository interface.
+type MockUploadsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUploadsRepositoryMockRecorder is the mock recorder for MockUploadsRepository.
+type MockUploadsRepositoryMockRecorder struct {
+	mock *MockUploadsRepository
+}
+
+// NewMockUploadsRepository creates a new mock instance.
+func NewMockUploadsRepository(ctrl *gomock.Controller) *MockUploadsRepository {
+	mock := &MockUploadsRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadsRepository) EXPECT() *MockUploadsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendChunk mocks base method.
+func (m *MockUploadsRepository) AppendChunk(ctx context.Context, id string, offset int64, chunk []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendChunk", ctx, id, offset, chunk)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendChunk indicates an expected call of AppendChunk.
+func (mr *MockUploadsRepositoryMockRecorder) AppendChunk(ctx, id, offset, chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendChunk", reflect.TypeOf((*MockUploadsRepository)(nil).AppendChunk), ctx, id, offset, chunk)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockUploadsRepository) CompleteUpload(ctx context.Context, id, link string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, id, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockUploadsRepositoryMockRecorder) CompleteUpload(ctx, id, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockUploadsRepository)(nil).CompleteUpload), ctx, id, link)
+}
+
+// CreateUpload mocks base method.
+func (m *MockUploadsRepository) CreateUpload(ctx context.Context, upload entities.ResumableUpload) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, upload)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadsRepositoryMockRecorder) CreateUpload(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUploadsRepository)(nil).CreateUpload), ctx, upload)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockUploadsRepository) DeleteUpload(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockUploadsRepositoryMockRecorder) DeleteUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockUploadsRepository)(nil).DeleteUpload), ctx, id)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadsRepository) GetUpload(ctx context.Context, id string) (*entities.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, id)
+	ret0, _ := ret[0].(*entities.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadsRepositoryMockRecorder) GetUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadsRepository)(nil).GetUpload), ctx, id)
+}
+
+// GetUploadData mocks base method.
+func (m *MockUploadsRepository) GetUploadData(ctx context.Context, id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadData", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadData indicates an expected call of GetUploadData.
+func (mr *MockUploadsRepositoryMockRecorder) GetUploadData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadData", reflect.TypeOf((*MockUploadsRepository)(nil).GetUploadData), ctx, id)
+}
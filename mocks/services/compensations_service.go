@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/compensations_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService,NotificationsService,UploadsService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompensationsService is a mock of CompensationsService interface.
+type MockCompensationsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompensationsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCompensationsServiceMockRecorder is the mock recorder for MockCompensationsService.
+type MockCompensationsServiceMockRecorder struct {
+	mock *MockCompensationsService
+}
+
+// NewMockCompensationsService creates a new mock instance.
+func NewMockCompensationsService(ctrl *gomock.Controller) *MockCompensationsService {
+	mock := &MockCompensationsService{ctrl: ctrl}
+	mock.recorder = &MockCompensationsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompensationsService) EXPECT() *MockCompensationsServiceMockRecorder {
+	return m.recorder
+}
+
+// ExecuteCompensation mocks base method.
+func (m *MockCompensationsService) ExecuteCompensation(ctx context.Context, compensation entities.Compensation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteCompensation", ctx, compensation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteCompensation indicates an expected call of ExecuteCompensation.
+func (mr *MockCompensationsServiceMockRecorder) ExecuteCompensation(ctx, compensation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteCompensation", reflect.TypeOf((*MockCompensationsService)(nil).ExecuteCompensation), ctx, compensation)
+}
+
+// PopCompensation mocks base method.
+func (m *MockCompensationsService) PopCompensation(ctx context.Context) (*entities.Compensation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopCompensation", ctx)
+	ret0, _ := ret[0].(*entities.Compensation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopCompensation indicates an expected call of PopCompensation.
+func (mr *MockCompensationsServiceMockRecorder) PopCompensation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopCompensation", reflect.TypeOf((*MockCompensationsService)(nil).PopCompensation), ctx)
+}
+
+// SaveCompensation mocks base method.
+func (m *MockCompensationsService) SaveCompensation(ctx context.Context, compensation entities.Compensation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCompensation", ctx, compensation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCompensation indicates an expected call of SaveCompensation.
+func (mr *MockCompensationsServiceMockRecorder) SaveCompensation(ctx, compensation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCompensation", reflect.TypeOf((*MockCompensationsService)(nil).SaveCompensation), ctx, compensation)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=services.go -destination=../../mocks/services/file_storage_service.go -package=mockservices -exclude_interfaces=ToysService,SsoService,TicketsService,NotificationsService
+//	mockgen -source=services.go -destination=../../mocks/services/file_storage_service.go -package=mockservices -exclude_interfaces=ToysService,SsoService,TicketsService,NotificationsService,CompensationsService,UploadsService
 //
 
 // Package mockservices is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -68,17 +69,92 @@ func (mr *MockFileStorageServiceMockRecorder) DeleteMany(ctx, keys any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockFileStorageService)(nil).DeleteMany), ctx, keys)
 }
 
+// GetPresignedURL mocks base method.
+func (m *MockFileStorageService) GetPresignedURL(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresignedURL", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresignedURL indicates an expected call of GetPresignedURL.
+func (mr *MockFileStorageServiceMockRecorder) GetPresignedURL(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedURL", reflect.TypeOf((*MockFileStorageService)(nil).GetPresignedURL), ctx, key)
+}
+
+// GetUsedBytes mocks base method.
+func (m *MockFileStorageService) GetUsedBytes(ctx context.Context, userID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsedBytes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsedBytes indicates an expected call of GetUsedBytes.
+func (mr *MockFileStorageServiceMockRecorder) GetUsedBytes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedBytes", reflect.TypeOf((*MockFileStorageService)(nil).GetUsedBytes), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockFileStorageService) List(ctx context.Context) ([]entities.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]entities.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFileStorageServiceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFileStorageService)(nil).List), ctx)
+}
+
+// SetVisibility mocks base method.
+func (m *MockFileStorageService) SetVisibility(ctx context.Context, key string, visibility entities.FileVisibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVisibility", ctx, key, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVisibility indicates an expected call of SetVisibility.
+func (mr *MockFileStorageServiceMockRecorder) SetVisibility(ctx, key, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibility", reflect.TypeOf((*MockFileStorageService)(nil).SetVisibility), ctx, key, visibility)
+}
+
 // Upload mocks base method.
-func (m *MockFileStorageService) Upload(ctx context.Context, key string, file []byte) (string, error) {
+func (m *MockFileStorageService) Upload(ctx context.Context, key string, file []byte, visibility entities.FileVisibility) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upload", ctx, key, file)
+	ret := m.ctrl.Call(m, "Upload", ctx, key, file, visibility)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Upload indicates an expected call of Upload.
-func (mr *MockFileStorageServiceMockRecorder) Upload(ctx, key, file any) *gomock.Call {
+func (mr *MockFileStorageServiceMockRecorder) Upload(ctx, key, file, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockFileStorageService)(nil).Upload), ctx, key, file, visibility)
+}
+
+// UploadUserFile mocks base method.
+func (m *MockFileStorageService) UploadUserFile(ctx context.Context, userID uint64, limit int64, key string, file []byte, visibility entities.FileVisibility) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadUserFile", ctx, userID, limit, key, file, visibility)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadUserFile indicates an expected call of UploadUserFile.
+func (mr *MockFileStorageServiceMockRecorder) UploadUserFile(ctx, userID, limit, key, file, visibility any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockFileStorageService)(nil).Upload), ctx, key, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadUserFile", reflect.TypeOf((*MockFileStorageService)(nil).UploadUserFile), ctx, userID, limit, key, file, visibility)
 }
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=services.go -destination=../../mocks/services/notifications_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService
+//	mockgen -source=services.go -destination=../../mocks/services/notifications_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,TicketsService,SsoService,CompensationsService,UploadsService
 //
 
 // Package mockservices is a generated GoMock package.
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=services.go -destination=../../mocks/services/tickets_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,SsoService,NotificationsService
+//	mockgen -source=services.go -destination=../../mocks/services/tickets_service.go -package=mockservices -exclude_interfaces=ToysService,FileStorageService,SsoService,NotificationsService,CompensationsService,UploadsService
 //
 
 // Package mockservices is a generated GoMock package.
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/uploads_service.go -package=mockservices -exclude_interfaces=SsoService,ToysService,FileStorageService,TicketsService,NotificationsService,CompensationsService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/DKhorkov/hmtm-bff/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadsService is a mock of UploadsService interface.
+type MockUploadsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUploadsServiceMockRecorder is the mock recorder for MockUploadsService.
+type MockUploadsServiceMockRecorder struct {
+	mock *MockUploadsService
+}
+
+// NewMockUploadsService creates a new mock instance.
+func NewMockUploadsService(ctrl *gomock.Controller) *MockUploadsService {
+	mock := &MockUploadsService{ctrl: ctrl}
+	mock.recorder = &MockUploadsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadsService) EXPECT() *MockUploadsServiceMockRecorder {
+	return m.recorder
+}
+
+// AppendChunk mocks base method.
+func (m *MockUploadsService) AppendChunk(ctx context.Context, id string, offset int64, chunk []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendChunk", ctx, id, offset, chunk)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendChunk indicates an expected call of AppendChunk.
+func (mr *MockUploadsServiceMockRecorder) AppendChunk(ctx, id, offset, chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendChunk", reflect.TypeOf((*MockUploadsService)(nil).AppendChunk), ctx, id, offset, chunk)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockUploadsService) CompleteUpload(ctx context.Context, id, link string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, id, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockUploadsServiceMockRecorder) CompleteUpload(ctx, id, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockUploadsService)(nil).CompleteUpload), ctx, id, link)
+}
+
+// CreateUpload mocks base method.
+func (m *MockUploadsService) CreateUpload(ctx context.Context, upload entities.ResumableUpload) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, upload)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadsServiceMockRecorder) CreateUpload(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUploadsService)(nil).CreateUpload), ctx, upload)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockUploadsService) DeleteUpload(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockUploadsServiceMockRecorder) DeleteUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockUploadsService)(nil).DeleteUpload), ctx, id)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadsService) GetUpload(ctx context.Context, id string) (*entities.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, id)
+	ret0, _ := ret[0].(*entities.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadsServiceMockRecorder) GetUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadsService)(nil).GetUpload), ctx, id)
+}
+
+// GetUploadData mocks base method.
+func (m *MockUploadsService) GetUploadData(ctx context.Context, id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadData", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadData indicates an expected call of GetUploadData.
+func (mr *MockUploadsServiceMockRecorder) GetUploadData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadData", reflect.TypeOf((*MockUploadsService)(nil).GetUploadData), ctx, id)
+}
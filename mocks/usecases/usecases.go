@@ -206,6 +206,21 @@ func (mr *MockUseCasesMockRecorder) CreateTicket(ctx, rawTicketData any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockUseCases)(nil).CreateTicket), ctx, rawTicketData)
 }
 
+// CreateUpload mocks base method.
+func (m *MockUseCases) CreateUpload(ctx context.Context, accessToken, filename string, length int64) (*entities.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, accessToken, filename, length)
+	ret0, _ := ret[0].(*entities.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUseCasesMockRecorder) CreateUpload(ctx, accessToken, filename, length any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUseCases)(nil).CreateUpload), ctx, accessToken, filename, length)
+}
+
 // DeleteRespond mocks base method.
 func (m *MockUseCases) DeleteRespond(ctx context.Context, accessToken string, id uint64) error {
 	m.ctrl.T.Helper()
@@ -472,6 +487,21 @@ func (mr *MockUseCasesMockRecorder) GetTagByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByID", reflect.TypeOf((*MockUseCases)(nil).GetTagByID), ctx, id)
 }
 
+// GetTicketAttachments mocks base method.
+func (m *MockUseCases) GetTicketAttachments(ctx context.Context, ticket entities.Ticket, accessToken string) ([]entities.TicketAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketAttachments", ctx, ticket, accessToken)
+	ret0, _ := ret[0].([]entities.TicketAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketAttachments indicates an expected call of GetTicketAttachments.
+func (mr *MockUseCasesMockRecorder) GetTicketAttachments(ctx, ticket, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketAttachments", reflect.TypeOf((*MockUseCases)(nil).GetTicketAttachments), ctx, ticket, accessToken)
+}
+
 // GetTicketByID mocks base method.
 func (m *MockUseCases) GetTicketByID(ctx context.Context, id uint64) (*entities.Ticket, error) {
 	m.ctrl.T.Helper()
@@ -547,6 +577,21 @@ func (mr *MockUseCasesMockRecorder) GetToys(ctx, pagination, filters any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToys", reflect.TypeOf((*MockUseCases)(nil).GetToys), ctx, pagination, filters)
 }
 
+// GetUpload mocks base method.
+func (m *MockUseCases) GetUpload(ctx context.Context, accessToken, id string) (*entities.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, accessToken, id)
+	ret0, _ := ret[0].(*entities.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUseCasesMockRecorder) GetUpload(ctx, accessToken, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUseCases)(nil).GetUpload), ctx, accessToken, id)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUseCases) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -577,6 +622,21 @@ func (mr *MockUseCasesMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUseCases)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserStorageUsage mocks base method.
+func (m *MockUseCases) GetUserStorageUsage(ctx context.Context, accessToken string, userID uint64) (*entities.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStorageUsage", ctx, accessToken, userID)
+	ret0, _ := ret[0].(*entities.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStorageUsage indicates an expected call of GetUserStorageUsage.
+func (mr *MockUseCasesMockRecorder) GetUserStorageUsage(ctx, accessToken, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStorageUsage", reflect.TypeOf((*MockUseCases)(nil).GetUserStorageUsage), ctx, accessToken, userID)
+}
+
 // GetUserTickets mocks base method.
 func (m *MockUseCases) GetUserTickets(ctx context.Context, userID uint64, pagination *entities.Pagination, filters *entities.TicketsFilters) ([]entities.Ticket, error) {
 	m.ctrl.T.Helper()
@@ -795,33 +855,33 @@ func (mr *MockUseCasesMockRecorder) UpdateUserProfile(ctx, rawUserProfileData an
 }
 
 // UploadFile mocks base method.
-func (m *MockUseCases) UploadFile(ctx context.Context, userID uint64, files *graphql.Upload) (string, error) {
+func (m *MockUseCases) UploadFile(ctx context.Context, userID uint64, file *graphql.Upload, visibility entities.FileVisibility) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", ctx, userID, files)
+	ret := m.ctrl.Call(m, "UploadFile", ctx, userID, file, visibility)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadFile indicates an expected call of UploadFile.
-func (mr *MockUseCasesMockRecorder) UploadFile(ctx, userID, files any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UploadFile(ctx, userID, file, visibility any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockUseCases)(nil).UploadFile), ctx, userID, files)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockUseCases)(nil).UploadFile), ctx, userID, file, visibility)
 }
 
 // UploadFiles mocks base method.
-func (m *MockUseCases) UploadFiles(ctx context.Context, userID uint64, files []*graphql.Upload) ([]string, error) {
+func (m *MockUseCases) UploadFiles(ctx context.Context, userID uint64, files []*graphql.Upload, visibility entities.FileVisibility) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFiles", ctx, userID, files)
+	ret := m.ctrl.Call(m, "UploadFiles", ctx, userID, files, visibility)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadFiles indicates an expected call of UploadFiles.
-func (mr *MockUseCasesMockRecorder) UploadFiles(ctx, userID, files any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UploadFiles(ctx, userID, files, visibility any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFiles", reflect.TypeOf((*MockUseCases)(nil).UploadFiles), ctx, userID, files)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFiles", reflect.TypeOf((*MockUseCases)(nil).UploadFiles), ctx, userID, files, visibility)
 }
 
 // VerifyUserEmail mocks base method.
@@ -837,3 +897,18 @@ func (mr *MockUseCasesMockRecorder) VerifyUserEmail(ctx, verifyEmailToken any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockUseCases)(nil).VerifyUserEmail), ctx, verifyEmailToken)
 }
+
+// WriteUploadChunk mocks base method.
+func (m *MockUseCases) WriteUploadChunk(ctx context.Context, accessToken, id string, offset int64, chunk []byte) (*entities.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteUploadChunk", ctx, accessToken, id, offset, chunk)
+	ret0, _ := ret[0].(*entities.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteUploadChunk indicates an expected call of WriteUploadChunk.
+func (mr *MockUseCasesMockRecorder) WriteUploadChunk(ctx, accessToken, id, offset, chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteUploadChunk", reflect.TypeOf((*MockUseCases)(nil).WriteUploadChunk), ctx, accessToken, id, offset, chunk)
+}